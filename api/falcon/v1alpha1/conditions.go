@@ -18,19 +18,41 @@ const (
 	ConditionSecretReady     string = "SecretReady"
 	ConditionWebhookReady    string = "WebhookReady"
 
+	ConditionWebhookEndpointsReady string = "WebhookEndpointsReady"
+	ConditionAdmissionBypassed     string = "AdmissionBypassed"
+	ConditionDriftDetected         string = "DriftDetected"
+	ConditionRolloutComplete       string = "RolloutComplete"
+	ConditionNodesSupported        string = "NodesSupported"
+	ConditionNodesReporting        string = "NodesReporting"
+	ConditionHostCleanupReady      string = "HostCleanupReady"
+
 	// Following strings are condition reasons
 
-	ReasonReqNotMet        string = "RequirementsNotMet"
-	ReasonReqMet           string = "RequirementsMet"
-	ReasonInstallSucceeded string = "InstallSucceeded"
-	ReasonInstallFailed    string = "InstallFailed"
-	ReasonSucceeded        string = "Succeeded"
-	ReasonUpdateSucceeded  string = "UpdateSucceeded"
-	ReasonUpdateFailed     string = "UpdateFailed"
-	ReasonDeleteSucceeded  string = "DeleteSucceeded"
-	ReasonDeleteFailed     string = "DeleteFailed"
-	ReasonFailed           string = "Failed"
-	ReasonDiscovered       string = "Discovered"
+	ReasonReqNotMet         string = "RequirementsNotMet"
+	ReasonReqMet            string = "RequirementsMet"
+	ReasonInstallSucceeded  string = "InstallSucceeded"
+	ReasonInstallFailed     string = "InstallFailed"
+	ReasonSucceeded         string = "Succeeded"
+	ReasonUpdateSucceeded   string = "UpdateSucceeded"
+	ReasonUpdateFailed      string = "UpdateFailed"
+	ReasonDeleteSucceeded   string = "DeleteSucceeded"
+	ReasonDeleteFailed      string = "DeleteFailed"
+	ReasonFailed            string = "Failed"
+	ReasonDiscovered        string = "Discovered"
+	ReasonEndpointsReady    string = "EndpointsReady"
+	ReasonNoReadyEndpoints  string = "NoReadyEndpoints"
+	ReasonOutageDetected    string = "OutageDetected"
+	ReasonRecovered         string = "Recovered"
+	ReasonComponentReady    string = "ComponentReady"
+	ReasonComponentNotReady string = "ComponentNotReady"
+	ReasonNoDrift           string = "NoDrift"
+	ReasonDriftCorrected    string = "DriftCorrected"
+	ReasonForeignFields     string = "ForeignFieldsRetained"
+	ReasonRolloutInProgress string = "RolloutInProgress"
+	ReasonUnsupportedNodes  string = "UnsupportedNodes"
+	ReasonNodesNotReporting string = "NodesNotReporting"
+	ReasonHostsQueryFailed  string = "HostsQueryFailed"
+	ReasonAPINotConfigured  string = "FalconAPINotConfigured"
 )
 
 // FalconAdmissionStatus defines the observed state of FalconAdmission
@@ -44,6 +66,10 @@ type FalconCRStatus struct {
 	// Version of the CrowdStrike Falcon Operator
 	Version string `json:"version,omitempty"`
 
+	// Time at which the CrowdStrike registry pull token stored in the operator-managed pull secrets was last fetched
+	// +optional
+	RegistryTokenRefreshTime *metav1.Time `json:"registryTokenRefreshTime,omitempty"`
+
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
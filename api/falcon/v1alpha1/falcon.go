@@ -43,3 +43,75 @@ type FalconSensor struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Trace Level",order=7
 	Trace string `json:"trace,omitempty"`
 }
+
+// FalconProxy configures the application proxy used by the Falcon sensors
+// +k8s:openapi-gen=true
+type FalconProxy struct {
+	// The application proxy host to use for Falcon sensor proxy configuration.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Proxy Host",order=1
+	Host string `json:"host,omitempty"`
+
+	// The application proxy port to use for Falcon sensor proxy configuration.
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=65535
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Proxy Port",order=2,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	Port *int `json:"port,omitempty"`
+}
+
+// PodTemplateOverrideType defines how the patch of a PodTemplateOverride is applied
+// +kubebuilder:validation:Enum=StrategicMerge;JSON
+type PodTemplateOverrideType string
+
+const (
+	// PodTemplateOverrideStrategicMerge applies the patch as a Kubernetes strategic merge patch
+	PodTemplateOverrideStrategicMerge PodTemplateOverrideType = "StrategicMerge"
+	// PodTemplateOverrideJSON applies the patch as an RFC 6902 JSON patch
+	PodTemplateOverrideJSON PodTemplateOverrideType = "JSON"
+)
+
+// PodTemplateOverride patches the pod template of a workload generated by the operator
+// +k8s:openapi-gen=true
+type PodTemplateOverride struct {
+	// Type of the patch. StrategicMerge patches behave like kubectl patch --type=strategic, JSON patches like kubectl patch --type=json.
+	// +kubebuilder:default:=StrategicMerge
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pod Template Override Type",order=1
+	Type PodTemplateOverrideType `json:"type,omitempty"`
+
+	// Patch applied to the pod template (metadata and spec) of the generated workload, written in YAML or JSON.
+	// The labels, service account, container names, container images, host namespaces, security contexts and volumes set by the operator
+	// cannot be patched, and added containers cannot be privileged nor added volumes expose the node.
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pod Template Override Patch",order=2
+	Patch string `json:"patch"`
+}
+
+// FieldOwnershipPolicy defines how the operator handles the fields of its managed resources changed by other field managers
+// +kubebuilder:validation:Enum=Force;RespectForeignFields
+type FieldOwnershipPolicy string
+
+const (
+	// FieldOwnershipForce reverts the changes made by other field managers to the fields set by the operator
+	FieldOwnershipForce FieldOwnershipPolicy = "Force"
+	// FieldOwnershipRespectForeignFields leaves the fields changed by other field managers as they are
+	FieldOwnershipRespectForeignFields FieldOwnershipPolicy = "RespectForeignFields"
+)
+
+// ResourceMetadata configures the labels and annotations added to the resources managed by the operator
+// +k8s:openapi-gen=true
+type ResourceMetadata struct {
+	// Labels added to every resource managed by the operator for this custom resource, including the pods. Labels set by the operator take precedence.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Common Labels"
+	CommonLabels map[string]string `json:"commonLabels,omitempty"`
+
+	// Annotations added to every resource managed by the operator for this custom resource, including the pods. Annotations set by the operator take precedence.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Common Annotations"
+	CommonAnnotations map[string]string `json:"commonAnnotations,omitempty"`
+
+	// Labels added to the pods managed by the operator for this custom resource. Labels set by the operator take precedence.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pod Labels"
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+
+	// Annotations added to the pods managed by the operator for this custom resource. Annotations set by the operator take precedence.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pod Annotations"
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+}
@@ -63,6 +63,8 @@ const (
 	RegistryTypeOpenshift RegistryTypeSpec = "openshift"
 	// RegistryTypeGCR represents Google Container Registry
 	RegistryTypeGCR RegistryTypeSpec = "gcr"
+	// RegistryTypeGAR represents Google Artifact Registry
+	RegistryTypeGAR RegistryTypeSpec = "gar"
 	// RegistryTypeECR represents AWS Elastic Container Registry
 	RegistryTypeECR RegistryTypeSpec = "ecr"
 	// RegistryTypeACR represents Azure Container Registry
@@ -71,10 +73,13 @@ const (
 	RegistryTypeCrowdStrike RegistryTypeSpec = "crowdstrike"
 )
 
+// DefaultGarRepository is the Artifact Registry repository used when gar_repository is not specified
+const DefaultGarRepository = "falcon-sensor"
+
 // RegistrySpec configures container image registry to which the Falcon Container image will be pushed
 type RegistrySpec struct {
 	// Type of container registry to be used
-	// +kubebuilder:validation:Enum=acr;ecr;gcr;crowdstrike;openshift
+	// +kubebuilder:validation:Enum=acr;ecr;gcr;gar;crowdstrike;openshift
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Registry Type",order=1
 	Type RegistryTypeSpec `json:"type"`
 
@@ -85,6 +90,22 @@ type RegistrySpec struct {
 	// Azure Container Registry Name represents the name of the ACR for the Falcon Container push. Only applicable to Azure cloud.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Azure Container Registry Name",order=3
 	AcrName *string `json:"acr_name,omitempty"`
+
+	// Google Artifact Registry Location represents the region or multi-region (e.g. us-central1, europe) of the Artifact Registry for the Falcon Container push. Only applicable to Google cloud.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Google Artifact Registry Location",order=4
+	GarLocation *string `json:"gar_location,omitempty"`
+
+	// Google Artifact Registry Repository represents the name of the Artifact Registry docker repository for the Falcon Container push. The repository is created when it does not exist. Defaults to falcon-sensor. Only applicable to Google cloud.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Google Artifact Registry Repository",order=5
+	GarRepository *string `json:"gar_repository,omitempty"`
+}
+
+// GarRepositoryName returns the Artifact Registry repository name, falling back to the default repository
+func (r *RegistrySpec) GarRepositoryName() string {
+	if r.GarRepository == nil || *r.GarRepository == "" {
+		return DefaultGarRepository
+	}
+	return *r.GarRepository
 }
 
 // ApiConfig generates standard gofalcon library api config
@@ -7,6 +7,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 const (
@@ -15,6 +16,10 @@ const (
 	SnapshotsIntervalDefault       = 22
 	WatcherEnabledDefault          = true
 	AdmissionControlEnabledDefault = true
+	PodDisruptionBudgetDefault     = true
+	ReplicasDefault                = 2
+	FailOpenEnabledDefault         = true
+	FailOpenOutagePeriodDefault    = 5 * time.Minute
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -45,6 +50,10 @@ type FalconAdmissionSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Platform API Configuration",order=2
 	FalconAPI *FalconAPI `json:"falcon_api,omitempty"`
 
+	// Reference to a FalconConfig holding the falcon_api, falconSecret, proxy and registry settings shared with other custom resources. Settings configured on the FalconAdmission take precedence.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Config Reference"
+	FalconConfigRef *FalconConfigReference `json:"falconConfigRef,omitempty"`
+
 	// FalconSecret config is used to inject k8s secrets with sensitive data for the FalconSensor and the FalconAPI.
 	// The following Falcon values are supported by k8s secret injection:
 	//   falcon-cid
@@ -75,12 +84,28 @@ type FalconAdmissionSpec struct {
 	Image string `json:"image,omitempty"`
 
 	// Falcon Admission Controller Version. The latest version will be selected when version specifier is missing. Example: 6.31, 6.31.0, 6.31.0-1409, etc.
+	// Version constraints such as ">=7.10 <7.14", "7.x" or "latest-1" are evaluated against the image tags.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Admission Controller Version",order=9
 	Version *string `json:"version,omitempty"`
 
+	// Advanced configures various options that go against industry practices or are otherwise not recommended for use.
+	// Adjusting these settings may result in incorrect or undesirable behavior. Proceed at your own risk.
+	// For more information, please see https://github.com/CrowdStrike/falcon-operator/blob/main/docs/ADVANCED.md.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Admission Controller Advanced Settings"
+	Advanced FalconAdvanced `json:"advanced,omitempty"`
+
 	// Cluster Name if Falcon KAC cannot discover the cluster name. This will be overwritten if Falcon KAC is able to discover the cluster name.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Admission Cluster Name",order=10
 	ClusterName *string `json:"clusterName,omitempty"`
+
+	// Defines how changes made by other field managers, such as kubectl edit, to the resources managed by the operator are handled.
+	// Force reverts them on the next reconciliation, RespectForeignFields leaves the changed fields alone. Both report the changes in the DriftDetected condition.
+	// +kubebuilder:default:=Force
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Field Ownership",order=11
+	FieldOwnership FieldOwnershipPolicy `json:"fieldOwnership,omitempty"`
+
+	// Labels and annotations added to the resources and pods managed by the operator.
+	ResourceMetadata `json:",inline"`
 }
 
 type FalconAdmissionRQSpec struct {
@@ -126,6 +151,25 @@ type FalconAdmissionConfigSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ignore Namespace List",order=12
 	DisabledNamespaces FalconAdmissionNamespace `json:"disabledNamespaces,omitempty"`
 
+	// Label selector limiting the namespaces validated by the Falcon Admission Controller. It is combined with the disabled namespaces, which are always ignored.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Admission Webhook Namespace Selector",order=21
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Label selector limiting the objects validated by the Falcon Admission Controller.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Admission Webhook Object Selector",order=22
+	ObjectSelector *metav1.LabelSelector `json:"objectSelector,omitempty"`
+
+	// Number of seconds the API server waits for the Falcon Admission Controller before applying the failure policy.
+	// +kubebuilder:default:=10
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=30
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Admission Webhook Timeout Seconds",order=23,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// Select the groups of resources validated by the Falcon Admission Controller.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Admission Webhook Rules",order=24
+	Rules FalconAdmissionRules `json:"rules,omitempty"`
+
 	// Determines if with falcon-watcher container is included in the Pod
 	// +kubebuilder:default:=true
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Deploy Watcher Container",order=13
@@ -158,7 +202,7 @@ type FalconAdmissionConfigSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Image Analyzer Namespace",order=19
 	FalconImageAnalyzerNamespace string `json:"falconImageAnalyzerNamespace,omitempty"`
 
-	// Currently ignored and internally set to 1
+	// Number of Falcon Admission Controller replicas. Running two or more replicas keeps the admission webhook available while a replica is rescheduled or updated.
 	// +kubebuilder:default:=2
 	// +kubebuilder:validation:XIntOrString
 	// +kubebuilder:validation:Minimum:=0
@@ -199,6 +243,107 @@ type FalconAdmissionConfigSpec struct {
 	// Specifies node affinity for scheduling the Admission Controller.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=18
 	NodeAffinity *corev1.NodeAffinity `json:"nodeAffinity,omitempty"`
+
+	// Specifies tolerations for scheduling the Admission Controller on tainted nodes.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=19
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Configure the PodDisruptionBudget protecting the Admission Controller replicas from voluntary disruptions such as node drains.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Admission Controller Pod Disruption Budget",order=20
+	PodDisruptionBudget FalconAdmissionPodDisruptionBudget `json:"podDisruptionBudget,omitempty"`
+
+	// Patch applied to the pod template of the Admission Controller Deployment for settings not exposed by this resource, such as extra sidecars, dnsConfig or hostAliases.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Admission Controller Pod Template Override",order=21
+	PodTemplateOverride *PodTemplateOverride `json:"podTemplateOverride,omitempty"`
+
+	// Configure the safeguard bypassing admission control while no Admission Controller replica is ready, so that a Fail failure policy cannot block every workload in the cluster.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Admission Webhook Fail Open Safeguard",order=25
+	FailOpen FalconAdmissionFailOpen `json:"failOpen,omitempty"`
+}
+
+// FalconAdmissionFailOpenAction defines how admission control is bypassed during an outage
+// +kubebuilder:validation:Enum=Ignore;Remove
+type FalconAdmissionFailOpenAction string
+
+const (
+	// FailOpenActionIgnore switches the failure policy of every admission webhook to Ignore
+	FailOpenActionIgnore FalconAdmissionFailOpenAction = "Ignore"
+	// FailOpenActionRemove deletes the ValidatingWebhookConfiguration
+	FailOpenActionRemove FalconAdmissionFailOpenAction = "Remove"
+)
+
+type FalconAdmissionFailOpen struct {
+	// Determines if admission control is bypassed when the Admission Controller has had no ready endpoint for longer than the outage period.
+	// +kubebuilder:default:=true
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enable Fail Open Safeguard",order=1,xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Time the Admission Controller may have no ready endpoint before admission control is bypassed.
+	// +kubebuilder:default:="5m"
+	// +kubebuilder:validation:Type:=string
+	// +kubebuilder:validation:Format:=duration
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Outage Period",order=2
+	OutagePeriod *metav1.Duration `json:"outagePeriod,omitempty"`
+
+	// How admission control is bypassed: Ignore switches the webhooks to the Ignore failure policy, Remove deletes the ValidatingWebhookConfiguration. Either change is reverted once the Admission Controller is ready again.
+	// +kubebuilder:default:=Ignore
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Fail Open Action",order=3
+	Action FalconAdmissionFailOpenAction `json:"action,omitempty"`
+}
+
+type FalconAdmissionRules struct {
+	// Validate pods and ephemeral containers.
+	// +kubebuilder:default:=true
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Validate Pods",order=1,xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Pods *bool `json:"pods,omitempty"`
+
+	// Validate workloads: deployments, daemonsets, replicasets, statefulsets and replicationcontrollers.
+	// +kubebuilder:default:=true
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Validate Workloads",order=2,xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Workloads *bool `json:"workloads,omitempty"`
+
+	// Validate services.
+	// +kubebuilder:default:=true
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Validate Services",order=3,xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Services *bool `json:"services,omitempty"`
+
+	// Validate batch resources: jobs and cronjobs.
+	// +kubebuilder:default:=true
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Validate Batch Resources",order=4,xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Batch *bool `json:"batch,omitempty"`
+}
+
+// PodsEnabled reports whether pods are validated, defaulting to true
+func (rules FalconAdmissionRules) PodsEnabled() bool {
+	return rules.Pods == nil || *rules.Pods
+}
+
+// WorkloadsEnabled reports whether workloads are validated, defaulting to true
+func (rules FalconAdmissionRules) WorkloadsEnabled() bool {
+	return rules.Workloads == nil || *rules.Workloads
+}
+
+// ServicesEnabled reports whether services are validated, defaulting to true
+func (rules FalconAdmissionRules) ServicesEnabled() bool {
+	return rules.Services == nil || *rules.Services
+}
+
+// BatchEnabled reports whether batch resources are validated, defaulting to true
+func (rules FalconAdmissionRules) BatchEnabled() bool {
+	return rules.Batch == nil || *rules.Batch
+}
+
+type FalconAdmissionPodDisruptionBudget struct {
+	// Determines if a PodDisruptionBudget is created. It is only created when more than one replica is configured.
+	// +kubebuilder:default:=true
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enable Pod Disruption Budget",order=1,xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Minimum number or percentage of Admission Controller replicas that must remain available during voluntary disruptions.
+	// +kubebuilder:default:=1
+	// +kubebuilder:validation:XIntOrString
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Minimum Available Replicas",order=2
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
 }
 
 type FalconAdmissionServiceAccount struct {
@@ -250,6 +395,7 @@ type FalconAdmissionStatus struct {
 //+kubebuilder:resource:scope=Cluster
 //+kubebuilder:printcolumn:name="Operator Version",type="string",JSONPath=".status.version",description="Version of the Operator"
 //+kubebuilder:printcolumn:name="Falcon Sensor",type="string",JSONPath=".status.sensor",description="Version of the Falcon Admission Controller"
+//+kubebuilder:printcolumn:name="Registry Token Age",type="date",JSONPath=".status.registryTokenRefreshTime",description="Age of the CrowdStrike registry pull token",priority=1
 
 // FalconAdmission is the Schema for the falconadmissions API
 type FalconAdmission struct {
@@ -313,6 +459,55 @@ func (ac *FalconAdmission) GetAdmissionControlEnabled() bool {
 	return *ac.Spec.AdmissionConfig.AdmissionControlEnabled
 }
 
+func (ac *FalconAdmission) GetReplicas() int32 {
+	if ac.Spec.AdmissionConfig.Replicas == nil {
+		return ReplicasDefault
+	}
+
+	return *ac.Spec.AdmissionConfig.Replicas
+}
+
+// GetPodDisruptionBudgetEnabled reports whether a PodDisruptionBudget should protect the Admission Controller replicas.
+// A single replica is never protected, as its budget would block node drains.
+func (ac *FalconAdmission) GetPodDisruptionBudgetEnabled() bool {
+	if ac.GetReplicas() < 2 {
+		return false
+	}
+
+	if ac.Spec.AdmissionConfig.PodDisruptionBudget.Enabled == nil {
+		return PodDisruptionBudgetDefault
+	}
+
+	return *ac.Spec.AdmissionConfig.PodDisruptionBudget.Enabled
+}
+
+// GetFailOpenEnabled reports whether admission control is bypassed during an Admission Controller outage
+func (ac *FalconAdmission) GetFailOpenEnabled() bool {
+	if ac.Spec.AdmissionConfig.FailOpen.Enabled == nil {
+		return FailOpenEnabledDefault
+	}
+
+	return *ac.Spec.AdmissionConfig.FailOpen.Enabled
+}
+
+// GetFailOpenOutagePeriod returns how long the Admission Controller may be unavailable before admission control is bypassed
+func (ac *FalconAdmission) GetFailOpenOutagePeriod() time.Duration {
+	if ac.Spec.AdmissionConfig.FailOpen.OutagePeriod == nil {
+		return FailOpenOutagePeriodDefault
+	}
+
+	return ac.Spec.AdmissionConfig.FailOpen.OutagePeriod.Duration
+}
+
+// GetFailOpenAction returns how admission control is bypassed during an Admission Controller outage
+func (ac *FalconAdmission) GetFailOpenAction() FalconAdmissionFailOpenAction {
+	if ac.Spec.AdmissionConfig.FailOpen.Action == "" {
+		return FailOpenActionIgnore
+	}
+
+	return ac.Spec.AdmissionConfig.FailOpen.Action
+}
+
 func (ac *FalconAdmission) GetFalconSecretSpec() FalconSecret {
 	return ac.Spec.FalconSecret
 }
@@ -332,3 +527,19 @@ func (ac *FalconAdmission) GetFalconSpec() FalconSensor {
 func (ac *FalconAdmission) SetFalconSpec(falconSpec FalconSensor) {
 	ac.Spec.Falcon = falconSpec
 }
+
+func (ac *FalconAdmission) GetFalconConfigRef() *FalconConfigReference {
+	return ac.Spec.FalconConfigRef
+}
+
+func (ac *FalconAdmission) SetFalconSecretSpec(falconSecret FalconSecret) {
+	ac.Spec.FalconSecret = falconSecret
+}
+
+func (ac *FalconAdmission) GetRegistrySpec() RegistrySpec {
+	return ac.Spec.Registry
+}
+
+func (ac *FalconAdmission) SetRegistrySpec(registry RegistrySpec) {
+	ac.Spec.Registry = registry
+}
@@ -0,0 +1,62 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FalconConfigSpec defines the connection settings shared by the Falcon custom resources referencing the FalconConfig
+// +k8s:openapi-gen=true
+type FalconConfigSpec struct {
+	// FalconAPI configures connection from your local Falcon operator to CrowdStrike Falcon platform.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Platform API Configuration",order=1
+	FalconAPI *FalconAPI `json:"falcon_api,omitempty"`
+
+	// FalconSecret config is used to inject k8s secrets with sensitive data for the FalconSensor and the FalconAPI.
+	// The following Falcon values are supported by k8s secret injection:
+	//   falcon-cid
+	//   falcon-provisioning-token
+	//   falcon-client-id
+	//   falcon-client-secret
+	// +kubebuilder:default={"enabled": false}
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Platform Secrets Configuration",order=2
+	FalconSecret FalconSecret `json:"falconSecret,omitempty"`
+
+	// Proxy used by the Falcon sensors to reach the CrowdStrike Falcon cloud.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Proxy",order=3
+	Proxy FalconProxy `json:"proxy,omitempty"`
+
+	// Registry configures container image registry to which registry image will be pushed. Does not apply to FalconNodeSensor.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Registry Configuration",order=4
+	Registry *RegistrySpec `json:"registry,omitempty"`
+}
+
+// FalconConfigReference references a FalconConfig by name
+type FalconConfigReference struct {
+	// Name of the FalconConfig
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+
+// FalconConfig is the Schema for the falconconfigs API
+type FalconConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec FalconConfigSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// FalconConfigList contains a list of FalconConfig
+type FalconConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FalconConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FalconConfig{}, &FalconConfigList{})
+}
@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	arv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -29,6 +30,10 @@ type FalconContainerSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Platform API Configuration",order=2
 	FalconAPI *FalconAPI `json:"falcon_api,omitempty"`
 
+	// Reference to a FalconConfig holding the falcon_api, falconSecret, proxy and registry settings shared with other custom resources. Settings configured on the FalconContainer take precedence.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Config Reference"
+	FalconConfigRef *FalconConfigReference `json:"falconConfigRef,omitempty"`
+
 	// FalconSecret config is used to inject k8s secrets with sensitive data for the FalconSensor and the FalconAPI.
 	// The following Falcon values are supported by k8s secret injection:
 	//   falcon-cid
@@ -53,6 +58,7 @@ type FalconContainerSpec struct {
 	Image *string `json:"image,omitempty"`
 
 	// Falcon Container Version. The latest version will be selected when version specifier is missing; ignored when Image is set.
+	// Version constraints such as ">=7.10 <7.14", "7.x" or "latest-1" are evaluated against the image tags.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Container Image Version",order=7
 	Version *string `json:"version,omitempty"`
 
@@ -60,11 +66,24 @@ type FalconContainerSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=7
 	NodeAffinity *corev1.NodeAffinity `json:"nodeAffinity,omitempty"`
 
+	// Specifies tolerations for scheduling the Container Sensor injector on tainted nodes.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=8
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
 	// Advanced configures various options that go against industry practices or are otherwise not recommended for use.
 	// Adjusting these settings may result in incorrect or undesirable behavior. Proceed at your own risk.
 	// For more information, please see https://github.com/CrowdStrike/falcon-operator/blob/main/docs/ADVANCED.md.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Container Advanced Settings"
 	Advanced FalconAdvanced `json:"advanced,omitempty"`
+
+	// Defines how changes made by other field managers, such as kubectl edit, to the resources managed by the operator are handled.
+	// Force reverts them on the next reconciliation, RespectForeignFields leaves the changed fields alone. Both report the changes in the DriftDetected condition.
+	// +kubebuilder:default:=Force
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Field Ownership"
+	FieldOwnership FieldOwnershipPolicy `json:"fieldOwnership,omitempty"`
+
+	// Labels and annotations added to the resources and pods managed by the operator.
+	ResourceMetadata `json:",inline"`
 }
 
 type FalconContainerInjectorSpec struct {
@@ -124,6 +143,38 @@ type FalconContainerInjectorSpec struct {
 	// +kubebuilder:default=false
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enable Alternate Mount Path", order=14
 	AlternateMountPath bool `json:"alternateMountPath,omitempty"`
+
+	// Label selector limiting the namespaces that receive the CrowdStrike registry pull secret. When unset, every namespace not opted out of injection receives it.
+	// Pull secrets are removed from namespaces that stop matching, so the selector should match every namespace targeted for injection.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pull Secret Namespace Selector",order=15
+	PullSecretNamespaceSelector *metav1.LabelSelector `json:"pullSecretNamespaceSelector,omitempty"`
+
+	// Label selector limiting the pods sent to the Falcon Container Injector. Pods labelled sensor.falcon-system.crowdstrike.com/injection=disabled are always skipped.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Container Injector Object Selector",order=16
+	ObjectSelector *metav1.LabelSelector `json:"objectSelector,omitempty"`
+
+	// Configure the failure policy of the Falcon Container Injector mutating webhook. Ignore admits pods without the sensor when the injector is unavailable.
+	// +kubebuilder:default:=Fail
+	// +kubebuilder:validation:Enum=Ignore;Fail
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Container Injector Failure Policy",order=17
+	FailurePolicy arv1.FailurePolicyType `json:"failurePolicy,omitempty"`
+
+	// Number of seconds the API server waits for the Falcon Container Injector before applying the failure policy.
+	// +kubebuilder:default:=30
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=30
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Container Injector Timeout Seconds",order=18,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// Configure whether the Falcon Container Injector is called again when other mutating webhooks modify the pod after injection.
+	// +kubebuilder:default:=Never
+	// +kubebuilder:validation:Enum=Never;IfNeeded
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Container Injector Reinvocation Policy",order=19
+	ReinvocationPolicy arv1.ReinvocationPolicyType `json:"reinvocationPolicy,omitempty"`
+
+	// Patch applied to the pod template of the Falcon Container Injector Deployment for settings not exposed by this resource, such as extra sidecars, dnsConfig or hostAliases.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Container Injector Pod Template Override",order=20
+	PodTemplateOverride *PodTemplateOverride `json:"podTemplateOverride,omitempty"`
 }
 
 type FalconContainerServiceAccount struct {
@@ -151,6 +202,10 @@ type FalconContainerStatus struct {
 	// Version of the CrowdStrike Falcon Operator
 	Version string `json:"version,omitempty"`
 
+	// Time at which the CrowdStrike registry pull token stored in the operator-managed pull secrets was last fetched
+	// +optional
+	RegistryTokenRefreshTime *metav1.Time `json:"registryTokenRefreshTime,omitempty"`
+
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
@@ -160,6 +215,7 @@ type FalconContainerStatus struct {
 //+kubebuilder:resource:scope=Cluster
 //+kubebuilder:printcolumn:name="Operator Version",type="string",JSONPath=".status.version",description="Version of the Operator"
 //+kubebuilder:printcolumn:name="Falcon Sensor",type="string",JSONPath=".status.sensor",description="Version of the Falcon Container"
+//+kubebuilder:printcolumn:name="Registry Token Age",type="date",JSONPath=".status.registryTokenRefreshTime",description="Age of the CrowdStrike registry pull token",priority=1
 
 // FalconContainer is the Schema for the falconcontainers API
 type FalconContainer struct {
@@ -202,3 +258,19 @@ func (fc *FalconContainer) GetFalconSpec() FalconSensor {
 func (fc *FalconContainer) SetFalconSpec(falconSpec FalconSensor) {
 	fc.Spec.Falcon = falconSpec
 }
+
+func (fc *FalconContainer) GetFalconConfigRef() *FalconConfigReference {
+	return fc.Spec.FalconConfigRef
+}
+
+func (fc *FalconContainer) SetFalconSecretSpec(falconSecret FalconSecret) {
+	fc.Spec.FalconSecret = falconSecret
+}
+
+func (fc *FalconContainer) GetRegistrySpec() RegistrySpec {
+	return fc.Spec.Registry
+}
+
+func (fc *FalconContainer) SetRegistrySpec(registry RegistrySpec) {
+	fc.Spec.Registry = registry
+}
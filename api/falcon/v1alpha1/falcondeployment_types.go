@@ -1,9 +1,17 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	FalconDeploymentAdmissionNameDefault       = "falcon-kac"
+	FalconDeploymentNodeSensorNameDefault      = "falcon-node-sensor"
+	FalconDeploymentImageAnalyzerNameDefault   = "falcon-image-analyzer"
+	FalconDeploymentContainerSensorNameDefault = "falcon-container-sensor"
+)
+
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
@@ -21,6 +29,10 @@ type FalconDeploymentSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Platform API Configuration",order=1
 	FalconAPI *FalconAPI `json:"falcon_api,omitempty"`
 
+	// Reference to a FalconConfig holding the falcon_api, falconSecret, proxy and registry settings shared with other custom resources. Settings configured on the FalconDeployment take precedence.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Config Reference"
+	FalconConfigRef *FalconConfigReference `json:"falconConfigRef,omitempty"`
+
 	// Registry configures container image registry to which registry image will be pushed.
 	// +kubebuilder:default:={"type": "crowdstrike"}
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Registry Configuration for FalconAdmission, FalconImageanalyzer, and FalconContainer",order=2
@@ -75,6 +87,93 @@ type FalconDeploymentSpec struct {
 	// +kubebuilder:default:={}
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Container Sensor Configuration",order=11
 	FalconContainerSensor FalconContainerSpec `json:"falconContainerSensor,omitempty"`
+
+	// Name of the FalconAdmission custom resource managed by the FalconDeployment
+	// +kubebuilder:default:=falcon-kac
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Admission Controller Name",order=12
+	FalconAdmissionName string `json:"falconAdmissionName,omitempty"`
+
+	// Name of the FalconNodeSensor custom resource managed by the FalconDeployment. Ignored when falconNodeSensors is set.
+	// +kubebuilder:default:=falcon-node-sensor
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Node Sensor Name",order=13
+	FalconNodeSensorName string `json:"falconNodeSensorName,omitempty"`
+
+	// Name of the FalconImageAnalyzer custom resource managed by the FalconDeployment
+	// +kubebuilder:default:=falcon-image-analyzer
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Image Analyzer Name",order=14
+	FalconImageAnalyzerName string `json:"falconImageAnalyzerName,omitempty"`
+
+	// Name of the FalconContainer custom resource managed by the FalconDeployment
+	// +kubebuilder:default:=falcon-container-sensor
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Container Sensor Name",order=15
+	FalconContainerSensorName string `json:"falconContainerSensorName,omitempty"`
+
+	// Deploy several FalconNodeSensor custom resources, for example one per node pool. When set, it replaces falconNodeSensor and falconNodeSensorName.
+	// The node affinities of the entries should not overlap, so that each node runs a single Falcon Node Sensor.
+	// +listType=map
+	// +listMapKey=name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Node Sensors",order=16
+	FalconNodeSensors []FalconDeploymentNodeSensor `json:"falconNodeSensors,omitempty"`
+
+	// Adopt standalone Falcon custom resources instead of creating new ones. A standalone resource is adopted when its name matches
+	// a component of the FalconDeployment, or when it is the only resource of a kind the FalconDeployment deploys once. The spec of an
+	// adopted resource is merged into the FalconDeployment, so that the running sensors are not changed by the migration.
+	// +kubebuilder:default:=false
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Adopt Existing Falcon Resources",order=17,xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	AdoptExistingResources *bool `json:"adoptExistingResources,omitempty"`
+
+	// Settings shared by every component deployed by the FalconDeployment. A setting configured on a component takes precedence over the shared one.
+	// +kubebuilder:default:={}
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Shared Component Settings",order=18
+	Common FalconDeploymentCommonSpec `json:"common,omitempty"`
+}
+
+// FalconDeploymentCommonSpec configures the settings shared by every component deployed by a FalconDeployment
+type FalconDeploymentCommonSpec struct {
+	// Proxy used by the Falcon Node Sensor, the Falcon Admission Controller and the Falcon Container Sensor to reach the CrowdStrike Falcon cloud.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Proxy",order=1
+	Proxy FalconProxy `json:"proxy,omitempty"`
+
+	// Tolerations added to the tolerations of every component.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=2
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Name of the priority class used by the Falcon Node Sensor and the Falcon Image Analyzer. The Falcon Admission Controller always runs with the system-cluster-critical priority class.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Priority Class Name",order=3
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// ImagePullSecrets added to the Falcon Node Sensor, the Falcon Admission Controller and the Falcon Image Analyzer for pulling images from a private registry.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=4
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// Node affinity of every component that does not set its own.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=5
+	NodeAffinity *corev1.NodeAffinity `json:"nodeAffinity,omitempty"`
+
+	// Name of the cluster reported by the Falcon Admission Controller and the Falcon Image Analyzer, and available to the sensor grouping tag templates of the Falcon Node Sensor.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cluster Name",order=6
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// Labels added to every custom resource managed by the FalconDeployment and, through their commonLabels, to the resources they manage.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=7
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// FalconDeploymentNodeSensor configures one of several FalconNodeSensor custom resources managed by a FalconDeployment
+type FalconDeploymentNodeSensor struct {
+	// Name of the FalconNodeSensor custom resource
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Node Sensor Name",order=1
+	Name string `json:"name"`
+
+	// Falcon Node Sensor configuration
+	// +kubebuilder:default:={}
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Node Sensor Configuration",order=2
+	Spec FalconNodeSensorSpec `json:"spec,omitempty"`
 }
 
 // FalconDeploymentStatus defines the observed state of FalconDeployment
@@ -88,15 +187,63 @@ type FalconDeploymentStatus struct {
 	// Version of the CrowdStrike Falcon Operator
 	Version string `json:"version,omitempty"`
 
+	// Health of the Falcon Admission Controller, when deployed
+	// +optional
+	FalconAdmission *FalconDeploymentComponentStatus `json:"falconAdmission,omitempty"`
+
+	// Health of every Falcon Node Sensor, when deployed
+	// +optional
+	FalconNodeSensors []FalconDeploymentComponentStatus `json:"falconNodeSensors,omitempty"`
+
+	// Health of the Falcon Image Analyzer, when deployed
+	// +optional
+	FalconImageAnalyzer *FalconDeploymentComponentStatus `json:"falconImageAnalyzer,omitempty"`
+
+	// Health of the Falcon Container Sensor, when deployed
+	// +optional
+	FalconContainerSensor *FalconDeploymentComponentStatus `json:"falconContainerSensor,omitempty"`
+
+	// Standalone custom resources adopted by the FalconDeployment
+	// +optional
+	AdoptedResources []FalconDeploymentAdoptedResource `json:"adoptedResources,omitempty"`
+
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// FalconDeploymentAdoptedResource records a standalone custom resource adopted by a FalconDeployment
+type FalconDeploymentAdoptedResource struct {
+	// Kind of the adopted custom resource
+	Kind string `json:"kind"`
+
+	// Name of the adopted custom resource
+	Name string `json:"name"`
+
+	// Time at which the custom resource was adopted
+	AdoptionTime metav1.Time `json:"adoptionTime"`
+}
+
+// FalconDeploymentComponentStatus summarizes the health of a custom resource managed by a FalconDeployment
+type FalconDeploymentComponentStatus struct {
+	// Name of the managed custom resource
+	Name string `json:"name"`
+
+	// Version of the CrowdStrike Falcon Sensor deployed by the component
+	Sensor *string `json:"sensor,omitempty"`
+
+	// Determines if the component reports a successful installation of its current configuration
+	Ready bool `json:"ready"`
+
+	// Details about the health of the component
+	Message string `json:"message,omitempty"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:scope=Cluster
 //+kubebuilder:printcolumn:name="Operator Version",type="string",JSONPath=".status.version",description="Version of the Operator"
 //+kubebuilder:printcolumn:name="Falcon Sensor",type="string",JSONPath=".status.sensor",description="Version of the Falcon Container"
+//+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Success\")].status",description="Whether every deployed component is healthy"
 
 // FalconDeployment is the Schema for the falcondeployments API
 type FalconDeployment struct {
@@ -119,3 +266,50 @@ type FalconDeploymentList struct {
 func init() {
 	SchemeBuilder.Register(&FalconDeployment{}, &FalconDeploymentList{})
 }
+
+func (fd *FalconDeployment) GetAdoptExistingResources() bool {
+	if fd.Spec.AdoptExistingResources == nil {
+		return false
+	}
+
+	return *fd.Spec.AdoptExistingResources
+}
+
+func (fd *FalconDeployment) GetFalconAdmissionName() string {
+	if fd.Spec.FalconAdmissionName == "" {
+		return FalconDeploymentAdmissionNameDefault
+	}
+
+	return fd.Spec.FalconAdmissionName
+}
+
+func (fd *FalconDeployment) GetFalconImageAnalyzerName() string {
+	if fd.Spec.FalconImageAnalyzerName == "" {
+		return FalconDeploymentImageAnalyzerNameDefault
+	}
+
+	return fd.Spec.FalconImageAnalyzerName
+}
+
+func (fd *FalconDeployment) GetFalconContainerSensorName() string {
+	if fd.Spec.FalconContainerSensorName == "" {
+		return FalconDeploymentContainerSensorNameDefault
+	}
+
+	return fd.Spec.FalconContainerSensorName
+}
+
+// GetFalconNodeSensors returns the FalconNodeSensor custom resources to deploy: the falconNodeSensors entries when set,
+// otherwise a single FalconNodeSensor configured by falconNodeSensor
+func (fd *FalconDeployment) GetFalconNodeSensors() []FalconDeploymentNodeSensor {
+	if len(fd.Spec.FalconNodeSensors) > 0 {
+		return fd.Spec.FalconNodeSensors
+	}
+
+	name := fd.Spec.FalconNodeSensorName
+	if name == "" {
+		name = FalconDeploymentNodeSensorNameDefault
+	}
+
+	return []FalconDeploymentNodeSensor{{Name: name, Spec: fd.Spec.FalconNodeSensor}}
+}
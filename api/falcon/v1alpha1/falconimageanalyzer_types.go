@@ -28,6 +28,10 @@ type FalconImageAnalyzerSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Platform API Configuration",order=2
 	FalconAPI *FalconAPI `json:"falcon_api,omitempty"`
 
+	// Reference to a FalconConfig holding the falcon_api, falconSecret, proxy and registry settings shared with other custom resources. Settings configured on the FalconImageAnalyzer take precedence.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Config Reference"
+	FalconConfigRef *FalconConfigReference `json:"falconConfigRef,omitempty"`
+
 	// Registry configures container image registry to which the Image Analyzer image will be pushed.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Image Analyzer Registry Configuration",order=4
 	Registry RegistrySpec `json:"registry,omitempty"`
@@ -52,12 +56,36 @@ type FalconImageAnalyzerSpec struct {
 	Image string `json:"image,omitempty"`
 
 	// Falcon Image Analyzer Version. The latest version will be selected when version specifier is missing. Example: 6.31, 6.31.0, 6.31.0-1409, etc.
+	// Version constraints such as ">=7.10 <7.14", "7.x" or "latest-1" are evaluated against the image tags.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Image Analyzer Version",order=7
 	Version *string `json:"version,omitempty"`
 
+	// Advanced configures various options that go against industry practices or are otherwise not recommended for use.
+	// Adjusting these settings may result in incorrect or undesirable behavior. Proceed at your own risk.
+	// For more information, please see https://github.com/CrowdStrike/falcon-operator/blob/main/docs/ADVANCED.md.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Image Analyzer Advanced Settings"
+	Advanced FalconAdvanced `json:"advanced,omitempty"`
+
 	// Specifies node affinity for scheduling the Sensor.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=8
 	NodeAffinity *corev1.NodeAffinity `json:"nodeAffinity,omitempty"`
+
+	// Specifies tolerations for scheduling the Image Analyzer on tainted nodes.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=9
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Patch applied to the pod template of the Image Analyzer Deployment for settings not exposed by this resource, such as extra sidecars, dnsConfig or hostAliases.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Image Analyzer Pod Template Override",order=10
+	PodTemplateOverride *PodTemplateOverride `json:"podTemplateOverride,omitempty"`
+
+	// Defines how changes made by other field managers, such as kubectl edit, to the resources managed by the operator are handled.
+	// Force reverts them on the next reconciliation, RespectForeignFields leaves the changed fields alone. Both report the changes in the DriftDetected condition.
+	// +kubebuilder:default:=Force
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Field Ownership",order=11
+	FieldOwnership FieldOwnershipPolicy `json:"fieldOwnership,omitempty"`
+
+	// Labels and annotations added to the resources and pods managed by the operator.
+	ResourceMetadata `json:",inline"`
 }
 
 type FalconImageAnalyzerConfigSpec struct {
@@ -223,6 +251,7 @@ type FalconImageAnalyzerStatus struct {
 //+kubebuilder:resource:scope=Cluster
 //+kubebuilder:printcolumn:name="Operator Version",type="string",JSONPath=".status.version",description="Version of the Operator"
 //+kubebuilder:printcolumn:name="Falcon Sensor",type="string",JSONPath=".status.sensor",description="Version of the Falcon Image Analyzer"
+//+kubebuilder:printcolumn:name="Registry Token Age",type="date",JSONPath=".status.registryTokenRefreshTime",description="Age of the CrowdStrike registry pull token",priority=1
 
 // FalconImageAnalyzer is the Schema for the falconImageAnalyzers API
 type FalconImageAnalyzer struct {
@@ -265,3 +294,19 @@ func (fia *FalconImageAnalyzer) GetFalconSpec() FalconSensor {
 func (fia *FalconImageAnalyzer) SetFalconSpec(FalconSensor) {
 	// noop
 }
+
+func (fia *FalconImageAnalyzer) GetFalconConfigRef() *FalconConfigReference {
+	return fia.Spec.FalconConfigRef
+}
+
+func (fia *FalconImageAnalyzer) SetFalconSecretSpec(falconSecret FalconSecret) {
+	fia.Spec.FalconSecret = falconSecret
+}
+
+func (fia *FalconImageAnalyzer) GetRegistrySpec() RegistrySpec {
+	return fia.Spec.Registry
+}
+
+func (fia *FalconImageAnalyzer) SetRegistrySpec(registry RegistrySpec) {
+	fia.Spec.Registry = registry
+}
@@ -39,6 +39,10 @@ type FalconNodeSensorSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Platform API Configuration",order=1
 	FalconAPI *FalconAPI `json:"falcon_api,omitempty"`
 
+	// Reference to a FalconConfig holding the falcon_api, falconSecret, proxy and registry settings shared with other custom resources. Settings configured on the FalconNodeSensor take precedence.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon Config Reference"
+	FalconConfigRef *FalconConfigReference `json:"falconConfigRef,omitempty"`
+
 	// FalconSecret config is used to inject k8s secrets with sensitive data for the FalconSensor and the FalconAPI.
 	// The following Falcon values are supported by k8s secret injection:
 	//   falcon-cid
@@ -51,6 +55,15 @@ type FalconNodeSensorSpec struct {
 
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Configurations used for internal testing",order=6
 	Internal FalconInternal `json:"internal,omitempty"`
+
+	// Defines how changes made by other field managers, such as kubectl edit, to the resources managed by the operator are handled.
+	// Force reverts them on the next reconciliation, RespectForeignFields leaves the changed fields alone. Both report the changes in the DriftDetected condition.
+	// +kubebuilder:default:=Force
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Field Ownership",order=7
+	FieldOwnership FieldOwnershipPolicy `json:"fieldOwnership,omitempty"`
+
+	// Labels and annotations added to the resources and pods managed by the operator.
+	ResourceMetadata `json:",inline"`
 }
 
 // FalconNodeSensorConfig defines aspects about how the daemonset works.
@@ -100,13 +113,20 @@ type FalconNodeSensorConfig struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=8
 	NodeCleanup *bool `json:"disableCleanup,omitempty"`
 
+	// Maximum time to wait for the cleanup DaemonSet to clean up the nodes when the FalconNodeSensor is deleted.
+	// Nodes not cleaned up in time are reported in the status and the deletion of the FalconNodeSensor proceeds.
+	// +kubebuilder:default:="5m"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Node Cleanup Timeout"
+	CleanupTimeout *metav1.Duration `json:"cleanupTimeout,omitempty"`
+
 	// Configure resource requests and limits for the DaemonSet Sensor. Only applies when using the eBPF backend.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon eBPF Sensor Resources",order=9
 	SensorResources Resources `json:"resources,omitempty"`
 
-	// Sets the backend to be used by the DaemonSet Sensor.
+	// Sets the backend to be used by the DaemonSet Sensor. The auto backend selects the backend of each node from its kernel version, OS image and architecture,
+	// and reports the nodes not supported by any backend in the status instead of running the sensor on them.
 	// +kubebuilder:default=bpf
-	// +kubebuilder:validation:Enum=kernel;bpf
+	// +kubebuilder:validation:Enum=kernel;bpf;auto
 	// +operator-sdk-csv:customresourcedefinitions:type=spec,order=10
 	Backend string `json:"backend,omitempty"`
 
@@ -119,7 +139,35 @@ type FalconNodeSensorConfig struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Priority Class",order=12
 	PriorityClass PriorityClassConfig `json:"priorityClass,omitempty"`
 
+	// Patch applied to the pod template of the DaemonSet for settings not exposed by this resource, such as dnsConfig, hostAliases or runtimeClassName.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="DaemonSet Pod Template Override",order=13
+	PodTemplateOverride *PodTemplateOverride `json:"podTemplateOverride,omitempty"`
+
+	// Node pools running the sensor with a different configuration, each rendered as its own DaemonSet and ConfigMap.
+	// A node is managed by the first node pool whose node selector matches its labels. The other nodes run the sensor configured by this resource.
+	// Node pools are not supported on GKE Autopilot.
+	// +listType=map
+	// +listMapKey=name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Node Pools",order=14
+	NodePools []FalconNodePool `json:"nodePools,omitempty"`
+
+	// Sensor grouping tag templates rendered for each node and added to the sensor grouping tags. Templates use the Go template syntax
+	// with the fields .ClusterName, .Namespace, .NodeName, .NodePool and .Labels, the labels of the node. Characters not allowed in tags are replaced with '-'.
+	// Tag templates are not supported on GKE Autopilot.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Sensor Grouping Tag Templates",order=15
+	TagTemplates []string `json:"tagTemplates,omitempty"`
+
+	// Name of the cluster, available to the sensor grouping tag templates as .ClusterName.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cluster Name",order=16
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// Hides or tags the hosts of deleted nodes in the Falcon platform, so that autoscaled nodes do not leave stale hosts in the Falcon console.
+	// Requires CrowdStrike API credentials with the Hosts: Read and Write permissions.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Deleted Node Host Cleanup",order=17
+	HostCleanup FalconNodeHostCleanup `json:"hostCleanup,omitempty"`
+
 	// Version of the sensor to be installed. The latest version will be selected when this version specifier is missing.
+	// Version constraints such as ">=7.10 <7.14", "7.x" or "latest-1" are evaluated against the image tags.
 	Version *string `json:"version,omitempty"`
 
 	// Advanced configures various options that go against industry practices or are otherwise not recommended for use.
@@ -129,6 +177,68 @@ type FalconNodeSensorConfig struct {
 	Advanced FalconAdvanced `json:"advanced,omitempty"`
 }
 
+// FalconNodePool overrides the sensor configuration on the nodes matching a node selector
+type FalconNodePool struct {
+	// Name of the node pool, used as a suffix of the names of its DaemonSet and ConfigMap.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=20
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=1
+	Name string `json:"name"`
+
+	// Labels a node must have to belong to the node pool.
+	// +kubebuilder:validation:MinProperties=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=2
+	NodeSelector map[string]string `json:"nodeSelector"`
+
+	// Sets the backend to be used by the sensor on the nodes of the pool. Defaults to the backend of the FalconNodeSensor.
+	// +kubebuilder:validation:Enum=kernel;bpf;auto
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=3
+	Backend string `json:"backend,omitempty"`
+
+	// Sensor grouping tags of the nodes of the pool. Replaces the tags of the FalconNodeSensor when set.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Sensor Grouping Tags",order=4
+	Tags []string `json:"tags,omitempty"`
+
+	// Sets the sensor trace level on the nodes of the pool. Defaults to the trace level of the FalconNodeSensor.
+	// +kubebuilder:validation:Enum=none;err;warn;info;debug
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Trace Level",order=5
+	Trace string `json:"trace,omitempty"`
+
+	// Configure resource requests and limits for the sensor on the nodes of the pool. Only applies when using the eBPF backend.
+	// Replaces the resources of the FalconNodeSensor when set.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Falcon eBPF Sensor Resources",order=6
+	SensorResources *Resources `json:"resources,omitempty"`
+}
+
+// FalconNodeHostCleanup configures the cleanup of the hosts of deleted nodes in the Falcon platform
+type FalconNodeHostCleanup struct {
+	// Enables the cleanup of the hosts of deleted nodes. Default is false.
+	// +kubebuilder:default:=false
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=1
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Action taken on the hosts of a deleted node: hide them from the Falcon console, or add a sensor grouping tag to them.
+	// +kubebuilder:validation:Enum=hide;tag
+	// +kubebuilder:default:=hide
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=2
+	Action string `json:"action,omitempty"`
+
+	// Sensor grouping tag added to the hosts of deleted nodes with the tag action.
+	// +kubebuilder:validation:Pattern:="^[a-zA-Z0-9/_-]+$"
+	// +kubebuilder:default:=decommissioned
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=3
+	Tag string `json:"tag,omitempty"`
+
+	// Time to wait after the deletion of a node before its hosts are cleaned up. Nodes recreated with the same name within the grace period,
+	// and hosts still reporting after it, are left alone. Default is 1h.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=4
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+
+	// Records the hosts that would be hidden or tagged in Events without changing them.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=5
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
 type PriorityClassConfig struct {
 	// Enables the operator to deploy a PriorityClass instead of rolling your own. Default is false.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Deploy Priority Class to cluster",order=2
@@ -212,15 +322,178 @@ type FalconNodeSensorStatus struct {
 	// Version of the CrowdStrike Falcon Operator
 	Version string `json:"version,omitempty"`
 
+	// Time at which the CrowdStrike registry pull token stored in the operator-managed pull secrets was last fetched
+	// +optional
+	RegistryTokenRefreshTime *metav1.Time `json:"registryTokenRefreshTime,omitempty"`
+
+	// Progress of the rollout of the Falcon Node Sensor DaemonSets, including those of the node pools, to the nodes of the cluster
+	// +optional
+	Rollout *FalconNodeRolloutStatus `json:"rollout,omitempty"`
+
+	// Progress of the cleanup of the nodes started when the FalconNodeSensor is deleted
+	// +optional
+	Cleanup *FalconNodeCleanupStatus `json:"cleanup,omitempty"`
+
+	// Nodes that left the scheduling scope of the DaemonSet and whose sensor files are being cleaned up or could not be cleaned up.
+	// Nodes are removed from the list once they have been cleaned up.
+	// +optional
+	ScopeCleanup []FalconNodeCleanupResult `json:"scopeCleanup,omitempty"`
+
+	// Nodes not supported by any backend of the sensor, which do not run the sensor. Only reported with the auto backend.
+	// +optional
+	UnsupportedNodes []FalconNodeUnsupportedNode `json:"unsupportedNodes,omitempty"`
+
+	// Enrollment of the nodes running the sensor in the Falcon platform, as reported by the Falcon Hosts API.
+	// Only reported when CrowdStrike API credentials are configured.
+	// +optional
+	Enrollment *FalconNodeEnrollmentStatus `json:"enrollment,omitempty"`
+
+	// Deleted nodes whose hosts are waiting to be cleaned up in the Falcon platform. Nodes are removed from the list once their hosts
+	// have been handled or after 6 failed attempts, which is recorded in Events of the FalconNodeSensor.
+	// +listType=map
+	// +listMapKey=node
+	// +optional
+	DeletedNodes []FalconNodeDeletedNode `json:"deletedNodes,omitempty"`
+
 	// Conditions represent the latest available observations of an object's state
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// FalconNodeCleanupStatus reports the progress of the cleanup of the nodes when the FalconNodeSensor is deleted
+type FalconNodeCleanupStatus struct {
+	// Time at which the cleanup DaemonSet was created
+	StartTime metav1.Time `json:"startTime"`
+
+	// Time at which the cleanup finished, timed out or was skipped
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Result of the cleanup on each node scheduled to run the cleanup DaemonSet
+	// +optional
+	Nodes []FalconNodeCleanupResult `json:"nodes,omitempty"`
+}
+
+// FalconNodeCleanupState is the state of the cleanup of a node
+// +kubebuilder:validation:Enum=Pending;Completed;Failed;TimedOut
+type FalconNodeCleanupState string
+
+const (
+	// FalconNodeCleanupPending is the state of a node whose cleanup pod has not run yet
+	FalconNodeCleanupPending FalconNodeCleanupState = "Pending"
+	// FalconNodeCleanupCompleted is the state of a node on which the sensor files have been removed
+	FalconNodeCleanupCompleted FalconNodeCleanupState = "Completed"
+	// FalconNodeCleanupFailed is the state of a node whose cleanup pod failed or is crashlooping
+	FalconNodeCleanupFailed FalconNodeCleanupState = "Failed"
+	// FalconNodeCleanupTimedOut is the state of a node not cleaned up before the cleanup timeout or a forced finalization
+	FalconNodeCleanupTimedOut FalconNodeCleanupState = "TimedOut"
+)
+
+// FalconNodeCleanupResult is the result of the cleanup of a node
+type FalconNodeCleanupResult struct {
+	// Name of the node
+	Node string `json:"node"`
+
+	// State of the cleanup of the node
+	State FalconNodeCleanupState `json:"state"`
+}
+
+// FalconNodeUnsupportedNode is a node not supported by any backend of the sensor
+type FalconNodeUnsupportedNode struct {
+	// Name of the node
+	Node string `json:"node"`
+
+	// Reason why the node is not supported, such as its kernel version or architecture
+	Reason string `json:"reason"`
+}
+
+// FalconNodeDeletedNode is a deleted node whose hosts are waiting to be cleaned up in the Falcon platform
+type FalconNodeDeletedNode struct {
+	// Name of the node
+	Node string `json:"node"`
+
+	// Hostnames the sensor of the node may have reported
+	Hostnames []string `json:"hostnames"`
+
+	// Time at which the deletion of the node was observed
+	DeletionTime metav1.Time `json:"deletionTime"`
+
+	// Time of the last failed attempt to clean up the hosts of the node
+	// +optional
+	LastAttemptTime *metav1.Time `json:"lastAttemptTime,omitempty"`
+
+	// Number of failed attempts to clean up the hosts of the node. The node is dropped after 6 failed attempts.
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// Error of the last failed attempt to clean up the hosts of the node
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// FalconNodeEnrollmentStatus reports the enrollment of the nodes running the sensor in the Falcon platform
+type FalconNodeEnrollmentStatus struct {
+	// Time at which the hosts of the nodes were last queried from the Falcon Hosts API
+	LastCheckTime metav1.Time `json:"lastCheckTime"`
+
+	// Number of nodes running the sensor whose host reported to the Falcon platform within the last hour
+	ReportingNodes int32 `json:"reportingNodes"`
+
+	// Number of nodes running the sensor without a host in the Falcon platform, or whose host has not reported within the last hour
+	NotReportingNodes int32 `json:"notReportingNodes"`
+
+	// Enrollment of each node running the sensor, listing the nodes not reporting first. The list is truncated on large clusters,
+	// the counts always cover all the nodes.
+	// +listType=map
+	// +listMapKey=node
+	// +optional
+	Nodes []FalconNodeEnrollment `json:"nodes,omitempty"`
+}
+
+// FalconNodeEnrollment is the host registered in the Falcon platform for a node running the sensor
+type FalconNodeEnrollment struct {
+	// Name of the node
+	Node string `json:"node"`
+
+	// Agent ID of the host, empty when the node has no host in the Falcon platform
+	// +optional
+	AID string `json:"aid,omitempty"`
+
+	// Time at which the host last reported to the Falcon platform
+	// +optional
+	LastSeen *metav1.Time `json:"lastSeen,omitempty"`
+
+	// Version of the sensor reported by the host
+	// +optional
+	SensorVersion string `json:"sensorVersion,omitempty"`
+
+	// Whether the host reported to the Falcon platform within the last hour
+	Reporting bool `json:"reporting"`
+}
+
+// FalconNodeRolloutStatus reports the progress of the rollout of the Falcon Node Sensor DaemonSet
+type FalconNodeRolloutStatus struct {
+	// Number of nodes that should be running the Falcon Node Sensor
+	DesiredNumberScheduled int32 `json:"desiredNumberScheduled"`
+
+	// Number of nodes running the latest Falcon Node Sensor pod template
+	UpdatedNumberScheduled int32 `json:"updatedNumberScheduled"`
+
+	// Number of nodes running an available Falcon Node Sensor pod
+	NumberAvailable int32 `json:"numberAvailable"`
+
+	// Number of nodes that should be running the Falcon Node Sensor but have no available pod
+	// +optional
+	NumberUnavailable int32 `json:"numberUnavailable,omitempty"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:scope=Cluster
 //+kubebuilder:printcolumn:name="Operator Version",type="string",JSONPath=".status.version",description="Version of the Operator"
 //+kubebuilder:printcolumn:name="Falcon Sensor",type="string",JSONPath=".status.sensor",description="Version of the Falcon Sensor"
+//+kubebuilder:printcolumn:name="Updated",type="integer",JSONPath=".status.rollout.updatedNumberScheduled",description="Number of nodes running the latest Falcon Node Sensor pod template"
+//+kubebuilder:printcolumn:name="Available",type="integer",JSONPath=".status.rollout.numberAvailable",description="Number of nodes running an available Falcon Node Sensor pod"
+//+kubebuilder:printcolumn:name="Registry Token Age",type="date",JSONPath=".status.registryTokenRefreshTime",description="Age of the CrowdStrike registry pull token",priority=1
 
 // FalconNodeSensor is the Schema for the falconnodesensors API
 // +k8s:openapi-gen=true
@@ -251,6 +524,22 @@ func (node *FalconNodeSensor) GetTolerations() *[]corev1.Toleration {
 	return node.Spec.Node.Tolerations
 }
 
+// GetNodePools returns the node pools of the FalconNodeSensor. GKE Autopilot only runs the allowlisted sensor DaemonSet, so node pools are ignored there.
+func (node *FalconNodeSensor) GetNodePools() []FalconNodePool {
+	if node.Spec.Node.GKE.Enabled != nil && *node.Spec.Node.GKE.Enabled {
+		return nil
+	}
+	return node.Spec.Node.NodePools
+}
+
+// GetTagTemplates returns the sensor grouping tag templates of the FalconNodeSensor. GKE Autopilot does not allow the init container applying them.
+func (node *FalconNodeSensor) GetTagTemplates() []string {
+	if node.Spec.Node.GKE.Enabled != nil && *node.Spec.Node.GKE.Enabled {
+		return nil
+	}
+	return node.Spec.Node.TagTemplates
+}
+
 func (node *FalconNodeSensor) GetFalconSecretSpec() FalconSecret {
 	return node.Spec.FalconSecret
 }
@@ -270,3 +559,19 @@ func (node *FalconNodeSensor) GetFalconSpec() FalconSensor {
 func (node *FalconNodeSensor) SetFalconSpec(falconSpec FalconSensor) {
 	node.Spec.Falcon.FalconSensor = falconSpec
 }
+
+func (node *FalconNodeSensor) GetFalconConfigRef() *FalconConfigReference {
+	return node.Spec.FalconConfigRef
+}
+
+func (node *FalconNodeSensor) SetFalconSecretSpec(falconSecret FalconSecret) {
+	node.Spec.FalconSecret = falconSecret
+}
+
+func (node *FalconNodeSensor) GetRegistrySpec() RegistrySpec {
+	return RegistrySpec{}
+}
+
+func (node *FalconNodeSensor) SetRegistrySpec(RegistrySpec) {
+	// noop
+}
@@ -213,6 +213,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -333,6 +334,22 @@ func (in *FalconAdmissionConfigSpec) DeepCopyInto(out *FalconAdmissionConfigSpec
 	}
 	in.TLS.DeepCopyInto(&out.TLS)
 	in.DisabledNamespaces.DeepCopyInto(&out.DisabledNamespaces)
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ObjectSelector != nil {
+		in, out := &in.ObjectSelector, &out.ObjectSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	in.Rules.DeepCopyInto(&out.Rules)
 	if in.DeployWatcher != nil {
 		in, out := &in.DeployWatcher, &out.DeployWatcher
 		*out = new(bool)
@@ -394,6 +411,20 @@ func (in *FalconAdmissionConfigSpec) DeepCopyInto(out *FalconAdmissionConfigSpec
 		*out = new(corev1.NodeAffinity)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.PodDisruptionBudget.DeepCopyInto(&out.PodDisruptionBudget)
+	if in.PodTemplateOverride != nil {
+		in, out := &in.PodTemplateOverride, &out.PodTemplateOverride
+		*out = new(PodTemplateOverride)
+		**out = **in
+	}
+	in.FailOpen.DeepCopyInto(&out.FailOpen)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalconAdmissionConfigSpec.
@@ -406,6 +437,31 @@ func (in *FalconAdmissionConfigSpec) DeepCopy() *FalconAdmissionConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalconAdmissionFailOpen) DeepCopyInto(out *FalconAdmissionFailOpen) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.OutagePeriod != nil {
+		in, out := &in.OutagePeriod, &out.OutagePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalconAdmissionFailOpen.
+func (in *FalconAdmissionFailOpen) DeepCopy() *FalconAdmissionFailOpen {
+	if in == nil {
+		return nil
+	}
+	out := new(FalconAdmissionFailOpen)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FalconAdmissionList) DeepCopyInto(out *FalconAdmissionList) {
 	*out = *in
@@ -458,6 +514,31 @@ func (in *FalconAdmissionNamespace) DeepCopy() *FalconAdmissionNamespace {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalconAdmissionPodDisruptionBudget) DeepCopyInto(out *FalconAdmissionPodDisruptionBudget) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalconAdmissionPodDisruptionBudget.
+func (in *FalconAdmissionPodDisruptionBudget) DeepCopy() *FalconAdmissionPodDisruptionBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(FalconAdmissionPodDisruptionBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FalconAdmissionRQSpec) DeepCopyInto(out *FalconAdmissionRQSpec) {
 	*out = *in
@@ -473,6 +554,41 @@ func (in *FalconAdmissionRQSpec) DeepCopy() *FalconAdmissionRQSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalconAdmissionRules) DeepCopyInto(out *FalconAdmissionRules) {
+	*out = *in
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Workloads != nil {
+		in, out := &in.Workloads, &out.Workloads
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Batch != nil {
+		in, out := &in.Batch, &out.Batch
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalconAdmissionRules.
+func (in *FalconAdmissionRules) DeepCopy() *FalconAdmissionRules {
+	if in == nil {
+		return nil
+	}
+	out := new(FalconAdmissionRules)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FalconAdmissionServiceAccount) DeepCopyInto(out *FalconAdmissionServiceAccount) {
 	*out = *in
@@ -504,6 +620,11 @@ func (in *FalconAdmissionSpec) DeepCopyInto(out *FalconAdmissionSpec) {
 		*out = new(FalconAPI)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.FalconConfigRef != nil {
+		in, out := &in.FalconConfigRef, &out.FalconConfigRef
+		*out = new(FalconConfigReference)
+		**out = **in
+	}
 	out.FalconSecret = in.FalconSecret
 	out.ResQuota = in.ResQuota
 	in.Registry.DeepCopyInto(&out.Registry)
@@ -513,11 +634,13 @@ func (in *FalconAdmissionSpec) DeepCopyInto(out *FalconAdmissionSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	in.Advanced.DeepCopyInto(&out.Advanced)
 	if in.ClusterName != nil {
 		in, out := &in.ClusterName, &out.ClusterName
 		*out = new(string)
 		**out = **in
 	}
+	in.ResourceMetadata.DeepCopyInto(&out.ResourceMetadata)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalconAdmissionSpec.
@@ -626,6 +749,10 @@ func (in *FalconCRStatus) DeepCopyInto(out *FalconCRStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.RegistryTokenRefreshTime != nil {
+		in, out := &in.RegistryTokenRefreshTime, &out.RegistryTokenRefreshTime
+		*out = (*in).DeepCopy()
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -645,6 +772,106 @@ func (in *FalconCRStatus) DeepCopy() *FalconCRStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalconConfig) DeepCopyInto(out *FalconConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalconConfig.
+func (in *FalconConfig) DeepCopy() *FalconConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FalconConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FalconConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalconConfigList) DeepCopyInto(out *FalconConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FalconConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalconConfigList.
+func (in *FalconConfigList) DeepCopy() *FalconConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(FalconConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FalconConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalconConfigReference) DeepCopyInto(out *FalconConfigReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalconConfigReference.
+func (in *FalconConfigReference) DeepCopy() *FalconConfigReference {
+	if in == nil {
+		return nil
+	}
+	out := new(FalconConfigReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalconConfigSpec) DeepCopyInto(out *FalconConfigSpec) {
+	*out = *in
+	if in.FalconAPI != nil {
+		in, out := &in.FalconAPI, &out.FalconAPI
+		*out = new(FalconAPI)
+		(*in).DeepCopyInto(*out)
+	}
+	out.FalconSecret = in.FalconSecret
+	in.Proxy.DeepCopyInto(&out.Proxy)
+	if in.Registry != nil {
+		in, out := &in.Registry, &out.Registry
+		*out = new(RegistrySpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalconConfigSpec.
+func (in *FalconConfigSpec) DeepCopy() *FalconConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FalconConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FalconContainer) DeepCopyInto(out *FalconContainer) {
 	*out = *in
@@ -713,6 +940,26 @@ func (in *FalconContainerInjectorSpec) DeepCopyInto(out *FalconContainerInjector
 		*out = new(int32)
 		**out = **in
 	}
+	if in.PullSecretNamespaceSelector != nil {
+		in, out := &in.PullSecretNamespaceSelector, &out.PullSecretNamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ObjectSelector != nil {
+		in, out := &in.ObjectSelector, &out.ObjectSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PodTemplateOverride != nil {
+		in, out := &in.PodTemplateOverride, &out.PodTemplateOverride
+		*out = new(PodTemplateOverride)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalconContainerInjectorSpec.
@@ -808,6 +1055,11 @@ func (in *FalconContainerSpec) DeepCopyInto(out *FalconContainerSpec) {
 		*out = new(FalconAPI)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.FalconConfigRef != nil {
+		in, out := &in.FalconConfigRef, &out.FalconConfigRef
+		*out = new(FalconConfigReference)
+		**out = **in
+	}
 	out.FalconSecret = in.FalconSecret
 	in.Registry.DeepCopyInto(&out.Registry)
 	in.Injector.DeepCopyInto(&out.Injector)
@@ -826,7 +1078,15 @@ func (in *FalconContainerSpec) DeepCopyInto(out *FalconContainerSpec) {
 		*out = new(corev1.NodeAffinity)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.Advanced.DeepCopyInto(&out.Advanced)
+	in.ResourceMetadata.DeepCopyInto(&out.ResourceMetadata)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalconContainerSpec.
@@ -847,6 +1107,10 @@ func (in *FalconContainerStatus) DeepCopyInto(out *FalconContainerStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.RegistryTokenRefreshTime != nil {
+		in, out := &in.RegistryTokenRefreshTime, &out.RegistryTokenRefreshTime
+		*out = (*in).DeepCopy()
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -893,6 +1157,82 @@ func (in *FalconDeployment) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalconDeploymentAdoptedResource) DeepCopyInto(out *FalconDeploymentAdoptedResource) {
+	*out = *in
+	in.AdoptionTime.DeepCopyInto(&out.AdoptionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalconDeploymentAdoptedResource.
+func (in *FalconDeploymentAdoptedResource) DeepCopy() *FalconDeploymentAdoptedResource {
+	if in == nil {
+		return nil
+	}
+	out := new(FalconDeploymentAdoptedResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalconDeploymentCommonSpec) DeepCopyInto(out *FalconDeploymentCommonSpec) {
+	*out = *in
+	in.Proxy.DeepCopyInto(&out.Proxy)
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeAffinity != nil {
+		in, out := &in.NodeAffinity, &out.NodeAffinity
+		*out = new(corev1.NodeAffinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalconDeploymentCommonSpec.
+func (in *FalconDeploymentCommonSpec) DeepCopy() *FalconDeploymentCommonSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FalconDeploymentCommonSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalconDeploymentComponentStatus) DeepCopyInto(out *FalconDeploymentComponentStatus) {
+	*out = *in
+	if in.Sensor != nil {
+		in, out := &in.Sensor, &out.Sensor
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalconDeploymentComponentStatus.
+func (in *FalconDeploymentComponentStatus) DeepCopy() *FalconDeploymentComponentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FalconDeploymentComponentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FalconDeploymentList) DeepCopyInto(out *FalconDeploymentList) {
 	*out = *in
@@ -925,6 +1265,22 @@ func (in *FalconDeploymentList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalconDeploymentNodeSensor) DeepCopyInto(out *FalconDeploymentNodeSensor) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalconDeploymentNodeSensor.
+func (in *FalconDeploymentNodeSensor) DeepCopy() *FalconDeploymentNodeSensor {
+	if in == nil {
+		return nil
+	}
+	out := new(FalconDeploymentNodeSensor)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FalconDeploymentSpec) DeepCopyInto(out *FalconDeploymentSpec) {
 	*out = *in
@@ -933,6 +1289,11 @@ func (in *FalconDeploymentSpec) DeepCopyInto(out *FalconDeploymentSpec) {
 		*out = new(FalconAPI)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.FalconConfigRef != nil {
+		in, out := &in.FalconConfigRef, &out.FalconConfigRef
+		*out = new(FalconConfigReference)
+		**out = **in
+	}
 	in.Registry.DeepCopyInto(&out.Registry)
 	out.FalconSecret = in.FalconSecret
 	if in.DeployAdmissionController != nil {
@@ -959,6 +1320,19 @@ func (in *FalconDeploymentSpec) DeepCopyInto(out *FalconDeploymentSpec) {
 	in.FalconNodeSensor.DeepCopyInto(&out.FalconNodeSensor)
 	in.FalconImageAnalyzer.DeepCopyInto(&out.FalconImageAnalyzer)
 	in.FalconContainerSensor.DeepCopyInto(&out.FalconContainerSensor)
+	if in.FalconNodeSensors != nil {
+		in, out := &in.FalconNodeSensors, &out.FalconNodeSensors
+		*out = make([]FalconDeploymentNodeSensor, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AdoptExistingResources != nil {
+		in, out := &in.AdoptExistingResources, &out.AdoptExistingResources
+		*out = new(bool)
+		**out = **in
+	}
+	in.Common.DeepCopyInto(&out.Common)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalconDeploymentSpec.
@@ -979,6 +1353,35 @@ func (in *FalconDeploymentStatus) DeepCopyInto(out *FalconDeploymentStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.FalconAdmission != nil {
+		in, out := &in.FalconAdmission, &out.FalconAdmission
+		*out = new(FalconDeploymentComponentStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FalconNodeSensors != nil {
+		in, out := &in.FalconNodeSensors, &out.FalconNodeSensors
+		*out = make([]FalconDeploymentComponentStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FalconImageAnalyzer != nil {
+		in, out := &in.FalconImageAnalyzer, &out.FalconImageAnalyzer
+		*out = new(FalconDeploymentComponentStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FalconContainerSensor != nil {
+		in, out := &in.FalconContainerSensor, &out.FalconContainerSensor
+		*out = new(FalconDeploymentComponentStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdoptedResources != nil {
+		in, out := &in.AdoptedResources, &out.AdoptedResources
+		*out = make([]FalconDeploymentAdoptedResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -1164,6 +1567,11 @@ func (in *FalconImageAnalyzerSpec) DeepCopyInto(out *FalconImageAnalyzerSpec) {
 		*out = new(FalconAPI)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.FalconConfigRef != nil {
+		in, out := &in.FalconConfigRef, &out.FalconConfigRef
+		*out = new(FalconConfigReference)
+		**out = **in
+	}
 	in.Registry.DeepCopyInto(&out.Registry)
 	in.ImageAnalyzerConfig.DeepCopyInto(&out.ImageAnalyzerConfig)
 	out.FalconSecret = in.FalconSecret
@@ -1172,11 +1580,25 @@ func (in *FalconImageAnalyzerSpec) DeepCopyInto(out *FalconImageAnalyzerSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	in.Advanced.DeepCopyInto(&out.Advanced)
 	if in.NodeAffinity != nil {
 		in, out := &in.NodeAffinity, &out.NodeAffinity
 		*out = new(corev1.NodeAffinity)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodTemplateOverride != nil {
+		in, out := &in.PodTemplateOverride, &out.PodTemplateOverride
+		*out = new(PodTemplateOverride)
+		**out = **in
+	}
+	in.ResourceMetadata.DeepCopyInto(&out.ResourceMetadata)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalconImageAnalyzerSpec.
@@ -1252,6 +1674,180 @@ func (in *FalconInternal) DeepCopy() *FalconInternal {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalconNodeCleanupResult) DeepCopyInto(out *FalconNodeCleanupResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalconNodeCleanupResult.
+func (in *FalconNodeCleanupResult) DeepCopy() *FalconNodeCleanupResult {
+	if in == nil {
+		return nil
+	}
+	out := new(FalconNodeCleanupResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalconNodeCleanupStatus) DeepCopyInto(out *FalconNodeCleanupStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]FalconNodeCleanupResult, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalconNodeCleanupStatus.
+func (in *FalconNodeCleanupStatus) DeepCopy() *FalconNodeCleanupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FalconNodeCleanupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalconNodeDeletedNode) DeepCopyInto(out *FalconNodeDeletedNode) {
+	*out = *in
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.DeletionTime.DeepCopyInto(&out.DeletionTime)
+	if in.LastAttemptTime != nil {
+		in, out := &in.LastAttemptTime, &out.LastAttemptTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalconNodeDeletedNode.
+func (in *FalconNodeDeletedNode) DeepCopy() *FalconNodeDeletedNode {
+	if in == nil {
+		return nil
+	}
+	out := new(FalconNodeDeletedNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalconNodeEnrollment) DeepCopyInto(out *FalconNodeEnrollment) {
+	*out = *in
+	if in.LastSeen != nil {
+		in, out := &in.LastSeen, &out.LastSeen
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalconNodeEnrollment.
+func (in *FalconNodeEnrollment) DeepCopy() *FalconNodeEnrollment {
+	if in == nil {
+		return nil
+	}
+	out := new(FalconNodeEnrollment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalconNodeEnrollmentStatus) DeepCopyInto(out *FalconNodeEnrollmentStatus) {
+	*out = *in
+	in.LastCheckTime.DeepCopyInto(&out.LastCheckTime)
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]FalconNodeEnrollment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalconNodeEnrollmentStatus.
+func (in *FalconNodeEnrollmentStatus) DeepCopy() *FalconNodeEnrollmentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FalconNodeEnrollmentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalconNodeHostCleanup) DeepCopyInto(out *FalconNodeHostCleanup) {
+	*out = *in
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalconNodeHostCleanup.
+func (in *FalconNodeHostCleanup) DeepCopy() *FalconNodeHostCleanup {
+	if in == nil {
+		return nil
+	}
+	out := new(FalconNodeHostCleanup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalconNodePool) DeepCopyInto(out *FalconNodePool) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SensorResources != nil {
+		in, out := &in.SensorResources, &out.SensorResources
+		*out = new(Resources)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalconNodePool.
+func (in *FalconNodePool) DeepCopy() *FalconNodePool {
+	if in == nil {
+		return nil
+	}
+	out := new(FalconNodePool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalconNodeRolloutStatus) DeepCopyInto(out *FalconNodeRolloutStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalconNodeRolloutStatus.
+func (in *FalconNodeRolloutStatus) DeepCopy() *FalconNodeRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FalconNodeRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FalconNodeSensor) DeepCopyInto(out *FalconNodeSensor) {
 	*out = *in
@@ -1306,9 +1902,32 @@ func (in *FalconNodeSensorConfig) DeepCopyInto(out *FalconNodeSensorConfig) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.CleanupTimeout != nil {
+		in, out := &in.CleanupTimeout, &out.CleanupTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	out.SensorResources = in.SensorResources
 	in.GKE.DeepCopyInto(&out.GKE)
 	in.PriorityClass.DeepCopyInto(&out.PriorityClass)
+	if in.PodTemplateOverride != nil {
+		in, out := &in.PodTemplateOverride, &out.PodTemplateOverride
+		*out = new(PodTemplateOverride)
+		**out = **in
+	}
+	if in.NodePools != nil {
+		in, out := &in.NodePools, &out.NodePools
+		*out = make([]FalconNodePool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TagTemplates != nil {
+		in, out := &in.TagTemplates, &out.TagTemplates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.HostCleanup.DeepCopyInto(&out.HostCleanup)
 	if in.Version != nil {
 		in, out := &in.Version, &out.Version
 		*out = new(string)
@@ -1369,8 +1988,14 @@ func (in *FalconNodeSensorSpec) DeepCopyInto(out *FalconNodeSensorSpec) {
 		*out = new(FalconAPI)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.FalconConfigRef != nil {
+		in, out := &in.FalconConfigRef, &out.FalconConfigRef
+		*out = new(FalconConfigReference)
+		**out = **in
+	}
 	out.FalconSecret = in.FalconSecret
 	in.Internal.DeepCopyInto(&out.Internal)
+	in.ResourceMetadata.DeepCopyInto(&out.ResourceMetadata)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalconNodeSensorSpec.
@@ -1391,6 +2016,42 @@ func (in *FalconNodeSensorStatus) DeepCopyInto(out *FalconNodeSensorStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.RegistryTokenRefreshTime != nil {
+		in, out := &in.RegistryTokenRefreshTime, &out.RegistryTokenRefreshTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(FalconNodeRolloutStatus)
+		**out = **in
+	}
+	if in.Cleanup != nil {
+		in, out := &in.Cleanup, &out.Cleanup
+		*out = new(FalconNodeCleanupStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScopeCleanup != nil {
+		in, out := &in.ScopeCleanup, &out.ScopeCleanup
+		*out = make([]FalconNodeCleanupResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnsupportedNodes != nil {
+		in, out := &in.UnsupportedNodes, &out.UnsupportedNodes
+		*out = make([]FalconNodeUnsupportedNode, len(*in))
+		copy(*out, *in)
+	}
+	if in.Enrollment != nil {
+		in, out := &in.Enrollment, &out.Enrollment
+		*out = new(FalconNodeEnrollmentStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeletedNodes != nil {
+		in, out := &in.DeletedNodes, &out.DeletedNodes
+		*out = make([]FalconNodeDeletedNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -1432,6 +2093,21 @@ func (in *FalconNodeServiceAccount) DeepCopy() *FalconNodeServiceAccount {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalconNodeUnsupportedNode) DeepCopyInto(out *FalconNodeUnsupportedNode) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalconNodeUnsupportedNode.
+func (in *FalconNodeUnsupportedNode) DeepCopy() *FalconNodeUnsupportedNode {
+	if in == nil {
+		return nil
+	}
+	out := new(FalconNodeUnsupportedNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FalconNodeUpdateStrategy) DeepCopyInto(out *FalconNodeUpdateStrategy) {
 	*out = *in
@@ -1448,6 +2124,26 @@ func (in *FalconNodeUpdateStrategy) DeepCopy() *FalconNodeUpdateStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalconProxy) DeepCopyInto(out *FalconProxy) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalconProxy.
+func (in *FalconProxy) DeepCopy() *FalconProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(FalconProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FalconSecret) DeepCopyInto(out *FalconSecret) {
 	*out = *in
@@ -1514,6 +2210,21 @@ func (in *FalconUnified) DeepCopy() *FalconUnified {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodTemplateOverride) DeepCopyInto(out *PodTemplateOverride) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplateOverride.
+func (in *PodTemplateOverride) DeepCopy() *PodTemplateOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(PodTemplateOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PriorityClassConfig) DeepCopyInto(out *PriorityClassConfig) {
 	*out = *in
@@ -1583,6 +2294,16 @@ func (in *RegistrySpec) DeepCopyInto(out *RegistrySpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.GarLocation != nil {
+		in, out := &in.GarLocation, &out.GarLocation
+		*out = new(string)
+		**out = **in
+	}
+	if in.GarRepository != nil {
+		in, out := &in.GarRepository, &out.GarRepository
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistrySpec.
@@ -1625,6 +2346,49 @@ func (in *ResourceList) DeepCopy() *ResourceList {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceMetadata) DeepCopyInto(out *ResourceMetadata) {
+	*out = *in
+	if in.CommonLabels != nil {
+		in, out := &in.CommonLabels, &out.CommonLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CommonAnnotations != nil {
+		in, out := &in.CommonAnnotations, &out.CommonAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodLabels != nil {
+		in, out := &in.PodLabels, &out.PodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceMetadata.
+func (in *ResourceMetadata) DeepCopy() *ResourceMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Resources) DeepCopyInto(out *Resources) {
 	*out = *in
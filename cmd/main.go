@@ -35,6 +35,8 @@ import (
 	arv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	schedulingv1 "k8s.io/api/scheduling/v1"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -42,6 +44,7 @@ import (
 
 	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
 	admissioncontroller "github.com/crowdstrike/falcon-operator/internal/controller/admission"
+	"github.com/crowdstrike/falcon-operator/internal/controller/common/pullsecret"
 	"github.com/crowdstrike/falcon-operator/internal/controller/common/sensorversion"
 	containercontroller "github.com/crowdstrike/falcon-operator/internal/controller/falcon_container"
 	falcondeployment "github.com/crowdstrike/falcon-operator/internal/controller/falcon_deployment"
@@ -53,6 +56,7 @@ import (
 )
 
 const defaultSensorAutoUpdateInterval = time.Hour * 24
+const defaultPullSecretRefreshInterval = time.Hour * 6
 const defaultLeaseDuration = time.Second * 30
 const defaultRenewDeadline = time.Second * 20
 
@@ -74,6 +78,13 @@ var (
 		&corev1.ResourceQuota{}: {
 			Label: labels.SelectorFromSet(labels.Set{common.FalconComponentKey: common.FalconAdmissionController}),
 		},
+		&policyv1.PodDisruptionBudget{}: {
+			Label: labels.SelectorFromSet(labels.Set{common.FalconComponentKey: common.FalconAdmissionController}),
+		},
+		// EndpointSlices mirror the labels of the Admission Controller service they belong to
+		&discoveryv1.EndpointSlice{}: {
+			Label: labels.SelectorFromSet(labels.Set{common.FalconComponentKey: common.FalconAdmissionController}),
+		},
 		&appsv1.Deployment{}: {
 			Label: labels.SelectorFromSet(labels.Set{common.FalconProviderKey: common.FalconProviderValue}),
 		},
@@ -89,9 +100,10 @@ var (
 		&arv1.ValidatingWebhookConfiguration{}: {
 			Label: labels.SelectorFromSet(labels.Set{common.FalconComponentKey: common.FalconAdmissionController}),
 		},
-		&corev1.Namespace{}: {
-			Label: labels.SelectorFromSet(labels.Set{common.FalconInstanceNameKey: "namespace"}),
-		},
+		// All namespaces are cached so that the FalconContainer controller sees namespaces created or relabelled for injection
+		&corev1.Namespace{}: {},
+		// All nodes are cached so that the FalconNodeSensor controller selects the backend of new nodes
+		&corev1.Node{}: {},
 		&corev1.Secret{}: {
 			Label: labels.SelectorFromSet(labels.Set{common.FalconInstanceNameKey: "secret"}),
 		},
@@ -124,6 +136,7 @@ func main() {
 	var ver bool
 	var err error
 	var sensorAutoUpdateInterval time.Duration
+	var pullSecretRefreshInterval time.Duration
 	var leaseDuration time.Duration
 	var renewDeadline time.Duration
 
@@ -141,6 +154,7 @@ func main() {
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.BoolVar(&ver, "version", false, "Print version")
 	flag.DurationVar(&sensorAutoUpdateInterval, "sensor-auto-update-interval", defaultSensorAutoUpdateInterval, "The rate at which the Falcon API is queried for new sensor versions")
+	flag.DurationVar(&pullSecretRefreshInterval, "pull-secret-refresh-interval", defaultPullSecretRefreshInterval, "The rate at which the CrowdStrike registry pull secrets are refreshed. Set to 0 to disable")
 	flag.DurationVar(&leaseDuration, "lease-duration", defaultLeaseDuration, "The duration that non-leader candidates will wait to force acquire leadership.")
 	flag.DurationVar(&renewDeadline, "renew-deadline", defaultRenewDeadline, "the duration that the acting controlplane will retry refreshing leadership before giving up.")
 
@@ -260,21 +274,23 @@ func main() {
 
 	ctx := ctrl.SetupSignalHandler()
 	tracker := sensorversion.NewTracker(ctx, sensorAutoUpdateInterval)
+	pullSecretRefresher := pullsecret.NewRefresher(ctx, pullSecretRefreshInterval)
 
 	if err = (&containercontroller.FalconContainerReconciler{
 		Client:     mgr.GetClient(),
 		Reader:     mgr.GetAPIReader(),
 		Scheme:     mgr.GetScheme(),
 		RestConfig: mgr.GetConfig(),
-	}).SetupWithManager(mgr, tracker); err != nil {
+	}).SetupWithManager(mgr, tracker, pullSecretRefresher); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "FalconContainer")
 		os.Exit(1)
 	}
 	if err = (&nodecontroller.FalconNodeSensorReconciler{
-		Client: mgr.GetClient(),
-		Reader: mgr.GetAPIReader(),
-		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr, tracker); err != nil {
+		Client:   mgr.GetClient(),
+		Reader:   mgr.GetAPIReader(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("falconnodesensor-controller"),
+	}).SetupWithManager(mgr, tracker, pullSecretRefresher); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "FalconNodeSensor")
 		os.Exit(1)
 	}
@@ -282,8 +298,9 @@ func main() {
 		Client:    mgr.GetClient(),
 		Reader:    mgr.GetAPIReader(),
 		Scheme:    mgr.GetScheme(),
+		Recorder:  mgr.GetEventRecorderFor("falconadmission-controller"),
 		OpenShift: openShift,
-	}).SetupWithManager(mgr); err != nil {
+	}).SetupWithManager(mgr, tracker, pullSecretRefresher); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "FalconAdmission")
 		os.Exit(1)
 	}
@@ -291,7 +308,7 @@ func main() {
 		Client: mgr.GetClient(),
 		Reader: mgr.GetAPIReader(),
 		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr); err != nil {
+	}).SetupWithManager(mgr, tracker, pullSecretRefresher); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "FalconImageAnalyzer")
 		os.Exit(1)
 	}
@@ -332,6 +349,7 @@ func main() {
 	}
 
 	go tracker.StartTracking()
+	go pullSecretRefresher.StartRefreshing()
 
 	setupLog.Info("starting manager", "version", version.Get(), "go version", version.GoVersion)
 	if err := mgr.Start(ctx); err != nil {
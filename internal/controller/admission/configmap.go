@@ -34,6 +34,7 @@ func (r *FalconAdmissionReconciler) reconcileGenericConfigMap(name string, genFu
 	if err != nil {
 		return false, err
 	}
+	assets.ApplyResourceMetadata(cm, falconAdmission.Spec.ResourceMetadata)
 
 	existingCM := &corev1.ConfigMap{}
 	err = common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: name, Namespace: falconAdmission.Spec.InstallNamespace}, existingCM)
@@ -50,13 +51,22 @@ func (r *FalconAdmissionReconciler) reconcileGenericConfigMap(name string, genFu
 		return false, err
 	}
 
-	if !isOwnedByKacController(existingCM) {
-		existingCM.TypeMeta = metav1.TypeMeta{
-			APIVersion: corev1.SchemeGroupVersion.String(),
-			Kind:       "ConfigMap",
+	if isOwnedByKacController(existingCM) {
+		existingData := existingCM.Data
+		if _, err := k8sutils.Apply(r.Client, ctx, req, log, falconAdmission, &falconAdmission.Status, existingCM, cm, falconAdmission.Spec.FieldOwnership); err != nil {
+			return false, err
 		}
+
+		// Metadata changes do not require the Admission Controller to be restarted
+		return !reflect.DeepEqual(existingData, cm.Data), nil
+	}
+
+	existingCM.TypeMeta = metav1.TypeMeta{
+		APIVersion: corev1.SchemeGroupVersion.String(),
+		Kind:       "ConfigMap",
 	}
 
+	metadataUpdated := assets.SyncResourceMetadata(existingCM, cm, falconAdmission.Spec.ResourceMetadata)
 	if !reflect.DeepEqual(cm.Data, existingCM.Data) {
 		existingCM.Data = cm.Data
 		if err := k8sutils.Update(r.Client, ctx, req, log, falconAdmission, &falconAdmission.Status, existingCM); err != nil {
@@ -65,6 +75,11 @@ func (r *FalconAdmissionReconciler) reconcileGenericConfigMap(name string, genFu
 		return true, nil
 	}
 
+	// Metadata changes do not require the Admission Controller to be restarted
+	if metadataUpdated {
+		return false, k8sutils.Update(r.Client, ctx, req, log, falconAdmission, &falconAdmission.Status, existingCM)
+	}
+
 	return false, nil
 }
 
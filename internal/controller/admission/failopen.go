@@ -0,0 +1,204 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	k8sutils "github.com/crowdstrike/falcon-operator/internal/controller/common"
+	"github.com/crowdstrike/falcon-operator/pkg/common"
+	"github.com/go-logr/logr"
+	arv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// reconcileFailOpen tracks whether the Admission Controller service has a ready endpoint. Once it has had none for longer
+// than the configured outage period, admission control is bypassed so that a Fail failure policy cannot block every
+// workload in the cluster, including the Admission Controller itself. The bypass is lifted as soon as an endpoint is
+// ready again. It returns the time after which the outage must be checked again, or zero when no check is pending.
+func (r *FalconAdmissionReconciler) reconcileFailOpen(ctx context.Context, req ctrl.Request, log logr.Logger, falconAdmission *falconv1alpha1.FalconAdmission) (time.Duration, error) {
+	if !falconAdmission.GetAdmissionControlEnabled() {
+		return 0, r.restoreAdmission(ctx, req, log, falconAdmission, "Admission control is disabled")
+	}
+
+	ready, err := r.webhookEndpointsReady(ctx, falconAdmission)
+	if err != nil {
+		log.Error(err, "Failed to check FalconAdmission service endpoints")
+		return 0, err
+	}
+
+	endpointsCondition := metav1.Condition{
+		Type:               falconv1alpha1.ConditionWebhookEndpointsReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             falconv1alpha1.ReasonEndpointsReady,
+		Message:            "FalconAdmission service has a ready endpoint",
+		ObservedGeneration: falconAdmission.GetGeneration(),
+	}
+	if !ready {
+		endpointsCondition.Status = metav1.ConditionFalse
+		endpointsCondition.Reason = falconv1alpha1.ReasonNoReadyEndpoints
+		endpointsCondition.Message = "FalconAdmission service has no ready endpoint"
+	}
+
+	if err := k8sutils.ConditionsUpdate(r.Client, ctx, req, log, falconAdmission, &falconAdmission.Status, endpointsCondition); err != nil {
+		return 0, err
+	}
+
+	if ready {
+		return 0, r.restoreAdmission(ctx, req, log, falconAdmission, "Admission Controller is ready again")
+	}
+
+	if !falconAdmission.GetFailOpenEnabled() || admissionBypassed(falconAdmission) {
+		return 0, nil
+	}
+
+	outageStart := meta.FindStatusCondition(falconAdmission.Status.Conditions, falconv1alpha1.ConditionWebhookEndpointsReady).LastTransitionTime
+	if remaining := falconAdmission.GetFailOpenOutagePeriod() - time.Since(outageStart.Time); remaining > 0 {
+		return remaining, nil
+	}
+
+	message := fmt.Sprintf("Admission Controller has had no ready endpoint since %s, admission control bypassed with action %s", outageStart.UTC().Format(time.RFC3339), falconAdmission.GetFailOpenAction())
+	log.Info(message)
+
+	if err := r.bypassAdmissionWebhook(ctx, log, falconAdmission); err != nil {
+		return 0, err
+	}
+
+	if err := k8sutils.ConditionsUpdate(r.Client, ctx, req, log, falconAdmission, &falconAdmission.Status, metav1.Condition{
+		Type:               falconv1alpha1.ConditionAdmissionBypassed,
+		Status:             metav1.ConditionTrue,
+		Reason:             falconv1alpha1.ReasonOutageDetected,
+		Message:            message,
+		ObservedGeneration: falconAdmission.GetGeneration(),
+	}); err != nil {
+		return 0, err
+	}
+
+	r.event(falconAdmission, corev1.EventTypeWarning, "AdmissionBypassed", message)
+
+	return 0, nil
+}
+
+// restoreAdmission lifts an active bypass. The webhook itself is restored by reconcileAdmissionValidatingWebHook.
+func (r *FalconAdmissionReconciler) restoreAdmission(ctx context.Context, req ctrl.Request, log logr.Logger, falconAdmission *falconv1alpha1.FalconAdmission, message string) error {
+	if !admissionBypassed(falconAdmission) {
+		return nil
+	}
+
+	log.Info("Lifting admission control bypass", "reason", message)
+
+	if err := k8sutils.ConditionsUpdate(r.Client, ctx, req, log, falconAdmission, &falconAdmission.Status, metav1.Condition{
+		Type:               falconv1alpha1.ConditionAdmissionBypassed,
+		Status:             metav1.ConditionFalse,
+		Reason:             falconv1alpha1.ReasonRecovered,
+		Message:            message,
+		ObservedGeneration: falconAdmission.GetGeneration(),
+	}); err != nil {
+		return err
+	}
+
+	r.event(falconAdmission, corev1.EventTypeNormal, "AdmissionRestored", message)
+
+	return nil
+}
+
+// bypassAdmissionWebhook applies the bypass to the live ValidatingWebhookConfiguration right away, so that it does not
+// depend on the rest of the reconciliation succeeding during an outage
+func (r *FalconAdmissionReconciler) bypassAdmissionWebhook(ctx context.Context, log logr.Logger, falconAdmission *falconv1alpha1.FalconAdmission) error {
+	existingWebhook := &arv1.ValidatingWebhookConfiguration{}
+	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: common.FalconAdmissionValidatingWebhookName}, existingWebhook)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		log.Error(err, "Failed to get FalconAdmission Validating Webhook")
+		return err
+	}
+
+	if falconAdmission.GetFailOpenAction() == falconv1alpha1.FailOpenActionRemove {
+		if err := r.Delete(ctx, existingWebhook); err != nil && !apierrors.IsNotFound(err) {
+			log.Error(err, "Failed to delete FalconAdmission Validating Webhook")
+			return err
+		}
+
+		return nil
+	}
+
+	ignore := arv1.Ignore
+	for i := range existingWebhook.Webhooks {
+		existingWebhook.Webhooks[i].FailurePolicy = &ignore
+	}
+
+	if err := r.Update(ctx, existingWebhook); err != nil {
+		log.Error(err, "Failed to update FalconAdmission Validating Webhook failure policy")
+		return err
+	}
+
+	return nil
+}
+
+// webhookEndpointsReady reports whether any EndpointSlice of the Admission Controller service has a ready endpoint
+func (r *FalconAdmissionReconciler) webhookEndpointsReady(ctx context.Context, falconAdmission *falconv1alpha1.FalconAdmission) (bool, error) {
+	slices := &discoveryv1.EndpointSliceList{}
+	if err := r.List(ctx, slices, client.InNamespace(falconAdmission.Spec.InstallNamespace), client.MatchingLabels{discoveryv1.LabelServiceName: falconAdmission.Name}); err != nil {
+		return false, err
+	}
+
+	return endpointSlicesReady(slices.Items), nil
+}
+
+func endpointSlicesReady(slices []discoveryv1.EndpointSlice) bool {
+	for _, slice := range slices {
+		for _, endpoint := range slice.Endpoints {
+			// A nil ready condition must be interpreted as ready
+			if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// admissionBypassed reports whether admission control is currently bypassed because of an Admission Controller outage
+func admissionBypassed(falconAdmission *falconv1alpha1.FalconAdmission) bool {
+	return meta.IsStatusConditionTrue(falconAdmission.Status.Conditions, falconv1alpha1.ConditionAdmissionBypassed)
+}
+
+// endpointSliceToFalconAdmissions maps an Admission Controller service EndpointSlice to the FalconAdmission it belongs to
+func (r *FalconAdmissionReconciler) endpointSliceToFalconAdmissions(ctx context.Context, obj client.Object) []reconcile.Request {
+	serviceName := obj.GetLabels()[discoveryv1.LabelServiceName]
+	if serviceName == "" {
+		return nil
+	}
+
+	falconAdmissions := &falconv1alpha1.FalconAdmissionList{}
+	if err := r.List(ctx, falconAdmissions); err != nil {
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, falconAdmission := range falconAdmissions.Items {
+		if falconAdmission.Name == serviceName && falconAdmission.Spec.InstallNamespace == obj.GetNamespace() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: falconAdmission.Name}})
+		}
+	}
+
+	return requests
+}
+
+func (r *FalconAdmissionReconciler) event(falconAdmission *falconv1alpha1.FalconAdmission, eventType string, reason string, message string) {
+	if r.Recorder == nil {
+		return
+	}
+
+	r.Recorder.Event(falconAdmission, eventType, reason, message)
+}
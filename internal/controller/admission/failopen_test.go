@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/crowdstrike/falcon-operator/pkg/common"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	arv1 "k8s.io/api/admissionregistration/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func failOpenReconciler(t *testing.T, falconAdmission *falconv1alpha1.FalconAdmission, objs ...runtime.Object) *FalconAdmissionReconciler {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, falconv1alpha1.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(falconAdmission).WithObjects(falconAdmission).WithRuntimeObjects(objs...).Build()
+	return &FalconAdmissionReconciler{Client: c, Reader: c, Scheme: scheme}
+}
+
+func failOpenAdmission(outageStart time.Time) *falconv1alpha1.FalconAdmission {
+	return &falconv1alpha1.FalconAdmission{
+		ObjectMeta: metav1.ObjectMeta{Name: "falcon-kac"},
+		Spec:       falconv1alpha1.FalconAdmissionSpec{InstallNamespace: "falcon-kac"},
+		Status: falconv1alpha1.FalconCRStatus{
+			Conditions: []metav1.Condition{{
+				Type:               falconv1alpha1.ConditionWebhookEndpointsReady,
+				Status:             metav1.ConditionFalse,
+				Reason:             falconv1alpha1.ReasonNoReadyEndpoints,
+				LastTransitionTime: metav1.NewTime(outageStart),
+			}},
+		},
+	}
+}
+
+func failOpenWebhook() *arv1.ValidatingWebhookConfiguration {
+	fail := arv1.Fail
+	return &arv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: common.FalconAdmissionValidatingWebhookName},
+		Webhooks:   []arv1.ValidatingWebhook{{Name: "validating.admission.falcon.crowdstrike.com", FailurePolicy: &fail}},
+	}
+}
+
+func readyEndpointSlice(ready bool) *discoveryv1.EndpointSlice {
+	return &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "falcon-kac-abcde",
+			Namespace: "falcon-kac",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: "falcon-kac"},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints:   []discoveryv1.Endpoint{{Addresses: []string{"10.0.0.1"}, Conditions: discoveryv1.EndpointConditions{Ready: &ready}}},
+	}
+}
+
+func TestReconcileFailOpen_WithinOutagePeriod(t *testing.T) {
+	falconAdmission := failOpenAdmission(time.Now().Add(-time.Minute))
+	r := failOpenReconciler(t, falconAdmission, failOpenWebhook(), readyEndpointSlice(false))
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: falconAdmission.Name}}
+
+	requeue, err := r.reconcileFailOpen(context.Background(), req, logr.Discard(), falconAdmission)
+	require.NoError(t, err)
+	assert.Greater(t, requeue, time.Duration(0))
+	assert.LessOrEqual(t, requeue, 4*time.Minute)
+	assert.False(t, admissionBypassed(falconAdmission))
+
+	webhook := &arv1.ValidatingWebhookConfiguration{}
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{Name: common.FalconAdmissionValidatingWebhookName}, webhook))
+	assert.Equal(t, arv1.Fail, *webhook.Webhooks[0].FailurePolicy)
+}
+
+func TestReconcileFailOpen_BypassAndRestore(t *testing.T) {
+	falconAdmission := failOpenAdmission(time.Now().Add(-10 * time.Minute))
+	r := failOpenReconciler(t, falconAdmission, failOpenWebhook())
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: falconAdmission.Name}}
+
+	requeue, err := r.reconcileFailOpen(context.Background(), req, logr.Discard(), falconAdmission)
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), requeue)
+	assert.True(t, admissionBypassed(falconAdmission))
+
+	webhook := &arv1.ValidatingWebhookConfiguration{}
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{Name: common.FalconAdmissionValidatingWebhookName}, webhook))
+	assert.Equal(t, arv1.Ignore, *webhook.Webhooks[0].FailurePolicy)
+
+	require.NoError(t, r.Create(context.Background(), readyEndpointSlice(true)))
+
+	_, err = r.reconcileFailOpen(context.Background(), req, logr.Discard(), falconAdmission)
+	require.NoError(t, err)
+	assert.False(t, admissionBypassed(falconAdmission))
+	assert.Equal(t, falconv1alpha1.ReasonRecovered, meta.FindStatusCondition(falconAdmission.Status.Conditions, falconv1alpha1.ConditionAdmissionBypassed).Reason)
+	assert.True(t, meta.IsStatusConditionTrue(falconAdmission.Status.Conditions, falconv1alpha1.ConditionWebhookEndpointsReady))
+}
+
+func TestReconcileFailOpen_RemoveAction(t *testing.T) {
+	falconAdmission := failOpenAdmission(time.Now().Add(-10 * time.Minute))
+	falconAdmission.Spec.AdmissionConfig.FailOpen.Action = falconv1alpha1.FailOpenActionRemove
+	r := failOpenReconciler(t, falconAdmission, failOpenWebhook())
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: falconAdmission.Name}}
+
+	_, err := r.reconcileFailOpen(context.Background(), req, logr.Discard(), falconAdmission)
+	require.NoError(t, err)
+	assert.True(t, admissionBypassed(falconAdmission))
+
+	webhook := &arv1.ValidatingWebhookConfiguration{}
+	err = r.Get(context.Background(), types.NamespacedName{Name: common.FalconAdmissionValidatingWebhookName}, webhook)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestReconcileFailOpen_Disabled(t *testing.T) {
+	falconAdmission := failOpenAdmission(time.Now().Add(-10 * time.Minute))
+	disabled := false
+	falconAdmission.Spec.AdmissionConfig.FailOpen.Enabled = &disabled
+	r := failOpenReconciler(t, falconAdmission, failOpenWebhook())
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: falconAdmission.Name}}
+
+	_, err := r.reconcileFailOpen(context.Background(), req, logr.Discard(), falconAdmission)
+	require.NoError(t, err)
+	assert.False(t, admissionBypassed(falconAdmission))
+}
@@ -11,41 +11,58 @@ import (
 	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
 	"github.com/crowdstrike/falcon-operator/internal/controller/assets"
 	k8sutils "github.com/crowdstrike/falcon-operator/internal/controller/common"
+	"github.com/crowdstrike/falcon-operator/internal/controller/common/pullsecret"
+	"github.com/crowdstrike/falcon-operator/internal/controller/common/sensorversion"
 	"github.com/crowdstrike/falcon-operator/pkg/aws"
 	"github.com/crowdstrike/falcon-operator/pkg/common"
+	"github.com/crowdstrike/falcon-operator/pkg/gcp"
 	"github.com/crowdstrike/falcon-operator/pkg/registry/pulltoken"
 	"github.com/crowdstrike/falcon-operator/pkg/tls"
 	"github.com/crowdstrike/falcon-operator/version"
+	"github.com/crowdstrike/gofalcon/falcon"
 	"github.com/go-logr/logr"
 	imagev1 "github.com/openshift/api/image/v1"
 	"github.com/operator-framework/operator-lib/proxy"
 	arv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/equality"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+// configVersionKey is the pod template annotation bumped to roll out the Admission Controller after a configuration change
+const configVersionKey = "falcon.config.version"
+
 // FalconAdmissionReconciler reconciles a FalconAdmission object
 type FalconAdmissionReconciler struct {
 	client.Client
 	Reader    client.Reader
 	Scheme    *runtime.Scheme
+	Recorder  record.EventRecorder
 	OpenShift bool
+
+	reconcileObject     func(client.Object)
+	tracker             sensorversion.Tracker
+	pullSecretRefresher *pullsecret.Refresher
 }
 
 // SetupWithManager sets up the controller with the Manager.
-func (r *FalconAdmissionReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+func (r *FalconAdmissionReconciler) SetupWithManager(mgr ctrl.Manager, tracker sensorversion.Tracker, pullSecretRefresher *pullsecret.Refresher) error {
+	admissionController, err := ctrl.NewControllerManagedBy(mgr).
 		For(&falconv1alpha1.FalconAdmission{}).
 		Owns(&corev1.Namespace{}).
 		Owns(&corev1.ConfigMap{}).
@@ -54,7 +71,27 @@ func (r *FalconAdmissionReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&appsv1.Deployment{}).
 		Owns(&corev1.Service{}).
 		Owns(&arv1.ValidatingWebhookConfiguration{}).
-		Complete(r)
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Watches(&discoveryv1.EndpointSlice{}, handler.EnqueueRequestsFromMapFunc(r.endpointSliceToFalconAdmissions)).
+		Watches(&falconv1alpha1.FalconConfig{}, handler.EnqueueRequestsFromMapFunc(r.falconConfigToFalconAdmissions)).
+		Build(r)
+	if err != nil {
+		return err
+	}
+
+	r.reconcileObject, err = k8sutils.NewReconcileTrigger(admissionController)
+	if err != nil {
+		return err
+	}
+
+	r.tracker = tracker
+	r.pullSecretRefresher = pullSecretRefresher
+	return nil
+}
+
+// falconConfigToFalconAdmissions maps a FalconConfig to the FalconAdmission custom resources referencing it
+func (r *FalconAdmissionReconciler) falconConfigToFalconAdmissions(ctx context.Context, obj client.Object) []reconcile.Request {
+	return k8sutils.FalconConfigRequests(ctx, r.Client, &falconv1alpha1.FalconAdmissionList{}, obj)
 }
 
 func (r *FalconAdmissionReconciler) GetK8sClient() client.Client {
@@ -68,9 +105,10 @@ func (r *FalconAdmissionReconciler) GetK8sReader() client.Reader {
 //+kubebuilder:rbac:groups=falcon.crowdstrike.com,resources=falconadmissions,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=falcon.crowdstrike.com,resources=falconadmissions/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=falcon.crowdstrike.com,resources=falconadmissions/finalizers,verbs=update
+//+kubebuilder:rbac:groups=falcon.crowdstrike.com,resources=falconconfigs,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create;update;delete
 //+kubebuilder:rbac:groups="",resources=resourcequotas,verbs=get;list;watch;create;update;delete
-//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;delete
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;delete
 //+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;delete
 //+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;update
@@ -80,7 +118,10 @@ func (r *FalconAdmissionReconciler) GetK8sReader() client.Reader {
 //+kubebuilder:rbac:groups="apps",resources=replicasets,verbs=get;list;watch
 //+kubebuilder:rbac:groups="apps",resources=statefulsets,verbs=get;list;watch
 //+kubebuilder:rbac:groups="batch",resources=cronjobs;jobs,verbs=get;list;watch
-//+kubebuilder:rbac:groups="apps",resources=deployments,verbs=get;list;watch;create;update;delete
+//+kubebuilder:rbac:groups="apps",resources=deployments,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="discovery.k8s.io",resources=endpointslices,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups="policy",resources=poddisruptionbudgets,verbs=get;list;watch;create;update;delete
 //+kubebuilder:rbac:groups="coordination.k8s.io",resources=leases,verbs=get;list;watch;create;update;delete
 //+kubebuilder:rbac:groups="image.openshift.io",resources=imagestreams,verbs=get;list;watch;create;update;delete
 //+kubebuilder:rbac:groups="admissionregistration.k8s.io",resources=validatingwebhookconfigurations,verbs=get;list;watch;create;update;delete
@@ -105,6 +146,9 @@ func (r *FalconAdmissionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	err := r.Get(ctx, req.NamespacedName, falconAdmission)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
+			r.tracker.StopTracking(req.NamespacedName)
+			r.pullSecretRefresher.Unregister("FalconAdmission", req.NamespacedName)
+
 			// If the custom resource is not found then, it usually means that it was deleted or not created
 			// In this way, we will stop the reconciliation
 			log.Info("FalconAdmission resource not found. Ignoring since object must be deleted")
@@ -115,6 +159,8 @@ func (r *FalconAdmissionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, err
 	}
 
+	r.pullSecretRefresher.Register("FalconAdmission", req.NamespacedName, r.registryPullSecrets().Handler(r.Client))
+
 	validate, err := k8sutils.CheckRunningPodLabels(r.Reader, ctx, falconAdmission.Spec.InstallNamespace, common.CRLabels("deployment", falconAdmission.Name, common.FalconAdmissionController))
 	if err != nil {
 		return ctrl.Result{}, err
@@ -161,6 +207,31 @@ func (r *FalconAdmissionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		}
 	}
 
+	failOpenRequeue, err := r.reconcileFailOpen(ctx, req, log, falconAdmission)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// The rest of the reconciliation works on a copy of the FalconAdmission merged with the referenced FalconConfig. k8sutils.ConditionsUpdate
+	// and k8sutils.UpdateStatus keep its spec when refreshing and updating the status, so the merged settings are never written back.
+	falconAdmission, err = k8sutils.ResolveFalconConfig(ctx, r, falconAdmission)
+	if err != nil {
+		log.Error(err, "Failed to merge FalconConfig")
+		return ctrl.Result{}, err
+	}
+
+	if shouldTrackSensorVersions(falconAdmission) {
+		apiConfig, err := r.falconApiConfig(ctx, falconAdmission)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		getSensorVersion := sensorversion.NewFalconCloudQuery(falcon.KacSensor, apiConfig)
+		r.tracker.Track(req.NamespacedName, getSensorVersion, r.reconcileObjectWithName, falconAdmission.Spec.Advanced.IsAutoUpdatingForced())
+	} else {
+		r.tracker.StopTracking(req.NamespacedName)
+	}
+
 	if err := r.reconcileNamespace(ctx, req, log, falconAdmission); err != nil {
 		return ctrl.Result{}, err
 	}
@@ -186,7 +257,7 @@ func (r *FalconAdmissionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 				return ctrl.Result{}, fmt.Errorf("failed to reconcile Image Stream")
 			}
 			if stream == nil {
-				return ctrl.Result{}, nil
+				return ctrl.Result{RequeueAfter: failOpenRequeue}, nil
 			}
 		}
 
@@ -199,12 +270,16 @@ func (r *FalconAdmissionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 
 		if r.imageMirroringEnabled(falconAdmission) {
 			if err := r.PushImage(ctx, log, falconAdmission); err != nil {
+				if gcp.IsRepositoryCreating(err) {
+					log.Info("Waiting for the creation of the Artifact Registry repository", "Error", err.Error())
+					return ctrl.Result{RequeueAfter: gcp.ArtifactRegistryRequeueInterval}, nil
+				}
 				return ctrl.Result{}, fmt.Errorf("cannot refresh Falcon Admission image: %v", err)
 			}
 		} else {
 			updated, err = r.verifyCrowdStrike(ctx, log, falconAdmission)
 			if updated {
-				return ctrl.Result{}, nil
+				return ctrl.Result{RequeueAfter: failOpenRequeue}, nil
 			}
 			if err != nil {
 				log.Error(err, "Failed to verify CrowdStrike Admission Image Registry access")
@@ -274,6 +349,10 @@ func (r *FalconAdmissionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, err
 	}
 
+	if err := r.reconcilePodDisruptionBudget(ctx, req, log, falconAdmission); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	pod, err := k8sutils.GetReadyPod(r.Reader, ctx, falconAdmission.Spec.InstallNamespace, map[string]string{common.FalconComponentKey: common.FalconAdmissionController})
 	if err != nil && err != k8sutils.ErrNoWebhookServicePodReady {
 		log.Error(err, "Failed to find Ready admission controller pod")
@@ -290,7 +369,7 @@ func (r *FalconAdmissionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 			return ctrl.Result{}, err
 		}
 
-		return ctrl.Result{}, nil
+		return ctrl.Result{RequeueAfter: failOpenRequeue}, nil
 	}
 
 	if err := k8sutils.ConditionsUpdate(r.Client, ctx, req, log, falconAdmission, &falconAdmission.Status, metav1.Condition{
@@ -303,18 +382,24 @@ func (r *FalconAdmissionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, fmt.Errorf("failed to update FalconAdmission installation completion condition: %v", err)
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: failOpenRequeue}, nil
 }
 
 func (r *FalconAdmissionReconciler) reconcileResourceQuota(ctx context.Context, req ctrl.Request, log logr.Logger, falconAdmission *falconv1alpha1.FalconAdmission) error {
 	existingRQ := &corev1.ResourceQuota{}
 	defaultPodLimit := "5"
 
+	// Leave room for every replica plus the extra pod surged during a rolling update
+	if replicas := falconAdmission.GetReplicas(); replicas+1 > 5 {
+		defaultPodLimit = strconv.Itoa(int(replicas + 1))
+	}
+
 	if falconAdmission.Spec.ResQuota.PodLimit != "" {
 		defaultPodLimit = falconAdmission.Spec.ResQuota.PodLimit
 	}
 
 	rq := assets.ResourceQuota(falconAdmission.Name, falconAdmission.Spec.InstallNamespace, common.FalconAdmissionController, defaultPodLimit)
+	assets.ApplyResourceMetadata(rq, falconAdmission.Spec.ResourceMetadata)
 
 	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: falconAdmission.Name, Namespace: falconAdmission.Spec.InstallNamespace}, existingRQ)
 	if err != nil && apierrors.IsNotFound(err) {
@@ -330,7 +415,7 @@ func (r *FalconAdmissionReconciler) reconcileResourceQuota(ctx context.Context,
 	}
 
 	podLimit := resource.MustParse(defaultPodLimit)
-	if existingRQ.Spec.Hard["pods"] != podLimit {
+	if existingRQ.Spec.Hard["pods"] != podLimit || assets.SyncResourceMetadata(existingRQ, rq, falconAdmission.Spec.ResourceMetadata) {
 		err = k8sutils.Update(r.Client, ctx, req, log, falconAdmission, &falconAdmission.Status, rq)
 		if err != nil {
 			return err
@@ -340,6 +425,44 @@ func (r *FalconAdmissionReconciler) reconcileResourceQuota(ctx context.Context,
 	return nil
 }
 
+func (r *FalconAdmissionReconciler) reconcilePodDisruptionBudget(ctx context.Context, req ctrl.Request, log logr.Logger, falconAdmission *falconv1alpha1.FalconAdmission) error {
+	existingPDB := &policyv1.PodDisruptionBudget{}
+	minAvailable := intstr.FromInt32(1)
+
+	if falconAdmission.Spec.AdmissionConfig.PodDisruptionBudget.MinAvailable != nil {
+		minAvailable = *falconAdmission.Spec.AdmissionConfig.PodDisruptionBudget.MinAvailable
+	}
+
+	selector := common.CRLabels("deployment", falconAdmission.Name, common.FalconAdmissionController)
+	pdb := assets.PodDisruptionBudget(falconAdmission.Name, falconAdmission.Spec.InstallNamespace, common.FalconAdmissionController, selector, minAvailable)
+	assets.ApplyResourceMetadata(pdb, falconAdmission.Spec.ResourceMetadata)
+
+	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: falconAdmission.Name, Namespace: falconAdmission.Spec.InstallNamespace}, existingPDB)
+	if err != nil && apierrors.IsNotFound(err) {
+		if !falconAdmission.GetPodDisruptionBudgetEnabled() {
+			return nil
+		}
+
+		return k8sutils.Create(r.Client, r.Scheme, ctx, req, log, falconAdmission, &falconAdmission.Status, pdb)
+	} else if err != nil {
+		log.Error(err, "Failed to get FalconAdmission PodDisruptionBudget")
+		return err
+	}
+
+	if !falconAdmission.GetPodDisruptionBudgetEnabled() {
+		return k8sutils.Delete(r.Client, ctx, req, log, falconAdmission, &falconAdmission.Status, pdb)
+	}
+
+	metadataUpdated := assets.SyncResourceMetadata(existingPDB, pdb, falconAdmission.Spec.ResourceMetadata)
+	if metadataUpdated || !reflect.DeepEqual(pdb.Spec.MinAvailable, existingPDB.Spec.MinAvailable) || !reflect.DeepEqual(pdb.Spec.Selector, existingPDB.Spec.Selector) {
+		existingPDB.Spec.MinAvailable = pdb.Spec.MinAvailable
+		existingPDB.Spec.Selector = pdb.Spec.Selector
+		return k8sutils.Update(r.Client, ctx, req, log, falconAdmission, &falconAdmission.Status, existingPDB)
+	}
+
+	return nil
+}
+
 func (r *FalconAdmissionReconciler) reconcileTLSSecret(ctx context.Context, req ctrl.Request, log logr.Logger, falconAdmission *falconv1alpha1.FalconAdmission) (*corev1.Secret, error) {
 	existingTLSSecret := &corev1.Secret{}
 	name := falconAdmission.Name + "-tls"
@@ -371,6 +494,7 @@ func (r *FalconAdmissionReconciler) reconcileTLSSecret(ctx context.Context, req
 		}
 
 		admissionTLSSecret := assets.Secret(name, falconAdmission.Spec.InstallNamespace, common.FalconAdmissionController, secretData, corev1.SecretTypeTLS)
+		assets.ApplyResourceMetadata(admissionTLSSecret, falconAdmission.Spec.ResourceMetadata)
 		err = k8sutils.Create(r.Client, r.Scheme, ctx, req, log, falconAdmission, &falconAdmission.Status, admissionTLSSecret)
 		if err != nil {
 			return &corev1.Secret{}, err
@@ -394,6 +518,7 @@ func (r *FalconAdmissionReconciler) reconcileService(ctx context.Context, req ct
 	}
 
 	service := assets.Service(falconAdmission.Name, falconAdmission.Spec.InstallNamespace, common.FalconAdmissionController, selector, common.FalconAdmissionServiceHTTPSName, port)
+	assets.ApplyResourceMetadata(service, falconAdmission.Spec.ResourceMetadata)
 
 	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: falconAdmission.Name, Namespace: falconAdmission.Spec.InstallNamespace}, existingService)
 
@@ -417,6 +542,7 @@ func (r *FalconAdmissionReconciler) reconcileService(ctx context.Context, req ct
 		return false, err
 	}
 
+	metadataUpdated := assets.SyncResourceMetadata(existingService, service, falconAdmission.Spec.ResourceMetadata)
 	if !reflect.DeepEqual(service.Spec.Ports, existingService.Spec.Ports) {
 		existingService.Spec.Ports = service.Spec.Ports
 		if err := k8sutils.Update(r.Client, ctx, req, log, falconAdmission, &falconAdmission.Status, existingService); err != nil {
@@ -426,6 +552,10 @@ func (r *FalconAdmissionReconciler) reconcileService(ctx context.Context, req ct
 		return true, nil
 	}
 
+	if metadataUpdated {
+		return false, k8sutils.Update(r.Client, ctx, req, log, falconAdmission, &falconAdmission.Status, existingService)
+	}
+
 	return false, nil
 }
 
@@ -454,48 +584,89 @@ func (r *FalconAdmissionReconciler) reconcileAdmissionValidatingWebHook(ctx cont
 		failPolicy = falconAdmission.Spec.AdmissionConfig.FailurePolicy
 	}
 
+	// Keep admission control bypassed until the Admission Controller recovers from an outage
+	webhookEnabled := falconAdmission.GetAdmissionControlEnabled()
+	if admissionBypassed(falconAdmission) {
+		switch falconAdmission.GetFailOpenAction() {
+		case falconv1alpha1.FailOpenActionRemove:
+			webhookEnabled = false
+		default:
+			failPolicy = arv1.Ignore
+		}
+	}
+
 	if falconAdmission.Spec.AdmissionConfig.Port != nil {
 		port = *falconAdmission.Spec.AdmissionConfig.Port
 	}
 
-	webhook := assets.ValidatingWebhook(falconAdmission.Name, falconAdmission.Spec.InstallNamespace, common.FalconAdmissionValidatingWebhookName, cabundle, port, failPolicy, disabledNamespaces)
+	webhook := assets.ValidatingWebhook(falconAdmission.Name, falconAdmission.Spec.InstallNamespace, common.FalconAdmissionValidatingWebhookName, cabundle, port, failPolicy, disabledNamespaces, falconAdmission)
+	assets.ApplyResourceMetadata(webhook, falconAdmission.Spec.ResourceMetadata)
 	updated := false
 
+	// A ValidatingWebhookConfiguration without webhooks validates nothing, so it is removed when every rule is disabled
+	if len(webhook.Webhooks) == 0 && webhookEnabled {
+		log.Info("All the admission webhook rules are disabled, removing the FalconAdmission Validating Webhook")
+		webhookEnabled = false
+	}
+
 	err = common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: common.FalconAdmissionValidatingWebhookName}, existingWebhook)
 	switch {
-	case err == nil && !falconAdmission.GetAdmissionControlEnabled():
+	case err == nil && !webhookEnabled:
 		err = k8sutils.Delete(r.Client, ctx, req, log, falconAdmission, &falconAdmission.Status, webhook)
 		if err != nil {
 			return false, err
 		}
 		return false, nil
-	case apierrors.IsNotFound(err) && falconAdmission.GetAdmissionControlEnabled():
+	case apierrors.IsNotFound(err) && webhookEnabled:
 		err = k8sutils.Create(r.Client, r.Scheme, ctx, req, log, falconAdmission, &falconAdmission.Status, webhook)
 		if err != nil {
 			return false, err
 		}
 		return false, nil
-	case apierrors.IsNotFound(err) && !falconAdmission.GetAdmissionControlEnabled():
+	case apierrors.IsNotFound(err) && !webhookEnabled:
 		return false, nil
 	case err != nil:
 		log.Error(err, "Failed to get FalconAdmission Validating Webhook")
 		return false, err
 	}
 
-	if !reflect.DeepEqual(webhook.Webhooks[0].FailurePolicy, existingWebhook.Webhooks[0].FailurePolicy) {
+	if len(webhook.Webhooks) != len(existingWebhook.Webhooks) {
 		updated = true
-	}
+	} else {
+		for i := range webhook.Webhooks {
+			if webhook.Webhooks[i].Name != existingWebhook.Webhooks[i].Name {
+				updated = true
+			}
 
-	if !reflect.DeepEqual(webhook.Webhooks[0].ClientConfig, existingWebhook.Webhooks[0].ClientConfig) {
-		updated = true
-	}
+			if !reflect.DeepEqual(webhook.Webhooks[i].FailurePolicy, existingWebhook.Webhooks[i].FailurePolicy) {
+				updated = true
+			}
 
-	if !reflect.DeepEqual(webhook.Webhooks[0].NamespaceSelector, existingWebhook.Webhooks[0].NamespaceSelector) {
-		updated = true
+			if !reflect.DeepEqual(webhook.Webhooks[i].ClientConfig, existingWebhook.Webhooks[i].ClientConfig) {
+				updated = true
+			}
+
+			if !reflect.DeepEqual(webhook.Webhooks[i].NamespaceSelector, existingWebhook.Webhooks[i].NamespaceSelector) {
+				updated = true
+			}
+
+			if !reflect.DeepEqual(webhook.Webhooks[i].ObjectSelector, existingWebhook.Webhooks[i].ObjectSelector) {
+				updated = true
+			}
+
+			if !reflect.DeepEqual(webhook.Webhooks[i].TimeoutSeconds, existingWebhook.Webhooks[i].TimeoutSeconds) {
+				updated = true
+			}
+
+			if !reflect.DeepEqual(webhook.Webhooks[i].Rules, existingWebhook.Webhooks[i].Rules) {
+				updated = true
+			}
+		}
 	}
 
 	if updated {
 		existingWebhook.Webhooks = webhook.Webhooks
+		assets.SyncResourceMetadata(existingWebhook, webhook, falconAdmission.Spec.ResourceMetadata)
 		if err := k8sutils.Update(r.Client, ctx, req, log, falconAdmission, &falconAdmission.Status, existingWebhook); err != nil {
 			return false, err
 		}
@@ -503,6 +674,10 @@ func (r *FalconAdmissionReconciler) reconcileAdmissionValidatingWebHook(ctx cont
 		return true, nil
 	}
 
+	if assets.SyncResourceMetadata(existingWebhook, webhook, falconAdmission.Spec.ResourceMetadata) {
+		return false, k8sutils.Update(r.Client, ctx, req, log, falconAdmission, &falconAdmission.Status, existingWebhook)
+	}
+
 	return false, nil
 }
 
@@ -519,7 +694,11 @@ func (r *FalconAdmissionReconciler) reconcileAdmissionDeployment(ctx context.Con
 
 	existingDeployment := &appsv1.Deployment{}
 	dep := assets.AdmissionDeployment(falconAdmission.Name, falconAdmission.Spec.InstallNamespace, common.FalconAdmissionController, imageUri, falconAdmission, log)
-	updated := false
+	assets.ApplyResourceMetadata(dep, falconAdmission.Spec.ResourceMetadata)
+
+	if err := assets.ApplyPodTemplateOverride(&dep.Spec.Template, falconAdmission.Spec.AdmissionConfig.PodTemplateOverride); err != nil {
+		return fmt.Errorf("unable to apply the Admission Controller pod template override: %v", err)
+	}
 
 	if len(proxy.ReadProxyVarsFromEnv()) > 0 {
 		for i, container := range dep.Spec.Template.Spec.Containers {
@@ -540,94 +719,16 @@ func (r *FalconAdmissionReconciler) reconcileAdmissionDeployment(ctx context.Con
 		return err
 	}
 
-	if len(proxy.ReadProxyVarsFromEnv()) > 0 {
-		for i, container := range existingDeployment.Spec.Template.Spec.Containers {
-			newContainerEnv := common.AppendUniqueEnvVars(container.Env, proxy.ReadProxyVarsFromEnv())
-			updatedContainerEnv := common.UpdateEnvVars(container.Env, proxy.ReadProxyVarsFromEnv())
-			if !equality.Semantic.DeepEqual(existingDeployment.Spec.Template.Spec.Containers[i].Env, newContainerEnv) {
-				existingDeployment.Spec.Template.Spec.Containers[i].Env = newContainerEnv
-				updated = true
-			}
-			if !equality.Semantic.DeepEqual(existingDeployment.Spec.Template.Spec.Containers[i].Env, updatedContainerEnv) {
-				existingDeployment.Spec.Template.Spec.Containers[i].Env = updatedContainerEnv
-				updated = true
-			}
-			if updated {
-				log.Info("Updating FalconNodeSensor Deployment Proxy Settings")
-			}
-		}
-	}
-
-	if !reflect.DeepEqual(dep.Spec.Template.Spec.Containers[0].Image, existingDeployment.Spec.Template.Spec.Containers[0].Image) {
-		existingDeployment.Spec.Template.Spec.Containers[0].Image = dep.Spec.Template.Spec.Containers[0].Image
-		updated = true
-	}
-
-	if !reflect.DeepEqual(dep.Spec.Template.Spec.Containers[0].ImagePullPolicy, existingDeployment.Spec.Template.Spec.Containers[0].ImagePullPolicy) {
-		existingDeployment.Spec.Template.Spec.Containers[0].ImagePullPolicy = dep.Spec.Template.Spec.Containers[0].ImagePullPolicy
-		updated = true
-	}
-
-	if !reflect.DeepEqual(dep.Spec.Template.Spec.ImagePullSecrets, existingDeployment.Spec.Template.Spec.ImagePullSecrets) {
-		existingDeployment.Spec.Template.Spec.ImagePullSecrets = dep.Spec.Template.Spec.ImagePullSecrets
-		updated = true
-	}
-
-	if !reflect.DeepEqual(dep.Spec.Template.Spec.Containers[0].Ports, existingDeployment.Spec.Template.Spec.Containers[0].Ports) {
-		existingDeployment.Spec.Template.Spec.Containers[0].Ports = dep.Spec.Template.Spec.Containers[0].Ports
-		updated = true
-	}
-
-	if !reflect.DeepEqual(existingDeployment.Spec.Strategy.RollingUpdate, dep.Spec.Strategy.RollingUpdate) {
-		existingDeployment.Spec.Strategy.RollingUpdate = dep.Spec.Strategy.RollingUpdate
-		updated = true
-	}
-
-	if !reflect.DeepEqual(dep.Spec.Replicas, existingDeployment.Spec.Replicas) {
-		existingDeployment.Spec.Replicas = dep.Spec.Replicas
-		updated = true
-	}
-
-	if !reflect.DeepEqual(dep.Spec.Template.Spec.TopologySpreadConstraints, existingDeployment.Spec.Template.Spec.TopologySpreadConstraints) {
-		existingDeployment.Spec.Template.Spec.TopologySpreadConstraints = dep.Spec.Template.Spec.TopologySpreadConstraints
-		updated = true
-	}
-
-	if !reflect.DeepEqual(dep.Spec.Template.Spec.Affinity.NodeAffinity, existingDeployment.Spec.Template.Spec.Affinity.NodeAffinity) {
-		existingDeployment.Spec.Template.Spec.Affinity.NodeAffinity = dep.Spec.Template.Spec.Affinity.NodeAffinity
-		updated = true
-	}
-
-	if len(dep.Spec.Template.Spec.Containers) != len(existingDeployment.Spec.Template.Spec.Containers) {
-		existingDeployment.Spec.Template.Spec.Containers = dep.Spec.Template.Spec.Containers
-		updated = true
-	} else {
-		for i, containers := range dep.Spec.Template.Spec.Containers {
-			if !reflect.DeepEqual(containers.Resources, existingDeployment.Spec.Template.Spec.Containers[i].Resources) {
-				existingDeployment.Spec.Template.Spec.Containers[i].Resources = containers.Resources
-				updated = true
-			}
-
-			if !reflect.DeepEqual(containers.LivenessProbe.ProbeHandler.HTTPGet.Port, existingDeployment.Spec.Template.Spec.Containers[i].LivenessProbe.ProbeHandler.HTTPGet.Port) {
-				existingDeployment.Spec.Template.Spec.Containers[i].LivenessProbe.ProbeHandler.HTTPGet.Port = containers.LivenessProbe.ProbeHandler.HTTPGet.Port
-				updated = true
-			}
-
-			if !reflect.DeepEqual(containers.StartupProbe.ProbeHandler.HTTPGet.Port, existingDeployment.Spec.Template.Spec.Containers[i].StartupProbe.ProbeHandler.HTTPGet.Port) {
-				existingDeployment.Spec.Template.Spec.Containers[i].StartupProbe.ProbeHandler.HTTPGet.Port = containers.StartupProbe.ProbeHandler.HTTPGet.Port
-				updated = true
-			}
-			if !reflect.DeepEqual(containers.Env, existingDeployment.Spec.Template.Spec.Containers[i].Env) {
-				existingDeployment.Spec.Template.Spec.Containers[i].Env = containers.Env
-				updated = true
-			}
+	// Keep the configuration version bumped by admissionDeploymentUpdate so that applying the Deployment does not roll it out again
+	if version, ok := existingDeployment.Spec.Template.Annotations[configVersionKey]; ok {
+		if dep.Spec.Template.Annotations == nil {
+			dep.Spec.Template.Annotations = map[string]string{}
 		}
+		dep.Spec.Template.Annotations[configVersionKey] = version
 	}
 
-	if updated {
-		if err := k8sutils.Update(r.Client, ctx, req, log, falconAdmission, &falconAdmission.Status, existingDeployment); err != nil {
-			return err
-		}
+	if _, err := k8sutils.Apply(r.Client, ctx, req, log, falconAdmission, &falconAdmission.Status, existingDeployment, dep, falconAdmission.Spec.FieldOwnership); err != nil {
+		return err
 	}
 
 	return nil
@@ -646,6 +747,7 @@ func (r *FalconAdmissionReconciler) reconcileRegistrySecret(ctx context.Context,
 
 	secretData := map[string][]byte{corev1.DockerConfigJsonKey: common.CleanDecodedBase64(pulltoken)}
 	secret := assets.Secret(common.FalconPullSecretName, falconAdmission.Spec.InstallNamespace, "falcon-operator", secretData, corev1.SecretTypeDockerConfigJson)
+	assets.ApplyResourceMetadata(secret, falconAdmission.Spec.ResourceMetadata)
 	existingSecret := &corev1.Secret{}
 
 	err = common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: common.FalconPullSecretName, Namespace: falconAdmission.Spec.InstallNamespace}, existingSecret)
@@ -656,26 +758,57 @@ func (r *FalconAdmissionReconciler) reconcileRegistrySecret(ctx context.Context,
 			return err
 		}
 
-		return nil
+		return r.registryPullSecrets().UpdateRefreshTime(ctx, r.Client, falconAdmission)
 	} else if err != nil {
 		log.Error(err, "Failed to get FalconAdmission Registry Pull Secret")
 		return err
 	}
 
+	metadataUpdated := assets.SyncResourceMetadata(existingSecret, secret, falconAdmission.Spec.ResourceMetadata)
 	if !reflect.DeepEqual(secret.Data, existingSecret.Data) {
+		existingSecret.Data = secret.Data
 		err = k8sutils.Update(r.Client, ctx, req, log, falconAdmission, &falconAdmission.Status, existingSecret)
 		if err != nil {
 			return err
 		}
+
+		return r.registryPullSecrets().UpdateRefreshTime(ctx, r.Client, falconAdmission)
+	}
+
+	if metadataUpdated {
+		return k8sutils.Update(r.Client, ctx, req, log, falconAdmission, &falconAdmission.Status, existingSecret)
 	}
 
 	return nil
 }
 
+// registryPullSecrets gives the pull secret refresher access to the registry pull secrets of the FalconAdmission
+func (r *FalconAdmissionReconciler) registryPullSecrets() pullsecret.Accessors[*falconv1alpha1.FalconAdmission] {
+	return pullsecret.Accessors[*falconv1alpha1.FalconAdmission]{
+		Kind: "FalconAdmission",
+		New:  func() *falconv1alpha1.FalconAdmission { return &falconv1alpha1.FalconAdmission{} },
+		ApiConfig: func(ctx context.Context, falconAdmission *falconv1alpha1.FalconAdmission) (*falcon.ApiConfig, error) {
+			falconAdmission, err := k8sutils.ResolveFalconConfig(ctx, r, falconAdmission)
+			if err != nil {
+				return nil, err
+			}
+
+			if falconAdmission.Spec.FalconAPI == nil && !falconAdmission.Spec.FalconSecret.Enabled {
+				return nil, nil
+			}
+			return r.falconApiConfig(ctx, falconAdmission)
+		},
+		RefreshTime: func(falconAdmission *falconv1alpha1.FalconAdmission) **metav1.Time {
+			return &falconAdmission.Status.RegistryTokenRefreshTime
+		},
+	}
+}
+
 func (r *FalconAdmissionReconciler) reconcileImageStream(ctx context.Context, req ctrl.Request, log logr.Logger, falconAdmission *falconv1alpha1.FalconAdmission) (*imagev1.ImageStream, error) {
 	const imageStreamName = "falcon-admission-controller"
 	namespace := r.imageNamespace(falconAdmission)
 	imageStream := assets.ImageStream(imageStreamName, namespace, common.FalconAdmissionController)
+	assets.ApplyResourceMetadata(imageStream, falconAdmission.Spec.ResourceMetadata)
 	existingImageStream := &imagev1.ImageStream{}
 
 	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: imageStreamName, Namespace: namespace}, existingImageStream)
@@ -691,7 +824,8 @@ func (r *FalconAdmissionReconciler) reconcileImageStream(ctx context.Context, re
 		return existingImageStream, err
 	}
 
-	if !reflect.DeepEqual(imageStream.Spec, existingImageStream.Spec) {
+	metadataUpdated := assets.SyncResourceMetadata(existingImageStream, imageStream, falconAdmission.Spec.ResourceMetadata)
+	if metadataUpdated || !reflect.DeepEqual(imageStream.Spec, existingImageStream.Spec) {
 		existingImageStream.Spec = imageStream.Spec
 		err = k8sutils.Update(r.Client, ctx, req, log, falconAdmission, &falconAdmission.Status, existingImageStream)
 		if err != nil {
@@ -705,6 +839,7 @@ func (r *FalconAdmissionReconciler) reconcileImageStream(ctx context.Context, re
 func (r *FalconAdmissionReconciler) reconcileNamespace(ctx context.Context, req ctrl.Request, log logr.Logger, falconAdmission *falconv1alpha1.FalconAdmission) error {
 	namespace := assets.Namespace(falconAdmission.Spec.InstallNamespace)
 	namespace.ObjectMeta.Labels = common.CRLabels("namespace", falconAdmission.Spec.InstallNamespace, common.FalconAdmissionController)
+	assets.ApplyResourceMetadata(namespace, falconAdmission.Spec.ResourceMetadata)
 	existingNamespace := &corev1.Namespace{}
 
 	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: falconAdmission.Spec.InstallNamespace}, existingNamespace)
@@ -720,12 +855,15 @@ func (r *FalconAdmissionReconciler) reconcileNamespace(ctx context.Context, req
 		return err
 	}
 
+	if assets.SyncResourceMetadata(existingNamespace, namespace, falconAdmission.Spec.ResourceMetadata) {
+		return k8sutils.Update(r.Client, ctx, req, log, falconAdmission, &falconAdmission.Status, existingNamespace)
+	}
+
 	return nil
 }
 
 func (r *FalconAdmissionReconciler) admissionDeploymentUpdate(ctx context.Context, req ctrl.Request, log logr.Logger, falconAdmission *falconv1alpha1.FalconAdmission) error {
 	existingDeployment := &appsv1.Deployment{}
-	configVersion := "falcon.config.version"
 	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: falconAdmission.Name, Namespace: falconAdmission.Spec.InstallNamespace}, existingDeployment)
 	if err != nil && apierrors.IsNotFound(err) {
 		return err
@@ -734,16 +872,16 @@ func (r *FalconAdmissionReconciler) admissionDeploymentUpdate(ctx context.Contex
 		return err
 	}
 
-	_, ok := existingDeployment.Spec.Template.Annotations[configVersion]
+	_, ok := existingDeployment.Spec.Template.Annotations[configVersionKey]
 	if ok {
-		i, err := strconv.Atoi(existingDeployment.Spec.Template.Annotations[configVersion])
+		i, err := strconv.Atoi(existingDeployment.Spec.Template.Annotations[configVersionKey])
 		if err != nil {
 			return err
 		}
 
-		existingDeployment.Spec.Template.Annotations[configVersion] = strconv.Itoa(i + 1)
+		existingDeployment.Spec.Template.Annotations[configVersionKey] = strconv.Itoa(i + 1)
 	} else {
-		existingDeployment.Spec.Template.Annotations[configVersion] = "1"
+		existingDeployment.Spec.Template.Annotations[configVersionKey] = "1"
 	}
 
 	log.Info("Rolling FalconAdmission Deployment due to non-deployment configuration change")
@@ -759,3 +897,19 @@ func (r *FalconAdmissionReconciler) injectFalconSecretData(ctx context.Context,
 
 	return k8sutils.InjectFalconSecretData(ctx, r, falconAdmission)
 }
+
+func (r *FalconAdmissionReconciler) reconcileObjectWithName(ctx context.Context, name types.NamespacedName) error {
+	obj := &falconv1alpha1.FalconAdmission{}
+	err := r.Get(ctx, name, obj)
+	if err != nil {
+		return err
+	}
+
+	log.FromContext(ctx).Info("reconciling FalconAdmission object", "namespace", obj.Namespace, "name", obj.Name)
+	r.reconcileObject(obj)
+	return nil
+}
+
+func shouldTrackSensorVersions(obj *falconv1alpha1.FalconAdmission) bool {
+	return obj.Spec.FalconAPI != nil && obj.Spec.Advanced.IsAutoUpdating()
+}
@@ -7,6 +7,7 @@ import (
 
 	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
 	k8sutils "github.com/crowdstrike/falcon-operator/internal/controller/common"
+	"github.com/crowdstrike/falcon-operator/internal/controller/common/sensorversion"
 	internalErrors "github.com/crowdstrike/falcon-operator/internal/errors"
 	"github.com/crowdstrike/falcon-operator/pkg/common"
 	. "github.com/onsi/ginkgo/v2"
@@ -150,10 +151,14 @@ var _ = Describe("FalconAdmission controller", func() {
 			}, 20*time.Second, time.Second).Should(Succeed())
 
 			By("Reconciling the custom resource created")
+			tracker, cancel := sensorversion.NewTestTracker()
+			defer cancel()
+
 			falconAdmissionReconciler := &FalconAdmissionReconciler{
-				Client: k8sClient,
-				Reader: k8sReader,
-				Scheme: k8sClient.Scheme(),
+				Client:  k8sClient,
+				Reader:  k8sReader,
+				Scheme:  k8sClient.Scheme(),
+				tracker: tracker,
 			}
 			_, err = falconAdmissionReconciler.Reconcile(ctx, reconcile.Request{
 				NamespacedName: admissionNamespacedName,
@@ -280,10 +285,14 @@ var _ = Describe("FalconAdmission controller", func() {
 			}, 6*time.Second, time.Second).Should(Succeed())
 
 			By("Reconciling the custom resource created")
+			tracker, cancel := sensorversion.NewTestTracker()
+			defer cancel()
+
 			falconAdmissionReconciler := &FalconAdmissionReconciler{
-				Client: k8sClient,
-				Reader: k8sReader,
-				Scheme: k8sClient.Scheme(),
+				Client:  k8sClient,
+				Reader:  k8sReader,
+				Scheme:  k8sClient.Scheme(),
+				tracker: tracker,
 			}
 
 			_, err = falconAdmissionReconciler.Reconcile(ctx, reconcile.Request{
@@ -413,10 +422,14 @@ var _ = Describe("FalconAdmission controller", func() {
 			}, 6*time.Second, time.Second).Should(Succeed())
 
 			By("Reconciling the custom resource created")
+			tracker, cancel := sensorversion.NewTestTracker()
+			defer cancel()
+
 			falconAdmissionReconciler := &FalconAdmissionReconciler{
-				Client: k8sClient,
-				Reader: k8sReader,
-				Scheme: k8sClient.Scheme(),
+				Client:  k8sClient,
+				Reader:  k8sReader,
+				Scheme:  k8sClient.Scheme(),
+				tracker: tracker,
 			}
 
 			_, err = falconAdmissionReconciler.Reconcile(ctx, reconcile.Request{
@@ -445,10 +458,14 @@ var _ = Describe("FalconAdmission controller", func() {
 			}, time.Minute, time.Second).Should(Succeed())
 
 			By("Reconciling the custom resource create - with admission control disabled")
+			tracker, cancel := sensorversion.NewTestTracker()
+			defer cancel()
+
 			falconAdmissionReconciler := &FalconAdmissionReconciler{
-				Client: k8sClient,
-				Reader: k8sReader,
-				Scheme: k8sClient.Scheme(),
+				Client:  k8sClient,
+				Reader:  k8sReader,
+				Scheme:  k8sClient.Scheme(),
+				tracker: tracker,
 			}
 			_, err = falconAdmissionReconciler.Reconcile(ctx, reconcile.Request{
 				NamespacedName: admissionNamespacedName,
@@ -4,12 +4,13 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	k8sutils "github.com/crowdstrike/falcon-operator/internal/controller/common"
+	"github.com/crowdstrike/falcon-operator/internal/controller/common/sensor"
 	"github.com/crowdstrike/falcon-operator/internal/controller/image"
 	"github.com/crowdstrike/falcon-operator/pkg/aws"
 	"github.com/crowdstrike/falcon-operator/pkg/common"
@@ -69,7 +70,7 @@ func (r *FalconAdmissionReconciler) PushImage(ctx context.Context, log logr.Logg
 		Reason:  "Pushed",
 	})
 
-	return r.Client.Status().Update(ctx, falconAdmission)
+	return k8sutils.UpdateStatus(ctx, r.Client, falconAdmission)
 }
 
 func (r *FalconAdmissionReconciler) verifyCrowdStrike(ctx context.Context, log logr.Logger, falconAdmission *falconv1alpha1.FalconAdmission) (bool, error) {
@@ -96,7 +97,7 @@ func (r *FalconAdmissionReconciler) verifyCrowdStrike(ctx context.Context, log l
 		ObservedGeneration: falconAdmission.GetGeneration(),
 	})
 
-	return true, r.Status().Update(ctx, falconAdmission)
+	return true, k8sutils.UpdateStatus(ctx, r.Client, falconAdmission)
 }
 
 func (r *FalconAdmissionReconciler) registryUri(ctx context.Context, falconAdmission *falconv1alpha1.FalconAdmission) (string, error) {
@@ -120,6 +121,8 @@ func (r *FalconAdmissionReconciler) registryUri(ctx context.Context, falconAdmis
 		}
 
 		return "gcr.io/" + projectId + "/falcon-kac", nil
+	case falconv1alpha1.RegistryTypeGAR:
+		return gcp.ArtifactRegistryImageUri(ctx, falconAdmission.Spec.Registry, "falcon-kac")
 	case falconv1alpha1.RegistryTypeECR:
 		repo, err := aws.UpsertECRRepo(ctx, "falcon-kac")
 		if err != nil {
@@ -188,28 +191,28 @@ func (r *FalconAdmissionReconciler) setImageTag(ctx context.Context, falconAdmis
 	if falconAdmission.Spec.Image != "" {
 		falconAdmission.Status.Sensor = common.ImageVersion(falconAdmission.Spec.Image)
 
-		return *falconAdmission.Status.Sensor, r.Client.Status().Update(ctx, falconAdmission)
+		return *falconAdmission.Status.Sensor, k8sutils.UpdateStatus(ctx, r.Client, falconAdmission)
 	}
 
 	if os.Getenv("RELATED_IMAGE_ADMISSION_CONTROLLER") != "" && falconAdmission.Spec.FalconAPI == nil {
 		image := os.Getenv("RELATED_IMAGE_ADMISSION_CONTROLLER")
 		falconAdmission.Status.Sensor = common.ImageVersion(image)
 
-		return *falconAdmission.Status.Sensor, r.Client.Status().Update(ctx, falconAdmission)
+		return *falconAdmission.Status.Sensor, k8sutils.UpdateStatus(ctx, r.Client, falconAdmission)
 	}
 
-	// Otherwise, get the newest version matching the requested version string
+	// Otherwise, get the newest version matching the requested version string or the sensor update policy
 	apiConfig, err := r.falconApiConfig(ctx, falconAdmission)
 	if err != nil {
 		return "", err
 	}
 
-	registry, err := falcon_registry.NewFalconRegistry(ctx, apiConfig)
+	imageRepo, err := sensor.NewImageRepository(ctx, apiConfig)
 	if err != nil {
 		return "", err
 	}
 
-	tag, err := registry.LastContainerTag(ctx, falcon.KacSensor, falconAdmission.Spec.Version)
+	tag, err := imageRepo.GetPreferredImage(ctx, falcon.KacSensor, falconAdmission.Spec.Version, falconAdmission.Spec.Advanced.UpdatePolicy)
 	if err == nil {
 		falconAdmission.Status.Sensor = common.ImageVersion(tag)
 	}
@@ -218,7 +221,7 @@ func (r *FalconAdmissionReconciler) setImageTag(ctx context.Context, falconAdmis
 }
 
 func (r *FalconAdmissionReconciler) pushAuth(ctx context.Context, falconAdmission *falconv1alpha1.FalconAdmission) (auth.Credentials, error) {
-	return pushtoken.GetCredentials(ctx, falconAdmission.Spec.Registry.Type,
+	return pushtoken.GetCredentials(ctx, falconAdmission.Spec.Registry,
 		k8s_utils.QuerySecretsInNamespace(r.Client, r.imageNamespace(falconAdmission)),
 	)
 }
@@ -246,5 +249,9 @@ func (r *FalconAdmissionReconciler) imageMirroringEnabled(falconAdmission *falco
 }
 
 func (r *FalconAdmissionReconciler) versionLock(falconAdmission *falconv1alpha1.FalconAdmission) bool {
-	return (falconAdmission.Spec.Version != nil && falconAdmission.Status.Sensor != nil && strings.Contains(*falconAdmission.Status.Sensor, *falconAdmission.Spec.Version)) || (falconAdmission.Spec.Version == nil && falconAdmission.Status.Sensor != nil)
+	if falconAdmission.Status.Sensor == nil || falconAdmission.Spec.Advanced.HasUpdatePolicy() || falconAdmission.Spec.Advanced.IsAutoUpdating() {
+		return false
+	}
+
+	return falcon_registry.VersionMatches(*falconAdmission.Status.Sensor, falconAdmission.Spec.Version)
 }
@@ -36,6 +36,22 @@ func TestVersionLock_WithSameVersion(t *testing.T) {
 	assert.True(t, reconciler.versionLock(admission))
 }
 
+func TestVersionLock_WithNormalAutoUpdate(t *testing.T) {
+	reconciler := &FalconAdmissionReconciler{}
+	admission := &falconv1alpha1.FalconAdmission{}
+	admission.Status.Sensor = stringPointer("some sensor")
+	admission.Spec.Advanced.AutoUpdate = stringPointer(falconv1alpha1.Normal)
+	assert.False(t, reconciler.versionLock(admission))
+}
+
+func TestVersionLock_WithUpdatePolicy(t *testing.T) {
+	reconciler := &FalconAdmissionReconciler{}
+	admission := &falconv1alpha1.FalconAdmission{}
+	admission.Status.Sensor = stringPointer("some sensor")
+	admission.Spec.Advanced.UpdatePolicy = stringPointer("some policy")
+	assert.False(t, reconciler.versionLock(admission))
+}
+
 func stringPointer(s string) *string {
 	return &s
 }
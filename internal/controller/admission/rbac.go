@@ -39,6 +39,7 @@ func (r *FalconAdmissionReconciler) reconcileServiceAccount(ctx context.Context,
 		common.FalconAdmissionController,
 		falconAdmission.Spec.AdmissionConfig.ServiceAccount.Annotations,
 		imagePullSecrets)
+	assets.ApplyResourceMetadata(serviceAccount, falconAdmission.Spec.ResourceMetadata)
 
 	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: common.AdmissionServiceAccountName, Namespace: falconAdmission.Spec.InstallNamespace}, existingServiceAccount)
 	if err != nil && apierrors.IsNotFound(err) {
@@ -80,6 +81,7 @@ func (r *FalconAdmissionReconciler) reconcileClusterRoleBinding(ctx context.Cont
 		common.AdmissionServiceAccountName,
 		common.FalconAdmissionController,
 		[]rbacv1.Subject{})
+	assets.ApplyResourceMetadata(clusterRoleBinding, falconAdmission.Spec.ResourceMetadata)
 	existingClusterRoleBinding := &rbacv1.ClusterRoleBinding{}
 
 	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: admissionClusterRoleBindingName}, existingClusterRoleBinding)
@@ -106,7 +108,7 @@ func (r *FalconAdmissionReconciler) reconcileClusterRoleBinding(ctx context.Cont
 			return err
 		}
 		// If RoleRef is the same but Subjects have changed, update the object and post to k8s api
-	} else if !reflect.DeepEqual(clusterRoleBinding.Subjects, existingClusterRoleBinding.Subjects) {
+	} else if assets.SyncResourceMetadata(existingClusterRoleBinding, clusterRoleBinding, falconAdmission.Spec.ResourceMetadata) || !reflect.DeepEqual(clusterRoleBinding.Subjects, existingClusterRoleBinding.Subjects) {
 		existingClusterRoleBinding.Subjects = clusterRoleBinding.Subjects
 		err = k8sutils.Update(r.Client, ctx, req, log, falconAdmission, &falconAdmission.Status, existingClusterRoleBinding)
 		if err != nil {
@@ -119,6 +121,7 @@ func (r *FalconAdmissionReconciler) reconcileClusterRoleBinding(ctx context.Cont
 
 func (r *FalconAdmissionReconciler) reconcileRole(ctx context.Context, req ctrl.Request, log logr.Logger, falconAdmission *falconv1alpha1.FalconAdmission) error {
 	role := assets.Role(admissionControllerRoleName, falconAdmission.Spec.InstallNamespace)
+	assets.ApplyResourceMetadata(role, falconAdmission.Spec.ResourceMetadata)
 	existingRole := &rbacv1.Role{}
 
 	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: admissionControllerRoleName, Namespace: falconAdmission.Spec.InstallNamespace}, existingRole)
@@ -134,7 +137,8 @@ func (r *FalconAdmissionReconciler) reconcileRole(ctx context.Context, req ctrl.
 		return err
 	}
 
-	if !reflect.DeepEqual(role.Rules, existingRole.Rules) {
+	metadataUpdated := assets.SyncResourceMetadata(existingRole, role, falconAdmission.Spec.ResourceMetadata)
+	if metadataUpdated || !reflect.DeepEqual(role.Rules, existingRole.Rules) {
 		existingRole.Rules = role.Rules
 		err = k8sutils.Update(r.Client, ctx, req, log, falconAdmission, &falconAdmission.Status, existingRole)
 		if err != nil {
@@ -150,6 +154,7 @@ func (r *FalconAdmissionReconciler) reconcileRoleBinding(ctx context.Context, re
 		falconAdmission.Spec.InstallNamespace,
 		admissionControllerRoleName,
 		common.AdmissionServiceAccountName)
+	assets.ApplyResourceMetadata(roleBinding, falconAdmission.Spec.ResourceMetadata)
 	existingRoleBinding := &rbacv1.RoleBinding{}
 
 	err := r.Get(ctx, types.NamespacedName{Name: admissionControllerRoleBindingName, Namespace: falconAdmission.Spec.InstallNamespace}, existingRoleBinding)
@@ -176,7 +181,7 @@ func (r *FalconAdmissionReconciler) reconcileRoleBinding(ctx context.Context, re
 			return err
 		}
 		// If RoleRef is the same but Subjects have changed, update the object and post to k8s api
-	} else if !reflect.DeepEqual(roleBinding.Subjects, existingRoleBinding.Subjects) {
+	} else if assets.SyncResourceMetadata(existingRoleBinding, roleBinding, falconAdmission.Spec.ResourceMetadata) || !reflect.DeepEqual(roleBinding.Subjects, existingRoleBinding.Subjects) {
 		existingRoleBinding.Subjects = roleBinding.Subjects
 		err = k8sutils.Update(r.Client, ctx, req, log, falconAdmission, &falconAdmission.Status, existingRoleBinding)
 		if err != nil {
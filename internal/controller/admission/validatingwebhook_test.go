@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	arv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestReconcileAdmissionValidatingWebHook_AllRulesDisabled(t *testing.T) {
+	falconAdmission := &falconv1alpha1.FalconAdmission{
+		ObjectMeta: metav1.ObjectMeta{Name: "falcon-kac"},
+		Spec:       falconv1alpha1.FalconAdmissionSpec{InstallNamespace: "falcon-kac"},
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, falconv1alpha1.AddToScheme(scheme))
+
+	// The API server ignores the namespace of cluster-scoped resources, unlike the fake client
+	clusterScoped := func(obj client.Object) client.Object {
+		if _, ok := obj.(*arv1.ValidatingWebhookConfiguration); ok {
+			obj.SetNamespace("")
+		}
+		return obj
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(falconAdmission).WithObjects(falconAdmission).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				return c.Create(ctx, clusterScoped(obj), opts...)
+			},
+			Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				return c.Delete(ctx, clusterScoped(obj), opts...)
+			},
+		}).Build()
+	r := &FalconAdmissionReconciler{Client: c, Reader: c, Scheme: scheme}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: falconAdmission.Name}}
+
+	webhookExists := func() bool {
+		webhooks := &arv1.ValidatingWebhookConfigurationList{}
+		require.NoError(t, r.List(context.Background(), webhooks))
+		return len(webhooks.Items) > 0
+	}
+
+	_, err := r.reconcileAdmissionValidatingWebHook(context.Background(), req, logr.Discard(), falconAdmission, nil)
+	require.NoError(t, err)
+	require.True(t, webhookExists())
+
+	// The ValidatingWebhookConfiguration is removed once every rule is disabled, and not created again without webhooks
+	falconAdmission.Spec.AdmissionConfig.Rules = falconv1alpha1.FalconAdmissionRules{Pods: ptr.To(false), Workloads: ptr.To(false), Services: ptr.To(false), Batch: ptr.To(false)}
+	for range 2 {
+		_, err = r.reconcileAdmissionValidatingWebHook(context.Background(), req, logr.Discard(), falconAdmission, nil)
+		require.NoError(t, err)
+		assert.False(t, webhookExists())
+	}
+}
@@ -0,0 +1,62 @@
+package assets
+
+import (
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/crowdstrike/falcon-operator/pkg/common"
+	"github.com/crowdstrike/falcon-operator/pkg/node"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// KernelSensorName returns the name of the kernel backend DaemonSet of a FalconNodeSensor, or of a node pool, using the auto backend
+func KernelSensorName(name string) string {
+	return name + "-kernel"
+}
+
+// AutoBackendSensors splits a FalconNodeSensor using the auto backend into a copy running the eBPF backend on all nodes but the kernel
+// and unsupported nodes, which keeps the name of the FalconNodeSensor, and a copy running the kernel backend on the kernel nodes.
+// Nodes are selected with the backend label set by the operator, so that the pod templates do not change when nodes join or leave.
+// The kernel copy is nil when there is no kernel node. GKE Autopilot only runs the allowlisted eBPF sensor, so nodes are not split there.
+func AutoBackendSensors(sensor *falconv1alpha1.FalconNodeSensor, kernelNodes bool) (*falconv1alpha1.FalconNodeSensor, *falconv1alpha1.FalconNodeSensor) {
+	bpf := sensor.DeepCopy()
+	bpf.Spec.Node.Backend = node.BackendBPF
+	if sensor.Spec.Node.GKE.Enabled != nil && *sensor.Spec.Node.GKE.Enabled {
+		return bpf, nil
+	}
+
+	// Nodes not labeled yet run the default eBPF backend until they are classified
+	bpf.Spec.Node.NodeAffinity = requireSelectedBackend(sensor.Spec.Node.NodeAffinity, corev1.NodeSelectorOpNotIn, node.BackendKernel, node.BackendUnsupported)
+	if !kernelNodes {
+		return bpf, nil
+	}
+
+	kernel := sensor.DeepCopy()
+	kernel.Name = KernelSensorName(sensor.Name)
+	kernel.Spec.Node.Backend = node.BackendKernel
+	kernel.Spec.Node.NodeAffinity = requireSelectedBackend(sensor.Spec.Node.NodeAffinity, corev1.NodeSelectorOpIn, node.BackendKernel)
+
+	return bpf, kernel
+}
+
+// requireSelectedBackend adds a requirement on the backend selected for the node to every required node selector term of the node affinity
+func requireSelectedBackend(affinity corev1.NodeAffinity, operator corev1.NodeSelectorOperator, backends ...string) corev1.NodeAffinity {
+	restricted := *affinity.DeepCopy()
+
+	terms := requiredTerms(restricted)
+	for i := range terms {
+		terms[i].MatchExpressions = append(terms[i].MatchExpressions, corev1.NodeSelectorRequirement{
+			Key: common.FalconNodeSelectedBackendKey, Operator: operator, Values: backends,
+		})
+	}
+
+	restricted.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{NodeSelectorTerms: terms}
+	return restricted
+}
+
+// requiredTerms returns the required node selector terms of the node affinity, or a single empty term to add requirements to
+func requiredTerms(affinity corev1.NodeAffinity) []corev1.NodeSelectorTerm {
+	if affinity.RequiredDuringSchedulingIgnoredDuringExecution != nil && len(affinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms) > 0 {
+		return affinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	}
+
+	return []corev1.NodeSelectorTerm{{}}
+}
@@ -0,0 +1,75 @@
+package assets
+
+import (
+	"testing"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAutoBackendSensors(t *testing.T) {
+	autopilot := false
+	falconNode := falconv1alpha1.FalconNodeSensor{ObjectMeta: metav1.ObjectMeta{Name: "falcon-node-sensor"}}
+	falconNode.Spec.Node.Backend = "auto"
+	falconNode.Spec.Node.GKE.Enabled = &autopilot
+	falconNode.Spec.Node.NodeAffinity = corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{
+				{MatchExpressions: []corev1.NodeSelectorRequirement{nodeRequirement("pool", corev1.NodeSelectorOpIn, "general")}},
+			},
+		},
+	}
+
+	bpf, kernel := AutoBackendSensors(&falconNode, true)
+	if bpf.Name != "falcon-node-sensor" || bpf.Spec.Node.Backend != "bpf" {
+		t.Errorf("AutoBackendSensors() eBPF sensor name = %s, backend = %s, want falcon-node-sensor and bpf", bpf.Name, bpf.Spec.Node.Backend)
+	}
+
+	wantTerms := []corev1.NodeSelectorTerm{
+		{
+			MatchExpressions: []corev1.NodeSelectorRequirement{
+				nodeRequirement("pool", corev1.NodeSelectorOpIn, "general"),
+				{Key: "falcon.crowdstrike.com/selected-backend", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"kernel", "unsupported"}},
+			},
+		},
+	}
+	if diff := cmp.Diff(wantTerms, bpf.Spec.Node.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms); diff != "" {
+		t.Errorf("AutoBackendSensors() eBPF node affinity mismatch (-want +got): %s", diff)
+	}
+
+	if kernel == nil {
+		t.Fatal("AutoBackendSensors() returned no kernel sensor with kernel nodes")
+	}
+	if kernel.Name != "falcon-node-sensor-kernel" || kernel.Spec.Node.Backend != "kernel" {
+		t.Errorf("AutoBackendSensors() kernel sensor name = %s, backend = %s, want falcon-node-sensor-kernel and kernel", kernel.Name, kernel.Spec.Node.Backend)
+	}
+
+	wantTerms = []corev1.NodeSelectorTerm{
+		{
+			MatchExpressions: []corev1.NodeSelectorRequirement{
+				nodeRequirement("pool", corev1.NodeSelectorOpIn, "general"),
+				nodeRequirement("falcon.crowdstrike.com/selected-backend", corev1.NodeSelectorOpIn, "kernel"),
+			},
+		},
+	}
+	if diff := cmp.Diff(wantTerms, kernel.Spec.Node.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms); diff != "" {
+		t.Errorf("AutoBackendSensors() kernel node affinity mismatch (-want +got): %s", diff)
+	}
+
+	// The eBPF pod template does not change when kernel nodes join or leave
+	withoutKernel, kernel := AutoBackendSensors(&falconNode, false)
+	if kernel != nil {
+		t.Error("AutoBackendSensors() returned a kernel sensor without kernel nodes")
+	}
+	if diff := cmp.Diff(bpf.Spec.Node.NodeAffinity, withoutKernel.Spec.Node.NodeAffinity); diff != "" {
+		t.Errorf("AutoBackendSensors() eBPF node affinity mismatch (-want +got): %s", diff)
+	}
+
+	autopilot = true
+	bpf, kernel = AutoBackendSensors(&falconNode, true)
+	if kernel != nil || bpf.Spec.Node.Backend != "bpf" {
+		t.Errorf("AutoBackendSensors() on GKE Autopilot backend = %s, kernel sensor = %v, want bpf and no kernel sensor", bpf.Spec.Node.Backend, kernel)
+	}
+}
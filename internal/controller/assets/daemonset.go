@@ -239,7 +239,7 @@ func Daemonset(dsName, image, serviceAccount string, node *falconv1alpha1.Falcon
 		FSGroup: &fsGroup,
 	}
 
-	return &appsv1.DaemonSet{
+	ds := &appsv1.DaemonSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      dsName,
 			Namespace: node.Spec.InstallNamespace,
@@ -260,7 +260,7 @@ func Daemonset(dsName, image, serviceAccount string, node *falconv1alpha1.Falcon
 				Spec: corev1.PodSpec{
 					// NodeSelector is set to linux until windows containers are supported for the Falcon sensor
 					NodeSelector:                  common.NodeSelector,
-					Affinity:                      nodeAffinity(node),
+					Affinity:                      sensorAffinity(node),
 					Tolerations:                   *node.GetTolerations(),
 					HostPID:                       hostpid,
 					HostIPC:                       hostipc,
@@ -332,6 +332,9 @@ func Daemonset(dsName, image, serviceAccount string, node *falconv1alpha1.Falcon
 			},
 		},
 	}
+
+	addNodeTags(ds, image, node)
+	return ds
 }
 
 func RemoveNodeDirDaemonset(dsName, image, serviceAccount string, node *falconv1alpha1.FalconNodeSensor) *appsv1.DaemonSet {
@@ -409,3 +412,35 @@ func isInitReadOnlyRootFilesystem(node *falconv1alpha1.FalconNodeSensor) *bool {
 	disabled := node.Spec.Node.GKE.Enabled != nil && *node.Spec.Node.GKE.Enabled
 	return &disabled
 }
+
+// RemoveNodeDirPod returns a pod cleaning up /opt/CrowdStrike on a single node that left the scheduling scope of the sensor DaemonSet
+func RemoveNodeDirPod(podName, nodeName, image, serviceAccount string, node *falconv1alpha1.FalconNodeSensor) *corev1.Pod {
+	template := RemoveNodeDirDaemonset(podName, image, serviceAccount, node).Spec.Template
+
+	// The node no longer matches the node selector, affinity or tolerations of the sensor, so the pod is pinned to the node and tolerates its taints
+	template.Spec.NodeSelector = nil
+	template.Spec.Tolerations = []corev1.Toleration{{Operator: corev1.TolerationOpExists}}
+	template.Spec.Affinity = &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{
+						MatchFields: []corev1.NodeSelectorRequirement{
+							{Key: "metadata.name", Operator: corev1.NodeSelectorOpIn, Values: []string{nodeName}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        podName,
+			Namespace:   node.Spec.InstallNamespace,
+			Labels:      template.Labels,
+			Annotations: template.Annotations,
+		},
+		Spec: template.Spec,
+	}
+}
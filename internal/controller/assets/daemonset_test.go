@@ -497,3 +497,48 @@ func TestRemoveNodeDirDaemonset(t *testing.T) {
 		t.Errorf("Daemonset() mismatch (-want +got): %s", diff)
 	}
 }
+
+func TestRemoveNodeDirPod(t *testing.T) {
+	falconNode := falconv1alpha1.FalconNodeSensor{}
+	falconNode.Name = "test"
+	falconNode.Spec.InstallNamespace = "falcon-system"
+	falconNode.Spec.Node.Tolerations = &[]corev1.Toleration{}
+	autopilot := false
+	falconNode.Spec.Node.GKE.Enabled = &autopilot
+	podName := "test-cleanup-node"
+
+	got := RemoveNodeDirPod(podName, "node-a", "testImage", common.NodeServiceAccountName, &falconNode)
+
+	if got.Name != podName || got.Namespace != "falcon-system" {
+		t.Errorf("RemoveNodeDirPod() name = %s/%s, want falcon-system/%s", got.Namespace, got.Name, podName)
+	}
+
+	if diff := cmp.Diff(common.CRLabels("cleanup", podName, common.FalconKernelSensor), got.Labels); diff != "" {
+		t.Errorf("RemoveNodeDirPod() labels mismatch (-want +got): %s", diff)
+	}
+
+	wantAffinity := &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{MatchFields: []corev1.NodeSelectorRequirement{{Key: "metadata.name", Operator: corev1.NodeSelectorOpIn, Values: []string{"node-a"}}}},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(wantAffinity, got.Spec.Affinity); diff != "" {
+		t.Errorf("RemoveNodeDirPod() affinity mismatch (-want +got): %s", diff)
+	}
+
+	if diff := cmp.Diff([]corev1.Toleration{{Operator: corev1.TolerationOpExists}}, got.Spec.Tolerations); diff != "" {
+		t.Errorf("RemoveNodeDirPod() tolerations mismatch (-want +got): %s", diff)
+	}
+
+	if got.Spec.NodeSelector != nil {
+		t.Errorf("RemoveNodeDirPod() nodeSelector = %v, want nil", got.Spec.NodeSelector)
+	}
+
+	if len(got.Spec.InitContainers) != 1 || got.Spec.InitContainers[0].Name != "cleanup-opt-crowdstrike" {
+		t.Errorf("RemoveNodeDirPod() init containers = %v, want the cleanup-opt-crowdstrike container", got.Spec.InitContainers)
+	}
+}
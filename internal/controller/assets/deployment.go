@@ -22,8 +22,6 @@ const (
 	FalconWatcher
 )
 
-var enforcedSingleReplica = int32(1)
-
 // SideCarDeployment returns a Deployment object for the CrowdStrike Falcon sidecar
 func SideCarDeployment(name string, namespace string, component string, imageUri string, falconContainer *falconv1alpha1.FalconContainer) *appsv1.Deployment {
 	initContainerName := "crowdstrike-falcon-init-container"
@@ -164,7 +162,8 @@ func SideCarDeployment(name string, namespace string, component string, imageUri
 					},
 				},
 				Spec: corev1.PodSpec{
-					Affinity: getNodeAffinity(falconContainer.Spec.NodeAffinity),
+					Affinity:    getNodeAffinity(falconContainer.Spec.NodeAffinity),
+					Tolerations: falconContainer.Spec.Tolerations,
 					TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
 						{
 							MaxSkew:           1,
@@ -336,7 +335,8 @@ func ImageAnalyzerDeployment(name string, namespace string, component string, im
 					},
 				},
 				Spec: corev1.PodSpec{
-					Affinity: getNodeAffinity(falconImageAnalyzer.Spec.NodeAffinity),
+					Affinity:    getNodeAffinity(falconImageAnalyzer.Spec.NodeAffinity),
+					Tolerations: falconImageAnalyzer.Spec.Tolerations,
 					Containers: []corev1.Container{
 						{
 							Name: "falcon-image-analyzer",
@@ -475,10 +475,15 @@ func AdmissionDeployment(name string, namespace string, component string, imageU
 		})
 	}
 
-	if falconAdmission.Spec.AdmissionConfig.Replicas == nil || *falconAdmission.Spec.AdmissionConfig.Replicas != 1 {
-		log.Info("ignoring Replicas setting as only one is currently supported")
+	replicas := falconAdmission.GetReplicas()
+	strategy := admissionDepUpdateStrategy(falconAdmission)
+	if !reflect.DeepEqual(strategy.RollingUpdate.MaxUnavailable, falconAdmission.Spec.AdmissionConfig.DepUpdateStrategy.RollingUpdate.MaxUnavailable) {
+		log.Info("lowering maxUnavailable of the update strategy so that at least one replica stays available during rolling updates")
 	}
 
+	affinity := getNodeAffinity(falconAdmission.Spec.AdmissionConfig.NodeAffinity)
+	affinity.PodAntiAffinity = admissionPodAntiAffinity(labels)
+
 	falconClientEnv := []corev1.EnvVar{
 		{
 			Name: "__CS_POD_NAMESPACE",
@@ -723,11 +728,11 @@ func AdmissionDeployment(name string, namespace string, component string, imageU
 			Labels:    labels,
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: &enforcedSingleReplica,
+			Replicas: &replicas,
 			Selector: &metav1.LabelSelector{
 				MatchLabels: labels,
 			},
-			Strategy: admissionDepUpdateStrategy(falconAdmission),
+			Strategy: strategy,
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: labels,
@@ -736,18 +741,10 @@ func AdmissionDeployment(name string, namespace string, component string, imageU
 					},
 				},
 				Spec: corev1.PodSpec{
-					Affinity: getNodeAffinity(falconAdmission.Spec.AdmissionConfig.NodeAffinity),
-					TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
-						{
-							MaxSkew:           1,
-							TopologyKey:       "kubernetes.io/hostname",
-							WhenUnsatisfiable: corev1.ScheduleAnyway,
-							LabelSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{common.FalconInstanceNameKey: name},
-							},
-						},
-					},
-					ShareProcessNamespace: &shareProcessNamespace,
+					Affinity:                  affinity,
+					Tolerations:               falconAdmission.Spec.AdmissionConfig.Tolerations,
+					TopologySpreadConstraints: admissionTopologySpreadConstraints(labels),
+					ShareProcessNamespace:     &shareProcessNamespace,
 					SecurityContext: &corev1.PodSecurityContext{
 						RunAsNonRoot: &runNonRoot,
 						SeccompProfile: &corev1.SeccompProfile{
@@ -816,6 +813,21 @@ func admissionDepUpdateStrategy(admission *falconv1alpha1.FalconAdmission) appsv
 		rollingUpdateSettings.MaxUnavailable = admission.Spec.AdmissionConfig.DepUpdateStrategy.RollingUpdate.MaxUnavailable
 	}
 
+	// Never allow a rolling update to take down every replica, as the admission webhook would have no ready endpoint left
+	replicas := int(admission.GetReplicas())
+	if rollingUpdateSettings.MaxUnavailable != nil && replicas > 0 {
+		maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(rollingUpdateSettings.MaxUnavailable, replicas, false)
+		if err == nil && maxUnavailable >= replicas {
+			maxUnavailable := intstr.FromInt32(int32(replicas - 1))
+			rollingUpdateSettings.MaxUnavailable = &maxUnavailable
+
+			if replicas == 1 && (rollingUpdateSettings.MaxSurge == nil || rollingUpdateSettings.MaxSurge.IntValue() == 0) {
+				maxSurge := intstr.FromInt32(1)
+				rollingUpdateSettings.MaxSurge = &maxSurge
+			}
+		}
+	}
+
 	return appsv1.DeploymentStrategy{
 		Type:          appsv1.RollingUpdateDeploymentStrategyType,
 		RollingUpdate: &rollingUpdateSettings,
@@ -856,6 +868,45 @@ func admissionDepWatcherEnvVars(admission *falconv1alpha1.FalconAdmission) []cor
 	return envVars
 }
 
+// admissionTopologySpreadConstraints spreads the Admission Controller replicas across nodes and zones
+func admissionTopologySpreadConstraints(labels map[string]string) []corev1.TopologySpreadConstraint {
+	return []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       "kubernetes.io/hostname",
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+		},
+		{
+			MaxSkew:           1,
+			TopologyKey:       "topology.kubernetes.io/zone",
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+		},
+	}
+}
+
+// admissionPodAntiAffinity prefers scheduling Admission Controller replicas on different nodes
+func admissionPodAntiAffinity(labels map[string]string) *corev1.PodAntiAffinity {
+	return &corev1.PodAntiAffinity{
+		PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+			{
+				Weight: 100,
+				PodAffinityTerm: corev1.PodAffinityTerm{
+					TopologyKey: "kubernetes.io/hostname",
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: labels,
+					},
+				},
+			},
+		},
+	}
+}
+
 func getNodeAffinity(nodeAffinity *corev1.NodeAffinity) *corev1.Affinity {
 	if nodeAffinity != nil && !reflect.DeepEqual(nodeAffinity, corev1.NodeAffinity{}) {
 		return &corev1.Affinity{NodeAffinity: nodeAffinity}
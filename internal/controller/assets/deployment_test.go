@@ -93,6 +93,29 @@ func TestAdmissionDepUpdateStrategy(t *testing.T) {
 	}
 }
 
+// TestAdmissionDepUpdateStrategyKeepsReplicaAvailable tests that a rolling update never takes down every Admission Controller replica
+func TestAdmissionDepUpdateStrategyKeepsReplicaAvailable(t *testing.T) {
+	falconAdmission := falconv1alpha1.FalconAdmission{}
+	replicas := int32(2)
+	falconAdmission.Spec.AdmissionConfig.Replicas = &replicas
+	falconAdmission.Spec.AdmissionConfig.DepUpdateStrategy.RollingUpdate.MaxUnavailable = &intstr.IntOrString{Type: intstr.String, StrVal: "100%"}
+	falconAdmission.Spec.AdmissionConfig.DepUpdateStrategy.RollingUpdate.MaxSurge = &intstr.IntOrString{Type: intstr.Int, IntVal: 0}
+
+	got := admissionDepUpdateStrategy(&falconAdmission)
+	if diff := cmp.Diff(intstr.FromInt32(1), *got.RollingUpdate.MaxUnavailable); diff != "" {
+		t.Errorf("admissionDepUpdateStrategy() maxUnavailable mismatch (-want +got): %s", diff)
+	}
+
+	replicas = 1
+	got = admissionDepUpdateStrategy(&falconAdmission)
+	if diff := cmp.Diff(intstr.FromInt32(0), *got.RollingUpdate.MaxUnavailable); diff != "" {
+		t.Errorf("admissionDepUpdateStrategy() maxUnavailable mismatch (-want +got): %s", diff)
+	}
+	if diff := cmp.Diff(intstr.FromInt32(1), *got.RollingUpdate.MaxSurge); diff != "" {
+		t.Errorf("admissionDepUpdateStrategy() maxSurge mismatch (-want +got): %s", diff)
+	}
+}
+
 // testSideCarDeployment is a helper function to create a Deployment object for testing
 func testSideCarDeployment(name string, namespace string, component string, imageUri string, falconContainer *falconv1alpha1.FalconContainer) *appsv1.Deployment {
 	replicas := int32(123)
@@ -696,15 +719,37 @@ func testAdmissionDeployment(name string, namespace string, component string, im
 								},
 							},
 						},
-					},
-					TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
-						MaxSkew:           1,
-						TopologyKey:       "kubernetes.io/hostname",
-						WhenUnsatisfiable: corev1.ScheduleAnyway,
-						LabelSelector: &metav1.LabelSelector{
-							MatchLabels: map[string]string{common.FalconInstanceNameKey: name},
+						PodAntiAffinity: &corev1.PodAntiAffinity{
+							PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+								{
+									Weight: 100,
+									PodAffinityTerm: corev1.PodAffinityTerm{
+										TopologyKey: "kubernetes.io/hostname",
+										LabelSelector: &metav1.LabelSelector{
+											MatchLabels: labels,
+										},
+									},
+								},
+							},
 						},
 					},
+					TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+						{
+							MaxSkew:           1,
+							TopologyKey:       "kubernetes.io/hostname",
+							WhenUnsatisfiable: corev1.ScheduleAnyway,
+							LabelSelector: &metav1.LabelSelector{
+								MatchLabels: labels,
+							},
+						},
+						{
+							MaxSkew:           1,
+							TopologyKey:       "topology.kubernetes.io/zone",
+							WhenUnsatisfiable: corev1.ScheduleAnyway,
+							LabelSelector: &metav1.LabelSelector{
+								MatchLabels: labels,
+							},
+						},
 					},
 					ShareProcessNamespace: &shareProcessNamespace,
 					SecurityContext: &corev1.PodSecurityContext{
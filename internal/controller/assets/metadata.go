@@ -0,0 +1,99 @@
+package assets
+
+import (
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ApplyResourceMetadata adds the user-defined labels and annotations to an object built by the assets constructors.
+// The pod template of a Deployment or DaemonSet also receives the pod labels and annotations.
+// Labels and annotations set by the constructors take precedence.
+func ApplyResourceMetadata(obj client.Object, metadata falconv1alpha1.ResourceMetadata) {
+	obj.SetLabels(addMissing(obj.GetLabels(), metadata.CommonLabels))
+	obj.SetAnnotations(addMissing(obj.GetAnnotations(), metadata.CommonAnnotations))
+
+	if template := podTemplate(obj); template != nil {
+		template.Labels = addMissing(addMissing(template.Labels, metadata.PodLabels), metadata.CommonLabels)
+		template.Annotations = addMissing(addMissing(template.Annotations, metadata.PodAnnotations), metadata.CommonAnnotations)
+	}
+}
+
+// SyncResourceMetadata copies the user-defined labels and annotations of the desired object to the existing one.
+// It returns true when the existing object has been modified and needs to be updated.
+func SyncResourceMetadata(existing, desired client.Object, metadata falconv1alpha1.ResourceMetadata) bool {
+	labels, labelsUpdated := syncKeys(existing.GetLabels(), desired.GetLabels(), metadata.CommonLabels)
+	annotations, annotationsUpdated := syncKeys(existing.GetAnnotations(), desired.GetAnnotations(), metadata.CommonAnnotations)
+	existing.SetLabels(labels)
+	existing.SetAnnotations(annotations)
+	updated := labelsUpdated || annotationsUpdated
+
+	existingTemplate, desiredTemplate := podTemplate(existing), podTemplate(desired)
+	if existingTemplate != nil && desiredTemplate != nil {
+		var podLabelsUpdated, podAnnotationsUpdated bool
+		existingTemplate.Labels, podLabelsUpdated = syncKeys(existingTemplate.Labels, desiredTemplate.Labels, metadata.PodLabels, metadata.CommonLabels)
+		existingTemplate.Annotations, podAnnotationsUpdated = syncKeys(existingTemplate.Annotations, desiredTemplate.Annotations, metadata.PodAnnotations, metadata.CommonAnnotations)
+		updated = updated || podLabelsUpdated || podAnnotationsUpdated
+	}
+
+	return updated
+}
+
+func podTemplate(obj client.Object) *corev1.PodTemplateSpec {
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		return &o.Spec.Template
+	case *appsv1.DaemonSet:
+		return &o.Spec.Template
+	default:
+		return nil
+	}
+}
+
+// addMissing returns a copy of base with the user-defined entries not already present added.
+// The constructors share label maps between metadata and selectors, so base is never modified in place.
+func addMissing(base map[string]string, extra map[string]string) map[string]string {
+	if len(extra) == 0 {
+		return base
+	}
+
+	merged := make(map[string]string, len(base)+len(extra))
+	for k, v := range base {
+		merged[k] = v
+	}
+
+	for k, v := range extra {
+		if _, ok := merged[k]; !ok {
+			merged[k] = v
+		}
+	}
+
+	return merged
+}
+
+// syncKeys sets the value of the desired entries whose keys are user-defined on existing
+func syncKeys(existing, desired map[string]string, userDefined ...map[string]string) (map[string]string, bool) {
+	updated := false
+	for _, keys := range userDefined {
+		for k := range keys {
+			v, ok := desired[k]
+			if !ok {
+				continue
+			}
+
+			if current, ok := existing[k]; ok && current == v {
+				continue
+			}
+
+			if existing == nil {
+				existing = map[string]string{}
+			}
+
+			existing[k] = v
+			updated = true
+		}
+	}
+
+	return existing, updated
+}
@@ -0,0 +1,88 @@
+package assets
+
+import (
+	"testing"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/crowdstrike/falcon-operator/pkg/common"
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testResourceMetadata() falconv1alpha1.ResourceMetadata {
+	return falconv1alpha1.ResourceMetadata{
+		CommonLabels:      map[string]string{"cost-center": "security", common.FalconComponentKey: "user"},
+		CommonAnnotations: map[string]string{"owner": "secops"},
+		PodLabels:         map[string]string{"team": "platform"},
+		PodAnnotations:    map[string]string{"sidecar.istio.io/inject": "false"},
+	}
+}
+
+func testMetadataDeployment() *appsv1.Deployment {
+	selector := map[string]string{common.FalconComponentKey: "test"}
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Labels: selector},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: selector},
+			Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: selector}},
+		},
+	}
+}
+
+// TestApplyResourceMetadata tests the ApplyResourceMetadata function
+func TestApplyResourceMetadata(t *testing.T) {
+	deployment := testMetadataDeployment()
+	ApplyResourceMetadata(deployment, testResourceMetadata())
+
+	wantLabels := map[string]string{common.FalconComponentKey: "test", "cost-center": "security"}
+	if diff := cmp.Diff(wantLabels, deployment.Labels); diff != "" {
+		t.Errorf("ApplyResourceMetadata() labels mismatch (-want +got): %s", diff)
+	}
+
+	if diff := cmp.Diff(map[string]string{"owner": "secops"}, deployment.Annotations); diff != "" {
+		t.Errorf("ApplyResourceMetadata() annotations mismatch (-want +got): %s", diff)
+	}
+
+	wantPodLabels := map[string]string{common.FalconComponentKey: "test", "cost-center": "security", "team": "platform"}
+	if diff := cmp.Diff(wantPodLabels, deployment.Spec.Template.Labels); diff != "" {
+		t.Errorf("ApplyResourceMetadata() pod labels mismatch (-want +got): %s", diff)
+	}
+
+	wantPodAnnotations := map[string]string{"owner": "secops", "sidecar.istio.io/inject": "false"}
+	if diff := cmp.Diff(wantPodAnnotations, deployment.Spec.Template.Annotations); diff != "" {
+		t.Errorf("ApplyResourceMetadata() pod annotations mismatch (-want +got): %s", diff)
+	}
+
+	if diff := cmp.Diff(map[string]string{common.FalconComponentKey: "test"}, deployment.Spec.Selector.MatchLabels); diff != "" {
+		t.Errorf("ApplyResourceMetadata() modified the selector (-want +got): %s", diff)
+	}
+}
+
+// TestSyncResourceMetadata tests the SyncResourceMetadata function
+func TestSyncResourceMetadata(t *testing.T) {
+	metadata := testResourceMetadata()
+	existing := testMetadataDeployment()
+	existing.Labels = map[string]string{common.FalconComponentKey: "test", "added-by-user": "kubectl"}
+
+	desired := testMetadataDeployment()
+	ApplyResourceMetadata(desired, metadata)
+
+	if !SyncResourceMetadata(existing, desired, metadata) {
+		t.Error("SyncResourceMetadata() = false with missing labels, want true")
+	}
+
+	wantLabels := map[string]string{common.FalconComponentKey: "test", "added-by-user": "kubectl", "cost-center": "security"}
+	if diff := cmp.Diff(wantLabels, existing.Labels); diff != "" {
+		t.Errorf("SyncResourceMetadata() labels mismatch (-want +got): %s", diff)
+	}
+
+	if diff := cmp.Diff(desired.Spec.Template.Labels, existing.Spec.Template.Labels); diff != "" {
+		t.Errorf("SyncResourceMetadata() pod labels mismatch (-want +got): %s", diff)
+	}
+
+	if SyncResourceMetadata(existing, desired, metadata) {
+		t.Error("SyncResourceMetadata() = true after syncing, want false")
+	}
+}
@@ -19,12 +19,38 @@ func MutatingWebhook(name string, namespace string, webhookName string, caBundle
 	operatorSelector := metav1.LabelSelectorOpNotIn
 	operatorValues := []string{"disabled"}
 	labels := common.CRLabels("mutatingwebhook", name, common.FalconSidecarSensor)
+	objectSelector := &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{
+				Key:      common.FalconContainerInjection,
+				Operator: metav1.LabelSelectorOpNotIn,
+				Values:   []string{"disabled"},
+			},
+		},
+	}
 
 	if disableNSInjection {
 		operatorSelector = metav1.LabelSelectorOpIn
 		operatorValues = []string{"enabled"}
 	}
 
+	if falconContainer.Spec.Injector.FailurePolicy != "" {
+		failurePolicy = falconContainer.Spec.Injector.FailurePolicy
+	}
+
+	if falconContainer.Spec.Injector.ReinvocationPolicy != "" {
+		reinvocationPolicy = falconContainer.Spec.Injector.ReinvocationPolicy
+	}
+
+	if falconContainer.Spec.Injector.TimeoutSeconds != nil {
+		timeoutSeconds = *falconContainer.Spec.Injector.TimeoutSeconds
+	}
+
+	if selector := falconContainer.Spec.Injector.ObjectSelector; selector != nil {
+		objectSelector.MatchLabels = selector.MatchLabels
+		objectSelector.MatchExpressions = append(objectSelector.MatchExpressions, selector.MatchExpressions...)
+	}
+
 	return &arv1.MutatingWebhookConfiguration{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: arv1.SchemeGroupVersion.String(),
@@ -53,7 +79,7 @@ func MutatingWebhook(name string, namespace string, webhookName string, caBundle
 					},
 				},
 				TimeoutSeconds: &timeoutSeconds,
-				ObjectSelector: &metav1.LabelSelector{},
+				ObjectSelector: objectSelector,
 				NamespaceSelector: &metav1.LabelSelector{
 					MatchExpressions: []metav1.LabelSelectorRequirement{
 						{
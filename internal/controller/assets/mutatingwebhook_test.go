@@ -25,6 +25,36 @@ func TestMutatingWebhook(t *testing.T) {
 
 }
 
+// TestMutatingWebhookOverrides tests the MutatingWebhook function with injector webhook settings configured
+func TestMutatingWebhookOverrides(t *testing.T) {
+	falconContainer := &falconv1alpha1.FalconContainer{}
+	port := int32(123)
+	timeoutSeconds := int32(5)
+	falconContainer.Spec.Injector.ListenPort = &port
+	falconContainer.Spec.Injector.FailurePolicy = arv1.Ignore
+	falconContainer.Spec.Injector.ReinvocationPolicy = arv1.IfNeededReinvocationPolicy
+	falconContainer.Spec.Injector.TimeoutSeconds = &timeoutSeconds
+	falconContainer.Spec.Injector.ObjectSelector = &metav1.LabelSelector{
+		MatchLabels: map[string]string{"app": "test"},
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "tier", Operator: metav1.LabelSelectorOpExists},
+		},
+	}
+
+	want := testWebhook(false, falconContainer)
+	want.Webhooks[0].FailurePolicy = &falconContainer.Spec.Injector.FailurePolicy
+	want.Webhooks[0].ReinvocationPolicy = &falconContainer.Spec.Injector.ReinvocationPolicy
+	want.Webhooks[0].TimeoutSeconds = &timeoutSeconds
+	want.Webhooks[0].ObjectSelector.MatchLabels = map[string]string{"app": "test"}
+	want.Webhooks[0].ObjectSelector.MatchExpressions = append(want.Webhooks[0].ObjectSelector.MatchExpressions,
+		metav1.LabelSelectorRequirement{Key: "tier", Operator: metav1.LabelSelectorOpExists})
+
+	got := MutatingWebhook("test", "test", "test", []byte("test"), false, falconContainer)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("MutatingWebhook() mismatch (-want +got): %s", diff)
+	}
+}
+
 // testWebhook is a helper function to create a MutatingWebhookConfiguration
 func testWebhook(disableNSInjection bool, falconContainer *falconv1alpha1.FalconContainer) *arv1.MutatingWebhookConfiguration {
 	webhookName := "test"
@@ -73,7 +103,15 @@ func testWebhook(disableNSInjection bool, falconContainer *falconv1alpha1.Falcon
 					},
 				},
 				TimeoutSeconds: &timeoutSeconds,
-				ObjectSelector: &metav1.LabelSelector{},
+				ObjectSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{
+							Key:      common.FalconContainerInjection,
+							Operator: metav1.LabelSelectorOpNotIn,
+							Values:   []string{"disabled"},
+						},
+					},
+				},
 				NamespaceSelector: &metav1.LabelSelector{
 					MatchExpressions: []metav1.LabelSelectorRequirement{
 						{
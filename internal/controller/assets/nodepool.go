@@ -0,0 +1,106 @@
+package assets
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/crowdstrike/falcon-operator/pkg/common"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// NodePoolName returns the name of the DaemonSet of a node pool
+func NodePoolName(node *falconv1alpha1.FalconNodeSensor, pool string) string {
+	return node.Name + "-pool-" + pool
+}
+
+// NodePoolSensor returns a copy of the FalconNodeSensor with the overrides of a node pool applied, which renders the DaemonSet and ConfigMap of the node pool.
+// The node affinity of the copy is restricted to the nodes of the pool that are not managed by a previous node pool.
+func NodePoolSensor(node *falconv1alpha1.FalconNodeSensor, index int) *falconv1alpha1.FalconNodeSensor {
+	pool := node.GetNodePools()[index]
+	poolSensor := node.DeepCopy()
+	poolSensor.Name = NodePoolName(node, pool.Name)
+	poolSensor.Spec.Node.NodePools = nil
+	poolSensor.Spec.Node.NodeAffinity = restrictNodeAffinity(node.Spec.Node.NodeAffinity, pool.NodeSelector, nodePoolSelectors(node.GetNodePools()[:index]))
+	if poolSensor.Spec.CommonLabels == nil {
+		poolSensor.Spec.CommonLabels = map[string]string{}
+	}
+	poolSensor.Spec.CommonLabels[common.FalconNodePoolKey] = pool.Name
+
+	if pool.Backend != "" {
+		poolSensor.Spec.Node.Backend = pool.Backend
+	}
+	if pool.Tags != nil {
+		poolSensor.Spec.Falcon.Tags = pool.Tags
+	}
+	if pool.Trace != "" {
+		poolSensor.Spec.Falcon.Trace = pool.Trace
+	}
+	if pool.SensorResources != nil {
+		poolSensor.Spec.Node.SensorResources = *pool.SensorResources
+	}
+
+	return poolSensor
+}
+
+// sensorAffinity returns the affinity of the sensor DaemonSet, which excludes the nodes managed by a node pool
+func sensorAffinity(node *falconv1alpha1.FalconNodeSensor) *corev1.Affinity {
+	if len(node.GetNodePools()) == 0 {
+		return nodeAffinity(node)
+	}
+
+	affinity := restrictNodeAffinity(node.Spec.Node.NodeAffinity, nil, nodePoolSelectors(node.GetNodePools()))
+	return &corev1.Affinity{NodeAffinity: &affinity}
+}
+
+func nodePoolSelectors(pools []falconv1alpha1.FalconNodePool) []map[string]string {
+	selectors := []map[string]string{}
+	for _, pool := range pools {
+		selectors = append(selectors, pool.NodeSelector)
+	}
+
+	return selectors
+}
+
+// restrictNodeAffinity adds required node selector terms to the node affinity so that it only matches the nodes with the include labels
+// and without all the labels of any of the exclude selectors. A node does not match a selector when one of its labels differs,
+// so every term is split into one term per label of each exclude selector.
+func restrictNodeAffinity(affinity corev1.NodeAffinity, include map[string]string, exclude []map[string]string) corev1.NodeAffinity {
+	restricted := *affinity.DeepCopy()
+
+	terms := requiredTerms(restricted)
+
+	for _, key := range slices.Sorted(maps.Keys(include)) {
+		for i := range terms {
+			terms[i].MatchExpressions = append(terms[i].MatchExpressions, corev1.NodeSelectorRequirement{
+				Key: key, Operator: corev1.NodeSelectorOpIn, Values: []string{include[key]},
+			})
+		}
+	}
+
+	for _, selector := range exclude {
+		split := []corev1.NodeSelectorTerm{}
+		for _, term := range terms {
+			for _, key := range slices.Sorted(maps.Keys(selector)) {
+				excluded := *term.DeepCopy()
+				excluded.MatchExpressions = append(excluded.MatchExpressions, corev1.NodeSelectorRequirement{
+					Key: key, Operator: corev1.NodeSelectorOpNotIn, Values: []string{selector[key]},
+				})
+				split = append(split, excluded)
+			}
+		}
+
+		if len(split) > 0 {
+			terms = split
+		}
+	}
+
+	// Nothing to restrict, an empty term would match no node
+	if reflect.DeepEqual(terms, []corev1.NodeSelectorTerm{{}}) {
+		return restricted
+	}
+
+	restricted.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{NodeSelectorTerms: terms}
+	return restricted
+}
@@ -0,0 +1,127 @@
+package assets
+
+import (
+	"testing"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func nodeRequirement(key string, operator corev1.NodeSelectorOperator, value string) corev1.NodeSelectorRequirement {
+	return corev1.NodeSelectorRequirement{Key: key, Operator: operator, Values: []string{value}}
+}
+
+func TestRestrictNodeAffinity(t *testing.T) {
+	userAffinity := corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{
+				{MatchExpressions: []corev1.NodeSelectorRequirement{nodeRequirement("kubernetes.io/arch", corev1.NodeSelectorOpIn, "amd64")}},
+			},
+		},
+	}
+
+	// Nothing to restrict
+	if diff := cmp.Diff(userAffinity, restrictNodeAffinity(userAffinity, nil, nil)); diff != "" {
+		t.Errorf("restrictNodeAffinity() mismatch (-want +got): %s", diff)
+	}
+
+	got := restrictNodeAffinity(corev1.NodeAffinity{}, map[string]string{"pool": "gpu"}, nil)
+	want := corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{
+				{MatchExpressions: []corev1.NodeSelectorRequirement{nodeRequirement("pool", corev1.NodeSelectorOpIn, "gpu")}},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("restrictNodeAffinity() mismatch (-want +got): %s", diff)
+	}
+
+	got = restrictNodeAffinity(userAffinity, map[string]string{"pool": "legacy"}, []map[string]string{{"pool": "gpu", "zone": "a"}})
+	want = corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{
+				{MatchExpressions: []corev1.NodeSelectorRequirement{
+					nodeRequirement("kubernetes.io/arch", corev1.NodeSelectorOpIn, "amd64"),
+					nodeRequirement("pool", corev1.NodeSelectorOpIn, "legacy"),
+					nodeRequirement("pool", corev1.NodeSelectorOpNotIn, "gpu"),
+				}},
+				{MatchExpressions: []corev1.NodeSelectorRequirement{
+					nodeRequirement("kubernetes.io/arch", corev1.NodeSelectorOpIn, "amd64"),
+					nodeRequirement("pool", corev1.NodeSelectorOpIn, "legacy"),
+					nodeRequirement("zone", corev1.NodeSelectorOpNotIn, "a"),
+				}},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("restrictNodeAffinity() mismatch (-want +got): %s", diff)
+	}
+
+	if len(userAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions) != 1 {
+		t.Error("restrictNodeAffinity() modified the node affinity of the FalconNodeSensor")
+	}
+}
+
+func TestNodePoolSensor(t *testing.T) {
+	falconNode := falconv1alpha1.FalconNodeSensor{ObjectMeta: metav1.ObjectMeta{Name: "falcon-node-sensor"}}
+	falconNode.Spec.Node.Backend = "bpf"
+	falconNode.Spec.Falcon.Tags = []string{"cluster"}
+	falconNode.Spec.Falcon.Trace = "none"
+	falconNode.Spec.Node.NodePools = []falconv1alpha1.FalconNodePool{
+		{Name: "gpu", NodeSelector: map[string]string{"pool": "gpu"}, Tags: []string{"gpu"}},
+		{Name: "legacy", NodeSelector: map[string]string{"kernel": "legacy"}, Backend: "kernel", Trace: "debug"},
+	}
+
+	gpu := NodePoolSensor(&falconNode, 0)
+	if gpu.Name != "falcon-node-sensor-pool-gpu" {
+		t.Errorf("NodePoolSensor() name = %s, want falcon-node-sensor-pool-gpu", gpu.Name)
+	}
+	if diff := cmp.Diff([]string{"gpu"}, gpu.Spec.Falcon.Tags); diff != "" {
+		t.Errorf("NodePoolSensor() tags mismatch (-want +got): %s", diff)
+	}
+	if gpu.Spec.Node.Backend != "bpf" || gpu.Spec.Falcon.Trace != "none" {
+		t.Errorf("NodePoolSensor() backend = %s, trace = %s, want the settings of the FalconNodeSensor", gpu.Spec.Node.Backend, gpu.Spec.Falcon.Trace)
+	}
+	if gpu.Spec.Node.NodePools != nil {
+		t.Error("NodePoolSensor() kept the node pools")
+	}
+
+	legacy := NodePoolSensor(&falconNode, 1)
+	if legacy.Spec.Node.Backend != "kernel" || legacy.Spec.Falcon.Trace != "debug" {
+		t.Errorf("NodePoolSensor() backend = %s, trace = %s, want kernel and debug", legacy.Spec.Node.Backend, legacy.Spec.Falcon.Trace)
+	}
+	if diff := cmp.Diff([]string{"cluster"}, legacy.Spec.Falcon.Tags); diff != "" {
+		t.Errorf("NodePoolSensor() tags mismatch (-want +got): %s", diff)
+	}
+
+	// Nodes of the gpu pool are excluded from the legacy pool
+	wantTerms := []corev1.NodeSelectorTerm{
+		{MatchExpressions: []corev1.NodeSelectorRequirement{
+			nodeRequirement("kernel", corev1.NodeSelectorOpIn, "legacy"),
+			nodeRequirement("pool", corev1.NodeSelectorOpNotIn, "gpu"),
+		}},
+	}
+	if diff := cmp.Diff(wantTerms, legacy.Spec.Node.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms); diff != "" {
+		t.Errorf("NodePoolSensor() node affinity mismatch (-want +got): %s", diff)
+	}
+
+	// The sensor DaemonSet runs on the nodes outside of all node pools
+	wantTerms = []corev1.NodeSelectorTerm{
+		{MatchExpressions: []corev1.NodeSelectorRequirement{
+			nodeRequirement("pool", corev1.NodeSelectorOpNotIn, "gpu"),
+			nodeRequirement("kernel", corev1.NodeSelectorOpNotIn, "legacy"),
+		}},
+	}
+	if diff := cmp.Diff(wantTerms, sensorAffinity(&falconNode).NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms); diff != "" {
+		t.Errorf("sensorAffinity() mismatch (-want +got): %s", diff)
+	}
+
+	autopilot := true
+	falconNode.Spec.Node.GKE.Enabled = &autopilot
+	if diff := cmp.Diff(&corev1.Affinity{}, sensorAffinity(&falconNode)); diff != "" {
+		t.Errorf("sensorAffinity() mismatch on GKE Autopilot (-want +got): %s", diff)
+	}
+}
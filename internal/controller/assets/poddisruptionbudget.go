@@ -0,0 +1,31 @@
+package assets
+
+import (
+	"github.com/crowdstrike/falcon-operator/pkg/common"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// PodDisruptionBudget returns a PodDisruptionBudget object keeping minAvailable pods matching the selector available
+func PodDisruptionBudget(name string, namespace string, component string, selector map[string]string, minAvailable intstr.IntOrString) *policyv1.PodDisruptionBudget {
+	labels := common.CRLabels("poddisruptionbudget", name, component)
+
+	return &policyv1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: policyv1.SchemeGroupVersion.String(),
+			Kind:       "PodDisruptionBudget",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable: &minAvailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: selector,
+			},
+		},
+	}
+}
@@ -0,0 +1,40 @@
+package assets
+
+import (
+	"testing"
+
+	"github.com/crowdstrike/falcon-operator/pkg/common"
+	"github.com/google/go-cmp/cmp"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// TestPodDisruptionBudget tests the PodDisruptionBudget function
+func TestPodDisruptionBudget(t *testing.T) {
+	minAvailable := intstr.FromInt32(1)
+	selector := common.CRLabels("deployment", "test", "test")
+
+	want := &policyv1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: policyv1.SchemeGroupVersion.String(),
+			Kind:       "PodDisruptionBudget",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+			Labels:    common.CRLabels("poddisruptionbudget", "test", "test"),
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable: &minAvailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: selector,
+			},
+		},
+	}
+
+	got := PodDisruptionBudget("test", "test", "test", selector, minAvailable)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("PodDisruptionBudget() mismatch (-want +got): %s", diff)
+	}
+}
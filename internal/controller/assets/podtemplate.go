@@ -0,0 +1,211 @@
+package assets
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/crowdstrike/falcon-operator/pkg/common"
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"sigs.k8s.io/yaml"
+)
+
+// ApplyPodTemplateOverride patches the pod template of an operator-generated workload with the given override.
+// The hash of the override is recorded in the pod template annotations so that changes to the override can be detected on update.
+func ApplyPodTemplateOverride(template *corev1.PodTemplateSpec, override *falconv1alpha1.PodTemplateOverride) error {
+	if override == nil || override.Patch == "" {
+		return nil
+	}
+
+	patch, err := yaml.YAMLToJSON([]byte(override.Patch))
+	if err != nil {
+		return fmt.Errorf("unable to parse pod template override: %v", err)
+	}
+
+	original, err := json.Marshal(template)
+	if err != nil {
+		return err
+	}
+
+	var patched []byte
+	switch override.Type {
+	case falconv1alpha1.PodTemplateOverrideJSON:
+		jsonPatch, err := jsonpatch.DecodePatch(patch)
+		if err != nil {
+			return fmt.Errorf("unable to decode pod template override JSON patch: %v", err)
+		}
+
+		patched, err = jsonPatch.Apply(original)
+		if err != nil {
+			return fmt.Errorf("unable to apply pod template override JSON patch: %v", err)
+		}
+	case falconv1alpha1.PodTemplateOverrideStrategicMerge, "":
+		patched, err = strategicpatch.StrategicMergePatch(original, patch, corev1.PodTemplateSpec{})
+		if err != nil {
+			return fmt.Errorf("unable to apply pod template override strategic merge patch: %v", err)
+		}
+	default:
+		return fmt.Errorf("unsupported pod template override type %q", override.Type)
+	}
+
+	patchedTemplate := corev1.PodTemplateSpec{}
+	if err := json.Unmarshal(patched, &patchedTemplate); err != nil {
+		return fmt.Errorf("pod template override produced an invalid pod template: %v", err)
+	}
+
+	if err := validatePodTemplateOverride(template, &patchedTemplate); err != nil {
+		return err
+	}
+
+	if patchedTemplate.Annotations == nil {
+		patchedTemplate.Annotations = map[string]string{}
+	}
+	patchedTemplate.Annotations[common.FalconPodTemplateOverrideKey] = podTemplateOverrideHash(override)
+
+	*template = patchedTemplate
+	return nil
+}
+
+// PodTemplateOverrideChanged returns true when the pod template override applied to the existing workload differs from the desired one
+func PodTemplateOverrideChanged(existing, desired *corev1.PodTemplateSpec) bool {
+	return existing.Annotations[common.FalconPodTemplateOverrideKey] != desired.Annotations[common.FalconPodTemplateOverrideKey]
+}
+
+// validatePodTemplateOverride ensures the fields the operator relies on are left untouched by a pod template override
+func validatePodTemplateOverride(original, patched *corev1.PodTemplateSpec) error {
+	for key, value := range original.Labels {
+		if patched.Labels[key] != value {
+			return fmt.Errorf("pod template override cannot change the protected label %q", key)
+		}
+	}
+
+	if _, ok := patched.Annotations[common.FalconPodTemplateOverrideKey]; ok {
+		return fmt.Errorf("pod template override cannot set the protected annotation %q", common.FalconPodTemplateOverrideKey)
+	}
+
+	if patched.Spec.ServiceAccountName != original.Spec.ServiceAccountName || patched.Spec.DeprecatedServiceAccount != original.Spec.DeprecatedServiceAccount {
+		return fmt.Errorf("pod template override cannot change the protected field spec.serviceAccountName")
+	}
+
+	if err := validatePodSecurity(original, patched); err != nil {
+		return err
+	}
+
+	if err := validateProtectedContainers("containers", original.Spec.Containers, patched.Spec.Containers); err != nil {
+		return err
+	}
+
+	return validateProtectedContainers("initContainers", original.Spec.InitContainers, patched.Spec.InitContainers)
+}
+
+// validatePodSecurity ensures a pod template override does not grant the workload more access to its node than the operator does:
+// the host namespaces, the security contexts and the volumes set by the operator cannot be changed, added containers cannot be
+// privileged, and added volumes are limited to types that do not expose the node
+func validatePodSecurity(original, patched *corev1.PodTemplateSpec) error {
+	if patched.Spec.HostPID != original.Spec.HostPID {
+		return fmt.Errorf("pod template override cannot change the protected field spec.hostPID")
+	}
+	if patched.Spec.HostNetwork != original.Spec.HostNetwork {
+		return fmt.Errorf("pod template override cannot change the protected field spec.hostNetwork")
+	}
+	if patched.Spec.HostIPC != original.Spec.HostIPC {
+		return fmt.Errorf("pod template override cannot change the protected field spec.hostIPC")
+	}
+	if !equality.Semantic.DeepEqual(patched.Spec.SecurityContext, original.Spec.SecurityContext) {
+		return fmt.Errorf("pod template override cannot change the protected field spec.securityContext")
+	}
+
+	for _, volume := range patched.Spec.Volumes {
+		if originalVolume := findVolume(original.Spec.Volumes, volume.Name); originalVolume != nil {
+			if !equality.Semantic.DeepEqual(volume, *originalVolume) {
+				return fmt.Errorf("pod template override cannot change the protected volume spec.volumes[%s]", volume.Name)
+			}
+			continue
+		}
+
+		if volume.EmptyDir == nil && volume.ConfigMap == nil && volume.Secret == nil && volume.Projected == nil && volume.DownwardAPI == nil {
+			return fmt.Errorf("pod template override can only add emptyDir, configMap, secret, projected or downwardAPI volumes, spec.volumes[%s] is not one of them", volume.Name)
+		}
+	}
+
+	if err := validateContainerSecurity("containers", original.Spec.Containers, patched.Spec.Containers); err != nil {
+		return err
+	}
+
+	return validateContainerSecurity("initContainers", original.Spec.InitContainers, patched.Spec.InitContainers)
+}
+
+func validateContainerSecurity(field string, original, patched []corev1.Container) error {
+	for _, container := range patched {
+		if originalContainer := findContainer(original, container.Name); originalContainer != nil {
+			if !equality.Semantic.DeepEqual(container.SecurityContext, originalContainer.SecurityContext) {
+				return fmt.Errorf("pod template override cannot change the securityContext of the protected container spec.%s[%s]", field, container.Name)
+			}
+			continue
+		}
+
+		securityContext := container.SecurityContext
+		if securityContext == nil {
+			continue
+		}
+		if (securityContext.Privileged != nil && *securityContext.Privileged) ||
+			(securityContext.AllowPrivilegeEscalation != nil && *securityContext.AllowPrivilegeEscalation) ||
+			(securityContext.Capabilities != nil && len(securityContext.Capabilities.Add) > 0) {
+			return fmt.Errorf("pod template override cannot add the privileged container spec.%s[%s]", field, container.Name)
+		}
+	}
+
+	return nil
+}
+
+func findContainer(containers []corev1.Container, name string) *corev1.Container {
+	for i := range containers {
+		if containers[i].Name == name {
+			return &containers[i]
+		}
+	}
+
+	return nil
+}
+
+func findVolume(volumes []corev1.Volume, name string) *corev1.Volume {
+	for i := range volumes {
+		if volumes[i].Name == name {
+			return &volumes[i]
+		}
+	}
+
+	return nil
+}
+
+func validateProtectedContainers(field string, original, patched []corev1.Container) error {
+	for _, container := range original {
+		found := false
+		for _, patchedContainer := range patched {
+			if patchedContainer.Name != container.Name {
+				continue
+			}
+
+			if patchedContainer.Image != container.Image {
+				return fmt.Errorf("pod template override cannot change the image of the protected container spec.%s[%s]", field, container.Name)
+			}
+
+			found = true
+			break
+		}
+
+		if !found {
+			return fmt.Errorf("pod template override cannot remove the protected container spec.%s[%s]", field, container.Name)
+		}
+	}
+
+	return nil
+}
+
+func podTemplateOverrideHash(override *falconv1alpha1.PodTemplateOverride) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(string(override.Type)+"\n"+override.Patch)))[:16]
+}
@@ -0,0 +1,174 @@
+package assets
+
+import (
+	"testing"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/crowdstrike/falcon-operator/pkg/common"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testPodTemplate() corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{common.FalconComponentKey: "test"},
+		},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: "test-sa",
+			Containers: []corev1.Container{
+				{Name: "falcon", Image: "falcon:1.0", Env: []corev1.EnvVar{{Name: "FOO", Value: "bar"}}},
+			},
+		},
+	}
+}
+
+// TestApplyPodTemplateOverride tests the ApplyPodTemplateOverride function
+func TestApplyPodTemplateOverride(t *testing.T) {
+	template := testPodTemplate()
+	if err := ApplyPodTemplateOverride(&template, nil); err != nil {
+		t.Errorf("ApplyPodTemplateOverride() without override error: %v", err)
+	}
+
+	if diff := cmp.Diff(testPodTemplate(), template); diff != "" {
+		t.Errorf("ApplyPodTemplateOverride() without override mismatch (-want +got): %s", diff)
+	}
+
+	override := &falconv1alpha1.PodTemplateOverride{
+		Type: falconv1alpha1.PodTemplateOverrideStrategicMerge,
+		Patch: `
+metadata:
+  labels:
+    team: security
+spec:
+  runtimeClassName: falcon
+  hostAliases:
+  - ip: 10.0.0.1
+    hostnames: [proxy.internal]
+  containers:
+  - name: falcon
+    env:
+    - name: EXTRA
+      value: "1"
+`,
+	}
+
+	if err := ApplyPodTemplateOverride(&template, override); err != nil {
+		t.Fatalf("ApplyPodTemplateOverride() strategic merge error: %v", err)
+	}
+
+	runtimeClassName := "falcon"
+	want := testPodTemplate()
+	want.Labels["team"] = "security"
+	want.Annotations = map[string]string{common.FalconPodTemplateOverrideKey: podTemplateOverrideHash(override)}
+	want.Spec.RuntimeClassName = &runtimeClassName
+	want.Spec.HostAliases = []corev1.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"proxy.internal"}}}
+	want.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "EXTRA", Value: "1"}, {Name: "FOO", Value: "bar"}}
+	if diff := cmp.Diff(want, template); diff != "" {
+		t.Errorf("ApplyPodTemplateOverride() strategic merge mismatch (-want +got): %s", diff)
+	}
+
+	template = testPodTemplate()
+	override = &falconv1alpha1.PodTemplateOverride{
+		Type:  falconv1alpha1.PodTemplateOverrideJSON,
+		Patch: `[{"op": "add", "path": "/spec/dnsPolicy", "value": "None"}, {"op": "remove", "path": "/spec/containers/0/env"}]`,
+	}
+
+	if err := ApplyPodTemplateOverride(&template, override); err != nil {
+		t.Fatalf("ApplyPodTemplateOverride() JSON patch error: %v", err)
+	}
+
+	want = testPodTemplate()
+	want.Annotations = map[string]string{common.FalconPodTemplateOverrideKey: podTemplateOverrideHash(override)}
+	want.Spec.DNSPolicy = corev1.DNSNone
+	want.Spec.Containers[0].Env = nil
+	if diff := cmp.Diff(want, template); diff != "" {
+		t.Errorf("ApplyPodTemplateOverride() JSON patch mismatch (-want +got): %s", diff)
+	}
+}
+
+// TestApplyPodTemplateOverride_ProtectedFields tests that ApplyPodTemplateOverride rejects patches of protected fields
+func TestApplyPodTemplateOverride_ProtectedFields(t *testing.T) {
+	tests := map[string]falconv1alpha1.PodTemplateOverride{
+		"label":           {Type: falconv1alpha1.PodTemplateOverrideStrategicMerge, Patch: `{"metadata": {"labels": {"crowdstrike.com/component": "other"}}}`},
+		"annotation":      {Type: falconv1alpha1.PodTemplateOverrideStrategicMerge, Patch: `{"metadata": {"annotations": {"falcon.crowdstrike.com/pod-template-override": "x"}}}`},
+		"service account": {Type: falconv1alpha1.PodTemplateOverrideStrategicMerge, Patch: `{"spec": {"serviceAccountName": "other"}}`},
+		"image":           {Type: falconv1alpha1.PodTemplateOverrideStrategicMerge, Patch: `{"spec": {"containers": [{"name": "falcon", "image": "other:1.0"}]}}`},
+		"container":       {Type: falconv1alpha1.PodTemplateOverrideJSON, Patch: `[{"op": "remove", "path": "/spec/containers/0"}]`},
+		"invalid":         {Type: falconv1alpha1.PodTemplateOverrideJSON, Patch: `{"op": "remove"}`},
+		"host pid":        {Type: falconv1alpha1.PodTemplateOverrideStrategicMerge, Patch: `{"spec": {"hostPID": true}}`},
+		"host network":    {Type: falconv1alpha1.PodTemplateOverrideStrategicMerge, Patch: `{"spec": {"hostNetwork": true}}`},
+		"host ipc":        {Type: falconv1alpha1.PodTemplateOverrideStrategicMerge, Patch: `{"spec": {"hostIPC": true}}`},
+		"pod security":    {Type: falconv1alpha1.PodTemplateOverrideStrategicMerge, Patch: `{"spec": {"securityContext": {"runAsUser": 0}}}`},
+		"security":        {Type: falconv1alpha1.PodTemplateOverrideStrategicMerge, Patch: `{"spec": {"containers": [{"name": "falcon", "securityContext": {"privileged": true}}]}}`},
+		"privileged":      {Type: falconv1alpha1.PodTemplateOverrideStrategicMerge, Patch: `{"spec": {"containers": [{"name": "debug", "image": "debug:1.0", "securityContext": {"privileged": true}}]}}`},
+		"capabilities":    {Type: falconv1alpha1.PodTemplateOverrideStrategicMerge, Patch: `{"spec": {"initContainers": [{"name": "init", "image": "init:1.0", "securityContext": {"capabilities": {"add": ["SYS_ADMIN"]}}}]}}`},
+		"host path":       {Type: falconv1alpha1.PodTemplateOverrideStrategicMerge, Patch: `{"spec": {"volumes": [{"name": "root", "hostPath": {"path": "/"}}]}}`},
+	}
+
+	for name, override := range tests {
+		t.Run(name, func(t *testing.T) {
+			template := testPodTemplate()
+			if err := ApplyPodTemplateOverride(&template, &override); err == nil {
+				t.Error("ApplyPodTemplateOverride() did not return an error")
+			}
+
+			if diff := cmp.Diff(testPodTemplate(), template); diff != "" {
+				t.Errorf("ApplyPodTemplateOverride() modified the pod template on error (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+// TestApplyPodTemplateOverride_UnprivilegedSidecar tests that ApplyPodTemplateOverride accepts sidecars which do not expose the node
+func TestApplyPodTemplateOverride_UnprivilegedSidecar(t *testing.T) {
+	template := testPodTemplate()
+	override := &falconv1alpha1.PodTemplateOverride{
+		Type: falconv1alpha1.PodTemplateOverrideStrategicMerge,
+		Patch: `
+spec:
+  containers:
+  - name: proxy
+    image: proxy:1.0
+    securityContext:
+      allowPrivilegeEscalation: false
+    volumeMounts:
+    - name: cache
+      mountPath: /cache
+  volumes:
+  - name: cache
+    emptyDir: {}
+`,
+	}
+
+	if err := ApplyPodTemplateOverride(&template, override); err != nil {
+		t.Fatalf("ApplyPodTemplateOverride() error: %v", err)
+	}
+
+	if len(template.Spec.Containers) != 2 || len(template.Spec.Volumes) != 1 {
+		t.Errorf("ApplyPodTemplateOverride() did not add the sidecar and its volume: %+v", template.Spec)
+	}
+}
+
+// TestPodTemplateOverrideChanged tests the PodTemplateOverrideChanged function
+func TestPodTemplateOverrideChanged(t *testing.T) {
+	existing := testPodTemplate()
+	desired := testPodTemplate()
+	if PodTemplateOverrideChanged(&existing, &desired) {
+		t.Error("PodTemplateOverrideChanged() = true without override, want false")
+	}
+
+	override := &falconv1alpha1.PodTemplateOverride{Type: falconv1alpha1.PodTemplateOverrideStrategicMerge, Patch: `{"spec": {"dnsPolicy": "None"}}`}
+	if err := ApplyPodTemplateOverride(&desired, override); err != nil {
+		t.Fatalf("ApplyPodTemplateOverride() error: %v", err)
+	}
+
+	if !PodTemplateOverrideChanged(&existing, &desired) {
+		t.Error("PodTemplateOverrideChanged() = false after adding an override, want true")
+	}
+
+	if PodTemplateOverrideChanged(&desired, &desired) {
+		t.Error("PodTemplateOverrideChanged() = true for the same override, want false")
+	}
+}
@@ -0,0 +1,92 @@
+package assets
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/crowdstrike/falcon-operator/pkg/common"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const nodeTagsVolume = "falcon-tags"
+
+// TagsConfigMapName returns the name of the ConfigMap holding the sensor grouping tags rendered for each node of a sensor DaemonSet
+func TagsConfigMapName(node *falconv1alpha1.FalconNodeSensor) string {
+	return node.Name + "-tags"
+}
+
+// addNodeTags adds the init container setting the sensor grouping tags rendered for the node, which are read from the tags ConfigMap
+// when the pod starts. The hash of the tags and tag templates is recorded in the pod template annotations, so that changing them rolls out
+// the sensor pods. Changes of the tags rendered for a single node are applied by restarting the sensor pod of the node.
+func addNodeTags(ds *appsv1.DaemonSet, image string, node *falconv1alpha1.FalconNodeSensor) {
+	if len(node.GetTagTemplates()) == 0 {
+		return
+	}
+
+	privileged := true
+	escalation := true
+	runAsRoot := int64(0)
+	optional := true
+
+	spec := &ds.Spec.Template.Spec
+	spec.InitContainers = append(spec.InitContainers, corev1.Container{
+		Name:      "init-falcon-tags",
+		Image:     image,
+		Command:   common.FalconShellCommand,
+		Args:      common.InitTagsArgs(),
+		Resources: initContainerResources(node),
+		SecurityContext: &corev1.SecurityContext{
+			Privileged:               &privileged,
+			RunAsUser:                &runAsRoot,
+			ReadOnlyRootFilesystem:   isInitReadOnlyRootFilesystem(node),
+			AllowPrivilegeEscalation: &escalation,
+			Capabilities:             sensorCapabilities(node, true),
+		},
+		Env: []corev1.EnvVar{
+			{
+				Name: "POD_NODE_NAME",
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{
+						FieldPath: "spec.nodeName",
+					},
+				},
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      "falconstore",
+				MountPath: common.FalconStoreFile,
+			},
+			{
+				Name:      nodeTagsVolume,
+				MountPath: common.FalconNodeTagsDir,
+				ReadOnly:  true,
+			},
+		},
+	})
+
+	// The ConfigMap is optional so that the sensor pods can start before the operator renders the tags of a new node
+	spec.Volumes = append(spec.Volumes, corev1.Volume{
+		Name: nodeTagsVolume,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: TagsConfigMapName(node)},
+				Optional:             &optional,
+			},
+		},
+	})
+
+	if ds.Spec.Template.Annotations == nil {
+		ds.Spec.Template.Annotations = map[string]string{}
+	}
+	ds.Spec.Template.Annotations[common.FalconTagTemplatesKey] = TagsHash(node)
+}
+
+// TagsHash returns the hash of the static tags and tag templates of a sensor DaemonSet, recorded in its pod template annotations
+func TagsHash(node *falconv1alpha1.FalconNodeSensor) string {
+	tags := strings.Join(append(append([]string{}, node.Spec.Falcon.Tags...), node.GetTagTemplates()...), "\n")
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(tags)))[:16]
+}
@@ -0,0 +1,49 @@
+package assets
+
+import (
+	"testing"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/crowdstrike/falcon-operator/pkg/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAddNodeTags(t *testing.T) {
+	autopilot := false
+	falconNode := falconv1alpha1.FalconNodeSensor{ObjectMeta: metav1.ObjectMeta{Name: "falcon-node-sensor"}}
+	falconNode.Spec.Node.GKE.Enabled = &autopilot
+
+	ds := Daemonset(falconNode.Name, "image", "sa", &falconNode)
+	if len(ds.Spec.Template.Spec.InitContainers) != 1 || len(ds.Spec.Template.Spec.Volumes) != 1 {
+		t.Error("Daemonset() adds the tags init container without tag templates")
+	}
+
+	falconNode.Spec.Node.TagTemplates = []string{"zone/{{ index .Labels \"topology.kubernetes.io/zone\" }}"}
+	ds = Daemonset(falconNode.Name, "image", "sa", &falconNode)
+
+	initContainers := ds.Spec.Template.Spec.InitContainers
+	if len(initContainers) != 2 || initContainers[1].Name != "init-falcon-tags" || initContainers[1].Image != "image" {
+		t.Fatalf("Daemonset() init containers = %v, want init-falcon-tags after init-falconstore", initContainers)
+	}
+
+	volumes := ds.Spec.Template.Spec.Volumes
+	if len(volumes) != 2 || volumes[1].ConfigMap == nil || volumes[1].ConfigMap.Name != "falcon-node-sensor-tags" {
+		t.Errorf("Daemonset() volumes = %v, want the falcon-node-sensor-tags ConfigMap", volumes)
+	}
+
+	hash := ds.Spec.Template.Annotations[common.FalconTagTemplatesKey]
+	if hash == "" {
+		t.Fatal("Daemonset() does not record the hash of the tag templates")
+	}
+
+	falconNode.Spec.Falcon.Tags = []string{"static"}
+	if got := Daemonset(falconNode.Name, "image", "sa", &falconNode).Spec.Template.Annotations[common.FalconTagTemplatesKey]; got == hash {
+		t.Error("Daemonset() tag templates hash does not change with the static tags")
+	}
+
+	autopilot = true
+	ds = Daemonset(falconNode.Name, "image", "sa", &falconNode)
+	if len(ds.Spec.Template.Spec.InitContainers) != 1 {
+		t.Error("Daemonset() adds the tags init container on GKE Autopilot")
+	}
+}
@@ -1,6 +1,7 @@
 package assets
 
 import (
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
 	"github.com/crowdstrike/falcon-operator/pkg/common"
 	"golang.org/x/exp/maps"
 	arv1 "k8s.io/api/admissionregistration/v1"
@@ -8,7 +9,7 @@ import (
 )
 
 // ValidatingWebhook returns a ValidatingWebhookConfiguration object
-func ValidatingWebhook(name string, namespace string, webhookName string, caBundle []byte, port int32, failPolicy arv1.FailurePolicyType, disabledNamespaces []string) *arv1.ValidatingWebhookConfiguration {
+func ValidatingWebhook(name string, namespace string, webhookName string, caBundle []byte, port int32, failPolicy arv1.FailurePolicyType, disabledNamespaces []string, falconAdmission *falconv1alpha1.FalconAdmission) *arv1.ValidatingWebhookConfiguration {
 	failurePolicy := arv1.Ignore
 	matchPolicy := arv1.Equivalent
 	sideEffects := arv1.SideEffectClassNone
@@ -24,165 +25,191 @@ func ValidatingWebhook(name string, namespace string, webhookName string, caBund
 		"app.kubernetes.io/component": "kac",
 	}
 	maps.Copy(labels, helmLabels)
+	admissionConfig := falconAdmission.Spec.AdmissionConfig
 
-	return &arv1.ValidatingWebhookConfiguration{
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: arv1.SchemeGroupVersion.String(),
-			Kind:       "ValidatingWebhookConfiguration",
-		},
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      webhookName,
-			Namespace: namespace,
-			Labels:    labels,
-			Annotations: map[string]string{
-				"admissions.enforcer/disabled": "true",
+	if admissionConfig.TimeoutSeconds != nil {
+		timeoutSeconds = *admissionConfig.TimeoutSeconds
+	}
+
+	namespaceSelector := func() *metav1.LabelSelector {
+		selector := &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{
+					Key:      "kubernetes.io/metadata.name",
+					Operator: operatorSelector,
+					Values:   disabledNamespaces,
+				},
+				{
+					Key:      common.FalconAdmissionReviewKey,
+					Operator: operatorSelector,
+					Values:   admissionOperatorValues,
+				},
 			},
+		}
+
+		if admissionConfig.NamespaceSelector != nil {
+			selector.MatchLabels = admissionConfig.NamespaceSelector.MatchLabels
+			selector.MatchExpressions = append(selector.MatchExpressions, admissionConfig.NamespaceSelector.MatchExpressions...)
+		}
+
+		return selector
+	}
+
+	objectSelector := func() *metav1.LabelSelector {
+		// The API server defaults an unset object selector to an empty one, which matches every object
+		if admissionConfig.ObjectSelector == nil {
+			return &metav1.LabelSelector{}
+		}
+
+		return admissionConfig.ObjectSelector.DeepCopy()
+	}
+
+	operations := func() []arv1.OperationType {
+		return []arv1.OperationType{
+			arv1.Create,
+			arv1.Update,
+		}
+	}
+
+	clientConfig := arv1.WebhookClientConfig{
+		CABundle: caBundle,
+		Service: &arv1.ServiceReference{
+			Name:      name,
+			Namespace: namespace,
+			Path:      &path,
+			Port:      &port,
 		},
-		Webhooks: []arv1.ValidatingWebhook{
-			{
-				Name:                    webhookName,
-				AdmissionReviewVersions: []string{"v1"},
-				SideEffects:             &sideEffects,
-				FailurePolicy:           &failPolicy,
-				MatchPolicy:             &matchPolicy,
-				ClientConfig: arv1.WebhookClientConfig{
-					CABundle: caBundle,
-					Service: &arv1.ServiceReference{
-						Name:      name,
-						Namespace: namespace,
-						Path:      &path,
-						Port:      &port,
-					},
-				},
-				TimeoutSeconds: &timeoutSeconds,
-				NamespaceSelector: &metav1.LabelSelector{
-					MatchExpressions: []metav1.LabelSelectorRequirement{
-						{
-							Key:      "kubernetes.io/metadata.name",
-							Operator: operatorSelector,
-							Values:   disabledNamespaces,
-						},
-						{
-							Key:      common.FalconAdmissionReviewKey,
-							Operator: operatorSelector,
-							Values:   admissionOperatorValues,
+	}
+
+	webhooks := []arv1.ValidatingWebhook{}
+
+	if admissionConfig.Rules.PodsEnabled() {
+		webhooks = append(webhooks, arv1.ValidatingWebhook{
+			Name:                    webhookName,
+			AdmissionReviewVersions: []string{"v1"},
+			SideEffects:             &sideEffects,
+			FailurePolicy:           &failPolicy,
+			MatchPolicy:             &matchPolicy,
+			ClientConfig:            *clientConfig.DeepCopy(),
+			TimeoutSeconds:          &timeoutSeconds,
+			NamespaceSelector:       namespaceSelector(),
+			ObjectSelector:          objectSelector(),
+			Rules: []arv1.RuleWithOperations{
+				{
+					Operations: operations(),
+					Rule: arv1.Rule{
+						APIGroups: []string{
+							"",
 						},
-					},
-				},
-				Rules: []arv1.RuleWithOperations{
-					{
-						Operations: []arv1.OperationType{
-							arv1.Create,
-							arv1.Update,
+						APIVersions: []string{
+							"v1",
 						},
-						Rule: arv1.Rule{
-							APIGroups: []string{
-								"",
-							},
-							APIVersions: []string{
-								"v1",
-							},
-							Resources: []string{
-								"pods",
-								"pods/ephemeralcontainers",
-							},
-							Scope: &scope,
+						Resources: []string{
+							"pods",
+							"pods/ephemeralcontainers",
 						},
+						Scope: &scope,
 					},
 				},
 			},
-			{
-				Name:                    "workload." + webhookName,
-				AdmissionReviewVersions: []string{"v1"},
-				SideEffects:             &sideEffects,
-				FailurePolicy:           &failurePolicy,
-				MatchPolicy:             &matchPolicy,
-				ClientConfig: arv1.WebhookClientConfig{
-					CABundle: caBundle,
-					Service: &arv1.ServiceReference{
-						Name:      name,
-						Namespace: namespace,
-						Path:      &path,
-						Port:      &port,
-					},
+		})
+	}
+
+	workloadRules := []arv1.RuleWithOperations{}
+	coreResources := []string{}
+
+	if admissionConfig.Rules.WorkloadsEnabled() {
+		coreResources = append(coreResources, "replicationcontrollers")
+	}
+
+	if admissionConfig.Rules.ServicesEnabled() {
+		coreResources = append(coreResources, "services")
+	}
+
+	if len(coreResources) > 0 {
+		workloadRules = append(workloadRules, arv1.RuleWithOperations{
+			Operations: operations(),
+			Rule: arv1.Rule{
+				APIGroups: []string{
+					"",
 				},
-				TimeoutSeconds: &timeoutSeconds,
-				NamespaceSelector: &metav1.LabelSelector{
-					MatchExpressions: []metav1.LabelSelectorRequirement{
-						{
-							Key:      "kubernetes.io/metadata.name",
-							Operator: operatorSelector,
-							Values:   disabledNamespaces,
-						},
-						{
-							Key:      common.FalconAdmissionReviewKey,
-							Operator: operatorSelector,
-							Values:   admissionOperatorValues,
-						},
-					},
+				APIVersions: []string{
+					"v1",
 				},
-				Rules: []arv1.RuleWithOperations{
-					{
-						Operations: []arv1.OperationType{
-							arv1.Create,
-							arv1.Update,
-						},
-						Rule: arv1.Rule{
-							APIGroups: []string{
-								"",
-							},
-							APIVersions: []string{
-								"v1",
-							},
-							Resources: []string{
-								"replicationcontrollers",
-								"services",
-							},
-							Scope: &scope,
-						},
-					},
-					{
-						Operations: []arv1.OperationType{
-							arv1.Create,
-							arv1.Update,
-						},
-						Rule: arv1.Rule{
-							APIGroups: []string{
-								"apps",
-							},
-							APIVersions: []string{
-								"v1",
-							},
-							Resources: []string{
-								"daemonsets",
-								"deployments",
-								"replicasets",
-								"statefulsets",
-							},
-							Scope: &scope,
-						},
-					},
-					{
-						Operations: []arv1.OperationType{
-							arv1.Create,
-							arv1.Update,
-						},
-						Rule: arv1.Rule{
-							APIGroups: []string{
-								"batch",
-							},
-							APIVersions: []string{
-								"v1",
-							},
-							Resources: []string{
-								"cronjobs",
-								"jobs",
-							},
-							Scope: &scope,
-						},
-					},
+				Resources: coreResources,
+				Scope:     &scope,
+			},
+		})
+	}
+
+	if admissionConfig.Rules.WorkloadsEnabled() {
+		workloadRules = append(workloadRules, arv1.RuleWithOperations{
+			Operations: operations(),
+			Rule: arv1.Rule{
+				APIGroups: []string{
+					"apps",
+				},
+				APIVersions: []string{
+					"v1",
+				},
+				Resources: []string{
+					"daemonsets",
+					"deployments",
+					"replicasets",
+					"statefulsets",
+				},
+				Scope: &scope,
+			},
+		})
+	}
+
+	if admissionConfig.Rules.BatchEnabled() {
+		workloadRules = append(workloadRules, arv1.RuleWithOperations{
+			Operations: operations(),
+			Rule: arv1.Rule{
+				APIGroups: []string{
+					"batch",
+				},
+				APIVersions: []string{
+					"v1",
 				},
+				Resources: []string{
+					"cronjobs",
+					"jobs",
+				},
+				Scope: &scope,
+			},
+		})
+	}
+
+	if len(workloadRules) > 0 {
+		webhooks = append(webhooks, arv1.ValidatingWebhook{
+			Name:                    "workload." + webhookName,
+			AdmissionReviewVersions: []string{"v1"},
+			SideEffects:             &sideEffects,
+			FailurePolicy:           &failurePolicy,
+			MatchPolicy:             &matchPolicy,
+			ClientConfig:            *clientConfig.DeepCopy(),
+			TimeoutSeconds:          &timeoutSeconds,
+			NamespaceSelector:       namespaceSelector(),
+			ObjectSelector:          objectSelector(),
+			Rules:                   workloadRules,
+		})
+	}
+
+	return &arv1.ValidatingWebhookConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: arv1.SchemeGroupVersion.String(),
+			Kind:       "ValidatingWebhookConfiguration",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      webhookName,
+			Namespace: namespace,
+			Labels:    labels,
+			Annotations: map[string]string{
+				"admissions.enforcer/disabled": "true",
 			},
 		},
+		Webhooks: webhooks,
 	}
 }
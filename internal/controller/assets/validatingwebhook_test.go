@@ -3,6 +3,7 @@ package assets
 import (
 	"testing"
 
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
 	"github.com/crowdstrike/falcon-operator/pkg/common"
 	"github.com/google/go-cmp/cmp"
 	"golang.org/x/exp/maps"
@@ -14,12 +15,45 @@ import (
 func TestValidatingWebhook(t *testing.T) {
 	want := testValidatingWebhook("test", "test", "test", []byte("test"), 123, arv1.Ignore, []string{"ns1", "ns2"})
 
-	got := ValidatingWebhook("test", "test", "test", []byte("test"), 123, arv1.Ignore, []string{"ns1", "ns2"})
+	got := ValidatingWebhook("test", "test", "test", []byte("test"), 123, arv1.Ignore, []string{"ns1", "ns2"}, &falconv1alpha1.FalconAdmission{})
 	if diff := cmp.Diff(want, got); diff != "" {
 		t.Errorf("ValidatingWebhook() mismatch (-want +got): %s", diff)
 	}
 }
 
+// TestValidatingWebhookSelectorsAndRules tests the ValidatingWebhook function with selectors, timeout and rule toggles configured
+func TestValidatingWebhookSelectorsAndRules(t *testing.T) {
+	disabled := false
+	timeoutSeconds := int32(5)
+	falconAdmission := &falconv1alpha1.FalconAdmission{}
+	falconAdmission.Spec.AdmissionConfig.TimeoutSeconds = &timeoutSeconds
+	falconAdmission.Spec.AdmissionConfig.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "true"}}
+	falconAdmission.Spec.AdmissionConfig.ObjectSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}}
+	falconAdmission.Spec.AdmissionConfig.Rules.Workloads = &disabled
+	falconAdmission.Spec.AdmissionConfig.Rules.Batch = &disabled
+
+	want := testValidatingWebhook("test", "test", "test", []byte("test"), 123, arv1.Ignore, []string{"ns1", "ns2"})
+	for i := range want.Webhooks {
+		want.Webhooks[i].TimeoutSeconds = &timeoutSeconds
+		want.Webhooks[i].NamespaceSelector.MatchLabels = map[string]string{"tenant": "true"}
+		want.Webhooks[i].ObjectSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}}
+	}
+	want.Webhooks[1].Rules = want.Webhooks[1].Rules[:1]
+	want.Webhooks[1].Rules[0].Resources = []string{"services"}
+
+	got := ValidatingWebhook("test", "test", "test", []byte("test"), 123, arv1.Ignore, []string{"ns1", "ns2"}, falconAdmission)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ValidatingWebhook() mismatch (-want +got): %s", diff)
+	}
+
+	falconAdmission.Spec.AdmissionConfig.Rules.Pods = &disabled
+	falconAdmission.Spec.AdmissionConfig.Rules.Services = &disabled
+	got = ValidatingWebhook("test", "test", "test", []byte("test"), 123, arv1.Ignore, []string{"ns1", "ns2"}, falconAdmission)
+	if len(got.Webhooks) != 0 {
+		t.Errorf("ValidatingWebhook() expected no webhooks with every rule disabled, got %d", len(got.Webhooks))
+	}
+}
+
 // testValidatingWebhook is a helper function to create a ValidatingWebhookConfiguration
 func testValidatingWebhook(name string, namespace string, webhookName string, caBundle []byte, port int32, failPolicy arv1.FailurePolicyType, disabledNamespaces []string) *arv1.ValidatingWebhookConfiguration {
 	failurePolicy := arv1.Ignore
@@ -66,6 +100,7 @@ func testValidatingWebhook(name string, namespace string, webhookName string, ca
 					},
 				},
 				TimeoutSeconds: &timeoutSeconds,
+				ObjectSelector: &metav1.LabelSelector{},
 				NamespaceSelector: &metav1.LabelSelector{
 					MatchExpressions: []metav1.LabelSelectorRequirement{
 						{
@@ -118,6 +153,7 @@ func testValidatingWebhook(name string, namespace string, webhookName string, ca
 					},
 				},
 				TimeoutSeconds: &timeoutSeconds,
+				ObjectSelector: &metav1.LabelSelector{},
 				NamespaceSelector: &metav1.LabelSelector{
 					MatchExpressions: []metav1.LabelSelectorRequirement{
 						{
@@ -0,0 +1,306 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/csaupgrade"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+)
+
+// FieldManager is the field manager used by the operator to server-side apply the resources it manages
+const FieldManager = "falcon-operator"
+
+// ApplyResult is the outcome of the server-side apply of a managed resource
+type ApplyResult struct {
+	// Resource is the kind and name of the applied resource
+	Resource string
+
+	// Updated is true when the live resource has been modified by the apply
+	Updated bool
+
+	// Drift lists the fields set by the operator that have been changed by other field managers
+	Drift []string
+}
+
+// Apply updates an existing resource with ServerSideApply and records the outcome in the conditions of the Falcon Object CR
+func Apply(r client.Client, ctx context.Context, req ctrl.Request, log logr.Logger, falconObject client.Object, falconStatus *falconv1alpha1.FalconCRStatus, existing client.Object, obj client.Object, policy falconv1alpha1.FieldOwnershipPolicy) (ApplyResult, error) {
+	fgvk := falconObject.GetObjectKind().GroupVersionKind()
+	result, err := ServerSideApply(r, ctx, log, falconObject, existing, obj, policy)
+	if err != nil {
+		gvk := obj.GetObjectKind().GroupVersionKind()
+		log.Error(err, logMessage("Failed to apply", fgvk.Kind, gvk.Kind), oLogMessage(gvk.Kind, "Name"), obj.GetName(), oLogMessage(gvk.Kind, "Namespace"), obj.GetNamespace())
+
+		if err := ConditionsUpdate(r, ctx, req, log, falconObject, falconStatus,
+			metav1.Condition{
+				Status:             metav1.ConditionFalse,
+				Reason:             falconv1alpha1.ReasonUpdateFailed,
+				Type:               fmt.Sprintf("%sReady", gvk.Kind),
+				Message:            fmt.Sprintf("%s %s update has failed", fgvk.Kind, gvk.Kind),
+				ObservedGeneration: falconObject.GetGeneration(),
+			}); err != nil {
+			return result, err
+		}
+
+		return result, err
+	}
+
+	if condition := DriftCondition(falconObject, falconStatus.Conditions, result, policy); condition != nil {
+		if err := ConditionsUpdate(r, ctx, req, log, falconObject, falconStatus, *condition); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// ServerSideApply updates an existing resource managed by falconObject with server-side apply.
+// Changes made by other field managers to the fields set by the operator are reported in the result and, depending on
+// the field ownership policy, either reverted or left alone. On success, obj holds the live resource.
+func ServerSideApply(r client.Client, ctx context.Context, log logr.Logger, falconObject client.Object, existing client.Object, obj client.Object, policy falconv1alpha1.FieldOwnershipPolicy) (ApplyResult, error) {
+	gvk, err := apiutil.GVKForObject(obj, r.Scheme())
+	if err != nil {
+		return ApplyResult{}, err
+	}
+
+	result := ApplyResult{Resource: fmt.Sprintf("%s %s", gvk.Kind, obj.GetName())}
+
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	obj.SetResourceVersion("")
+	obj.SetManagedFields(nil)
+	if err := ctrl.SetControllerReference(falconObject, obj, r.Scheme()); err != nil {
+		return result, fmt.Errorf("unable to set controller reference on %s %s: %v", gvk.Kind, obj.GetName(), err)
+	}
+
+	if err := upgradeManagedFields(r, ctx, existing); err != nil {
+		return result, fmt.Errorf("unable to migrate the managed fields of %s %s to server-side apply: %v", gvk.Kind, obj.GetName(), err)
+	}
+
+	// Without forcing ownership, the apply conflicts on every field set by the operator that another field manager changed
+	err = r.Patch(ctx, obj.DeepCopyObject().(client.Object), client.Apply, client.FieldOwner(FieldManager), client.DryRunAll)
+	if apierrors.IsConflict(err) {
+		result.Drift = conflictFields(err)
+	} else if err != nil {
+		return result, err
+	}
+
+	applied := obj
+	if len(result.Drift) > 0 {
+		if policy == falconv1alpha1.FieldOwnershipRespectForeignFields {
+			log.Info("Leaving fields changed by other field managers alone", "Kind", gvk.Kind, "Name", obj.GetName(), "Fields", result.Drift)
+			applied, err = withoutForeignFields(obj, existing)
+			if err != nil {
+				return result, err
+			}
+		} else {
+			log.Info("Reverting fields changed by other field managers", "Kind", gvk.Kind, "Name", obj.GetName(), "Fields", result.Drift)
+		}
+	}
+
+	if err := r.Patch(ctx, applied, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership); err != nil {
+		return result, err
+	}
+
+	if u, ok := applied.(*unstructured.Unstructured); ok {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, obj); err != nil {
+			return result, err
+		}
+	}
+
+	result.Updated = obj.GetResourceVersion() != existing.GetResourceVersion()
+	return result, nil
+}
+
+// DriftCondition returns the DriftDetected condition to record after a resource has been applied,
+// or nil when the condition recorded in conditions is still accurate
+func DriftCondition(falconObject client.Object, conditions []metav1.Condition, result ApplyResult, policy falconv1alpha1.FieldOwnershipPolicy) *metav1.Condition {
+	condition := &metav1.Condition{
+		Type:               falconv1alpha1.ConditionDriftDetected,
+		Status:             metav1.ConditionFalse,
+		Reason:             falconv1alpha1.ReasonNoDrift,
+		Message:            "No changes made by other field managers to the managed resources",
+		ObservedGeneration: falconObject.GetGeneration(),
+	}
+
+	current := meta.FindStatusCondition(conditions, falconv1alpha1.ConditionDriftDetected)
+	if len(result.Drift) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = falconv1alpha1.ReasonDriftCorrected
+		if policy == falconv1alpha1.FieldOwnershipRespectForeignFields {
+			condition.Reason = falconv1alpha1.ReasonForeignFields
+		}
+		condition.Message = fmt.Sprintf("%s: %s", result.Resource, strings.Join(result.Drift, ", "))
+	} else if current != nil && (current.Status != metav1.ConditionTrue || !strings.HasPrefix(current.Message, result.Resource+":")) {
+		// Drift reported for another resource is cleared when that resource is applied
+		return nil
+	}
+
+	if current != nil && current.Status == condition.Status && current.Reason == condition.Reason && current.Message == condition.Message {
+		return nil
+	}
+
+	return condition
+}
+
+// upgradeManagedFields transfers the ownership of the fields written by client-side updates of the operator to its
+// server-side apply field manager, so that fields no longer set by the operator are removed from the live resource
+func upgradeManagedFields(r client.Client, ctx context.Context, existing client.Object) error {
+	patch, err := csaupgrade.UpgradeManagedFieldsPatch(existing, sets.New(legacyFieldManager()), FieldManager)
+	if err != nil || patch == nil {
+		return err
+	}
+
+	return r.Patch(ctx, existing, client.RawPatch(types.JSONPatchType, patch))
+}
+
+// legacyFieldManager returns the field manager recorded by the API server for the client-side updates of the operator,
+// which is derived from the name of the operator binary
+func legacyFieldManager() string {
+	return strings.Split(rest.DefaultKubernetesUserAgent(), "/")[0]
+}
+
+// conflictFields lists the conflicting fields and their field managers reported by a failed server-side apply
+func conflictFields(err error) []string {
+	var statusErr apierrors.APIStatus
+	if !errors.As(err, &statusErr) || statusErr.Status().Details == nil {
+		return []string{err.Error()}
+	}
+
+	fields := []string{}
+	for _, cause := range statusErr.Status().Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+
+		fields = append(fields, fmt.Sprintf("%s (%s)", cause.Field, strings.TrimPrefix(cause.Message, "conflict with ")))
+	}
+
+	return fields
+}
+
+// withoutForeignFields returns a copy of obj without the values owned by other field managers on the existing resource
+func withoutForeignFields(obj client.Object, existing client.Object) (client.Object, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range existing.GetManagedFields() {
+		if entry.Manager == FieldManager || entry.Subresource == "status" || entry.FieldsV1 == nil {
+			continue
+		}
+
+		owned := &fieldpath.Set{}
+		if err := owned.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+			return nil, fmt.Errorf("unable to decode the fields managed by %s: %v", entry.Manager, err)
+		}
+
+		owned.Iterate(func(path fieldpath.Path) {
+			removeFieldPath(content, path)
+		})
+	}
+
+	return &unstructured.Unstructured{Object: content}, nil
+}
+
+// removeFieldPath removes the value at path from content. Maps are kept as their other entries may be set by the operator.
+func removeFieldPath(content interface{}, path fieldpath.Path) {
+	if len(path) == 0 {
+		return
+	}
+
+	var child interface{}
+	switch c := content.(type) {
+	case map[string]interface{}:
+		if path[0].FieldName == nil {
+			return
+		}
+
+		value, ok := c[*path[0].FieldName]
+		if !ok {
+			return
+		}
+
+		if len(path) == 1 {
+			if _, isMap := value.(map[string]interface{}); !isMap {
+				delete(c, *path[0].FieldName)
+			}
+			return
+		}
+
+		child = value
+	case []interface{}:
+		i := listElementIndex(c, path[0])
+		if i < 0 || len(path) == 1 {
+			return
+		}
+
+		child = c[i]
+	default:
+		return
+	}
+
+	removeFieldPath(child, path[1:])
+}
+
+// listElementIndex returns the index of the list element identified by pe, or -1 if the list has no such element
+func listElementIndex(list []interface{}, pe fieldpath.PathElement) int {
+	switch {
+	case pe.Index != nil:
+		if *pe.Index < len(list) {
+			return *pe.Index
+		}
+	case pe.Key != nil:
+		for i, item := range list {
+			element, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			matches := true
+			for _, field := range *pe.Key {
+				if !sameValue(element[field.Name], field.Value.Unstructured()) {
+					matches = false
+					break
+				}
+			}
+
+			if matches {
+				return i
+			}
+		}
+	case pe.Value != nil:
+		for i, item := range list {
+			if sameValue(item, (*pe.Value).Unstructured()) {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// sameValue compares the JSON representation of two values, as numbers are decoded to different types by the
+// unstructured converter and the managed fields decoder
+func sameValue(a, b interface{}) bool {
+	aJSON, errA := json.Marshal(a)
+	bJSON, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(aJSON, bJSON)
+}
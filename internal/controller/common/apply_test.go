@@ -0,0 +1,131 @@
+package common
+
+import (
+	"testing"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestWithoutForeignFields(t *testing.T) {
+	replicas := int32(2)
+	obj := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Labels: map[string]string{"app": "test"}},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "sensor", Image: "falcon-sensor:7.18", ImagePullPolicy: corev1.PullAlways},
+						{Name: "proxy", Image: "proxy:1.0"},
+					},
+				},
+			},
+		},
+	}
+
+	existing := obj.DeepCopy()
+	existing.ManagedFields = []metav1.ManagedFieldsEntry{
+		{
+			Manager:  FieldManager,
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)},
+		},
+		{
+			Manager:  "kubectl-edit",
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{}},"f:spec":{"f:replicas":{},"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"sensor\"}":{"f:image":{}}}}}}}`)},
+		},
+		{
+			Manager:     "kube-controller-manager",
+			Subresource: "status",
+			FieldsV1:    &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"proxy\"}":{"f:image":{}}}}}}}`)},
+		},
+	}
+
+	got, err := withoutForeignFields(obj, existing)
+	if err != nil {
+		t.Fatalf("withoutForeignFields() error = %v", err)
+	}
+
+	content := got.(*unstructured.Unstructured).Object
+	if _, found, _ := unstructured.NestedFieldNoCopy(content, "spec", "replicas"); found {
+		t.Error("withoutForeignFields() kept spec.replicas owned by another field manager")
+	}
+
+	labels, _, _ := unstructured.NestedStringMap(content, "metadata", "labels")
+	if diff := cmp.Diff(map[string]string{"app": "test"}, labels); diff != "" {
+		t.Errorf("withoutForeignFields() labels mismatch (-want +got): %s", diff)
+	}
+
+	containers, _, _ := unstructured.NestedSlice(content, "spec", "template", "spec", "containers")
+	want := []interface{}{
+		map[string]interface{}{"name": "sensor", "imagePullPolicy": "Always", "resources": map[string]interface{}{}},
+		map[string]interface{}{"name": "proxy", "image": "proxy:1.0", "resources": map[string]interface{}{}},
+	}
+	if diff := cmp.Diff(want, containers); diff != "" {
+		t.Errorf("withoutForeignFields() containers mismatch (-want +got): %s", diff)
+	}
+
+	if obj.Spec.Template.Spec.Containers[0].Image != "falcon-sensor:7.18" {
+		t.Error("withoutForeignFields() modified the desired object")
+	}
+}
+
+func TestConflictFields(t *testing.T) {
+	err := apierrors.NewApplyConflict([]metav1.StatusCause{
+		{Type: metav1.CauseTypeFieldManagerConflict, Message: `conflict with "kubectl-edit" using apps/v1`, Field: ".spec.replicas"},
+		{Type: metav1.CauseTypeFieldValueInvalid, Message: "invalid", Field: ".spec.template"},
+	}, "Apply failed with 1 conflict")
+
+	want := []string{`.spec.replicas ("kubectl-edit" using apps/v1)`}
+	if diff := cmp.Diff(want, conflictFields(err)); diff != "" {
+		t.Errorf("conflictFields() mismatch (-want +got): %s", diff)
+	}
+
+	notFound := apierrors.NewNotFound(schema.GroupResource{Resource: "deployments"}, "test")
+	if got := conflictFields(notFound); len(got) != 0 {
+		t.Errorf("conflictFields() = %v without conflict causes, want none", got)
+	}
+}
+
+func TestDriftCondition(t *testing.T) {
+	falconObject := &falconv1alpha1.FalconAdmission{ObjectMeta: metav1.ObjectMeta{Name: "test", Generation: 3}}
+	drift := ApplyResult{Resource: "Deployment falcon-kac", Drift: []string{".spec.replicas (kubectl-edit)"}}
+
+	got := DriftCondition(falconObject, nil, drift, falconv1alpha1.FieldOwnershipForce)
+	want := &metav1.Condition{
+		Type:               falconv1alpha1.ConditionDriftDetected,
+		Status:             metav1.ConditionTrue,
+		Reason:             falconv1alpha1.ReasonDriftCorrected,
+		Message:            "Deployment falcon-kac: .spec.replicas (kubectl-edit)",
+		ObservedGeneration: 3,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("DriftCondition() mismatch (-want +got): %s", diff)
+	}
+
+	got = DriftCondition(falconObject, nil, drift, falconv1alpha1.FieldOwnershipRespectForeignFields)
+	if got == nil || got.Reason != falconv1alpha1.ReasonForeignFields {
+		t.Errorf("DriftCondition() = %v, want reason %s", got, falconv1alpha1.ReasonForeignFields)
+	}
+
+	conditions := []metav1.Condition{*want}
+	if got := DriftCondition(falconObject, conditions, drift, falconv1alpha1.FieldOwnershipForce); got != nil {
+		t.Errorf("DriftCondition() = %v with an unchanged condition, want nil", got)
+	}
+
+	// Drift reported for another resource is kept until that resource is applied
+	if got := DriftCondition(falconObject, conditions, ApplyResult{Resource: "ConfigMap falcon-kac-config"}, falconv1alpha1.FieldOwnershipForce); got != nil {
+		t.Errorf("DriftCondition() = %v for another resource, want nil", got)
+	}
+
+	got = DriftCondition(falconObject, conditions, ApplyResult{Resource: "Deployment falcon-kac"}, falconv1alpha1.FieldOwnershipForce)
+	if got == nil || got.Status != metav1.ConditionFalse || got.Reason != falconv1alpha1.ReasonNoDrift {
+		t.Errorf("DriftCondition() = %v after the drift has been corrected, want %s", got, falconv1alpha1.ReasonNoDrift)
+	}
+}
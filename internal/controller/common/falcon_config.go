@@ -0,0 +1,117 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ResolveFalconConfig returns a copy of a Falcon custom resource with the settings of the FalconConfig it references merged into
+// its spec. Settings configured on the custom resource take precedence over the ones of the FalconConfig. The custom resource itself
+// is left untouched, so that the merged settings, which hold credentials, are never written back to the API server.
+func ResolveFalconConfig[T FalconReconciler[T], U FalconCRD](
+	ctx context.Context,
+	reconciler T,
+	falconCrd U,
+) (U, error) {
+	falconCrd = any(falconCrd).(runtime.Object).DeepCopyObject().(U)
+
+	ref := falconCrd.GetFalconConfigRef()
+	if ref == nil {
+		return falconCrd, nil
+	}
+
+	falconConfig := &falconv1alpha1.FalconConfig{}
+	if err := reconciler.GetK8sClient().Get(ctx, types.NamespacedName{Name: ref.Name}, falconConfig); err != nil {
+		return falconCrd, fmt.Errorf("unable to get FalconConfig %s: %w", ref.Name, err)
+	}
+
+	if falconCrd.GetFalconAPISpec() == nil && falconConfig.Spec.FalconAPI != nil {
+		falconCrd.SetFalconAPISpec(falconConfig.Spec.FalconAPI.DeepCopy())
+	}
+
+	if !falconCrd.GetFalconSecretSpec().Enabled {
+		falconCrd.SetFalconSecretSpec(falconConfig.Spec.FalconSecret)
+	}
+
+	falconSpec := falconCrd.GetFalconSpec()
+	if falconSpec.APH == "" {
+		falconSpec.APH = falconConfig.Spec.Proxy.Host
+	}
+	if falconSpec.APP == nil {
+		falconSpec.APP = falconConfig.Spec.Proxy.Port
+	}
+	falconCrd.SetFalconSpec(falconSpec)
+
+	// The registry of the custom resource defaults to the CrowdStrike registry, which is treated as unset
+	registry := falconCrd.GetRegistrySpec()
+	if falconConfig.Spec.Registry != nil && (reflect.DeepEqual(registry, falconv1alpha1.RegistrySpec{}) ||
+		reflect.DeepEqual(registry, falconv1alpha1.RegistrySpec{Type: falconv1alpha1.RegistryTypeCrowdStrike})) {
+		falconCrd.SetRegistrySpec(*falconConfig.Spec.Registry.DeepCopy())
+	}
+
+	return falconCrd, nil
+}
+
+// UpdateStatus updates the status of a Falcon custom resource without refreshing the object from the response of the API server,
+// so that the spec resolved by ResolveFalconConfig is kept for the rest of the reconciliation
+func UpdateStatus(ctx context.Context, c client.Client, obj client.Object) error {
+	updated := obj.DeepCopyObject().(client.Object)
+	if err := c.Status().Update(ctx, updated); err != nil {
+		return err
+	}
+
+	obj.SetResourceVersion(updated.GetResourceVersion())
+	return nil
+}
+
+// RefreshStatus re-fetches the metadata and the status of a Falcon custom resource from the API server, keeping the spec resolved by
+// ResolveFalconConfig for the rest of the reconciliation
+func RefreshStatus(ctx context.Context, c client.Reader, key types.NamespacedName, obj client.Object) error {
+	resolved := obj.DeepCopyObject()
+	if err := c.Get(ctx, key, obj); err != nil {
+		return err
+	}
+
+	if spec := reflect.ValueOf(obj).Elem().FieldByName("Spec"); spec.IsValid() && spec.CanSet() {
+		spec.Set(reflect.ValueOf(resolved).Elem().FieldByName("Spec"))
+	}
+
+	return nil
+}
+
+// FalconConfigRequests maps a FalconConfig to the reconcile requests of the custom resources of the list type referencing it
+func FalconConfigRequests(ctx context.Context, c client.Reader, list client.ObjectList, falconConfig client.Object) []reconcile.Request {
+	if err := c.List(ctx, list); err != nil {
+		return nil
+	}
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, item := range items {
+		obj, ok := item.(interface {
+			client.Object
+			GetFalconConfigRef() *falconv1alpha1.FalconConfigReference
+		})
+		if !ok {
+			continue
+		}
+
+		if ref := obj.GetFalconConfigRef(); ref != nil && ref.Name == falconConfig.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: obj.GetName()}})
+		}
+	}
+
+	return requests
+}
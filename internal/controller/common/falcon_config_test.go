@@ -0,0 +1,246 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type fakeFalconReconciler struct {
+	client client.Client
+}
+
+func (r fakeFalconReconciler) GetK8sClient() client.Client {
+	return r.client
+}
+
+func (r fakeFalconReconciler) GetK8sReader() client.Reader {
+	return r.client
+}
+
+func getFakeFalconClient(t *testing.T, initObjs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	if err := falconv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(initObjs...).Build()
+}
+
+func testFalconConfig() *falconv1alpha1.FalconConfig {
+	port := 3128
+	acrName := "falconacr"
+	return &falconv1alpha1.FalconConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "falcon-config"},
+		Spec: falconv1alpha1.FalconConfigSpec{
+			FalconAPI:    &falconv1alpha1.FalconAPI{CloudRegion: "us-1", ClientId: "config-id", ClientSecret: "config-secret"},
+			FalconSecret: falconv1alpha1.FalconSecret{Enabled: true, Namespace: "falcon-secrets", SecretName: "falcon-secret"},
+			Proxy:        falconv1alpha1.FalconProxy{Host: "proxy.example.com", Port: &port},
+			Registry:     &falconv1alpha1.RegistrySpec{Type: falconv1alpha1.RegistryTypeACR, AcrName: &acrName},
+		},
+	}
+}
+
+func TestResolveFalconConfig(t *testing.T) {
+	ctx := context.Background()
+	falconConfig := testFalconConfig()
+	r := fakeFalconReconciler{client: getFakeFalconClient(t, falconConfig)}
+
+	falconAdmission := &falconv1alpha1.FalconAdmission{}
+	falconAdmission.Spec.FalconConfigRef = &falconv1alpha1.FalconConfigReference{Name: "falcon-config"}
+	falconAdmission.Spec.Registry = falconv1alpha1.RegistrySpec{Type: falconv1alpha1.RegistryTypeCrowdStrike}
+	falconAdmission.Spec.Falcon.APH = "admission-proxy.example.com"
+
+	liveAdmission := falconAdmission
+	falconAdmission, err := ResolveFalconConfig(ctx, r, liveAdmission)
+	if err != nil {
+		t.Fatalf("ResolveFalconConfig() error: %v", err)
+	}
+
+	if liveAdmission.Spec.FalconAPI != nil || liveAdmission.Spec.Registry.Type != falconv1alpha1.RegistryTypeCrowdStrike {
+		t.Errorf("ResolveFalconConfig() modified the custom resource")
+	}
+
+	if diff := cmp.Diff(falconConfig.Spec.FalconAPI, falconAdmission.Spec.FalconAPI); diff != "" {
+		t.Errorf("ResolveFalconConfig() FalconAPI mismatch (-want +got): %s", diff)
+	}
+
+	if diff := cmp.Diff(falconConfig.Spec.FalconSecret, falconAdmission.Spec.FalconSecret); diff != "" {
+		t.Errorf("ResolveFalconConfig() FalconSecret mismatch (-want +got): %s", diff)
+	}
+
+	if diff := cmp.Diff(*falconConfig.Spec.Registry, falconAdmission.Spec.Registry); diff != "" {
+		t.Errorf("ResolveFalconConfig() Registry mismatch (-want +got): %s", diff)
+	}
+
+	if falconAdmission.Spec.Falcon.APH != "admission-proxy.example.com" {
+		t.Errorf("ResolveFalconConfig() overrode the proxy host of the custom resource: %s", falconAdmission.Spec.Falcon.APH)
+	}
+
+	if falconAdmission.Spec.Falcon.APP == nil || *falconAdmission.Spec.Falcon.APP != 3128 {
+		t.Errorf("ResolveFalconConfig() proxy port = %v, want 3128", falconAdmission.Spec.Falcon.APP)
+	}
+}
+
+func TestResolveFalconConfig_CustomResourceTakesPrecedence(t *testing.T) {
+	ctx := context.Background()
+	r := fakeFalconReconciler{client: getFakeFalconClient(t, testFalconConfig())}
+
+	falconAPI := &falconv1alpha1.FalconAPI{CloudRegion: "eu-1", ClientId: "cr-id", ClientSecret: "cr-secret"}
+	registry := falconv1alpha1.RegistrySpec{Type: falconv1alpha1.RegistryTypeECR}
+	falconContainer := &falconv1alpha1.FalconContainer{}
+	falconContainer.Spec.FalconConfigRef = &falconv1alpha1.FalconConfigReference{Name: "falcon-config"}
+	falconContainer.Spec.FalconAPI = falconAPI.DeepCopy()
+	falconContainer.Spec.Registry = registry
+
+	falconContainer, err := ResolveFalconConfig(ctx, r, falconContainer)
+	if err != nil {
+		t.Fatalf("ResolveFalconConfig() error: %v", err)
+	}
+
+	if diff := cmp.Diff(falconAPI, falconContainer.Spec.FalconAPI); diff != "" {
+		t.Errorf("ResolveFalconConfig() FalconAPI mismatch (-want +got): %s", diff)
+	}
+
+	if diff := cmp.Diff(registry, falconContainer.Spec.Registry); diff != "" {
+		t.Errorf("ResolveFalconConfig() Registry mismatch (-want +got): %s", diff)
+	}
+}
+
+func TestResolveFalconConfig_MissingConfig(t *testing.T) {
+	ctx := context.Background()
+	r := fakeFalconReconciler{client: getFakeFalconClient(t)}
+
+	nodeSensor := &falconv1alpha1.FalconNodeSensor{}
+	if _, err := ResolveFalconConfig(ctx, r, nodeSensor); err != nil {
+		t.Errorf("ResolveFalconConfig() without reference error: %v", err)
+	}
+
+	nodeSensor.Spec.FalconConfigRef = &falconv1alpha1.FalconConfigReference{Name: "falcon-config"}
+	if _, err := ResolveFalconConfig(ctx, r, nodeSensor); err == nil {
+		t.Error("ResolveFalconConfig() with a missing FalconConfig did not return an error")
+	}
+}
+
+func TestFalconConfigRequests(t *testing.T) {
+	ctx := context.Background()
+	referencing := &falconv1alpha1.FalconNodeSensor{ObjectMeta: metav1.ObjectMeta{Name: "referencing"}}
+	referencing.Spec.FalconConfigRef = &falconv1alpha1.FalconConfigReference{Name: "falcon-config"}
+	other := &falconv1alpha1.FalconNodeSensor{ObjectMeta: metav1.ObjectMeta{Name: "other"}}
+	other.Spec.FalconConfigRef = &falconv1alpha1.FalconConfigReference{Name: "other-config"}
+	standalone := &falconv1alpha1.FalconNodeSensor{ObjectMeta: metav1.ObjectMeta{Name: "standalone"}}
+	c := getFakeFalconClient(t, referencing, other, standalone)
+
+	got := FalconConfigRequests(ctx, c, &falconv1alpha1.FalconNodeSensorList{}, testFalconConfig())
+	want := []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "referencing"}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("FalconConfigRequests() mismatch (-want +got): %s", diff)
+	}
+}
+
+func TestUpdateStatus_KeepsResolvedSpec(t *testing.T) {
+	ctx := context.Background()
+	falconAdmission := &falconv1alpha1.FalconAdmission{ObjectMeta: metav1.ObjectMeta{Name: "falcon-kac"}}
+	falconAdmission.Spec.FalconConfigRef = &falconv1alpha1.FalconConfigReference{Name: "falcon-config"}
+
+	scheme := runtime.NewScheme()
+	if err := falconv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(testFalconConfig(), falconAdmission).WithStatusSubresource(falconAdmission).Build()
+	r := fakeFalconReconciler{client: c}
+
+	resolved, err := ResolveFalconConfig(ctx, r, falconAdmission)
+	if err != nil {
+		t.Fatalf("ResolveFalconConfig() error: %v", err)
+	}
+
+	sensor := "7.14.0"
+	resolved.Status.Sensor = &sensor
+	if err := UpdateStatus(ctx, c, resolved); err != nil {
+		t.Fatalf("UpdateStatus() error: %v", err)
+	}
+
+	if resolved.Spec.FalconAPI == nil {
+		t.Error("UpdateStatus() dropped the spec merged with the FalconConfig")
+	}
+
+	live := &falconv1alpha1.FalconAdmission{}
+	if err := c.Get(ctx, types.NamespacedName{Name: "falcon-kac"}, live); err != nil {
+		t.Fatal(err)
+	}
+	if live.Spec.FalconAPI != nil {
+		t.Error("UpdateStatus() wrote the spec merged with the FalconConfig to the API server")
+	}
+	if live.Status.Sensor == nil || *live.Status.Sensor != sensor {
+		t.Errorf("UpdateStatus() status sensor = %v, want %s", live.Status.Sensor, sensor)
+	}
+	if live.ResourceVersion != resolved.ResourceVersion {
+		t.Errorf("UpdateStatus() resource version = %s, want %s", resolved.ResourceVersion, live.ResourceVersion)
+	}
+}
+
+func TestCreate_KeepsResolvedSpec(t *testing.T) {
+	ctx := context.Background()
+	falconAdmission := &falconv1alpha1.FalconAdmission{ObjectMeta: metav1.ObjectMeta{Name: "falcon-kac"}}
+	falconAdmission.Spec.FalconConfigRef = &falconv1alpha1.FalconConfigReference{Name: "falcon-config"}
+
+	scheme := runtime.NewScheme()
+	if err := falconv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(testFalconConfig(), falconAdmission).WithStatusSubresource(falconAdmission).Build()
+	r := fakeFalconReconciler{client: c}
+
+	resolved, err := ResolveFalconConfig(ctx, r, falconAdmission)
+	if err != nil {
+		t.Fatalf("ResolveFalconConfig() error: %v", err)
+	}
+
+	// Creating a child resource updates the conditions of the custom resource, which refreshes its status
+	serviceAccount := &corev1.ServiceAccount{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ServiceAccount"},
+		ObjectMeta: metav1.ObjectMeta{Name: "falcon-kac", Namespace: "falcon-kac"},
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "falcon-kac"}}
+	if err := Create(c, scheme, ctx, req, logr.Discard(), resolved, &resolved.Status, serviceAccount); err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+
+	if diff := cmp.Diff(testFalconConfig().Spec.FalconAPI, resolved.Spec.FalconAPI); diff != "" {
+		t.Errorf("Create() dropped the FalconAPI merged with the FalconConfig (-want +got): %s", diff)
+	}
+	if diff := cmp.Diff(*testFalconConfig().Spec.Registry, resolved.Spec.Registry); diff != "" {
+		t.Errorf("Create() dropped the registry merged with the FalconConfig (-want +got): %s", diff)
+	}
+	if meta.FindStatusCondition(resolved.Status.Conditions, "ServiceAccountReady") == nil {
+		t.Error("Create() did not set the ServiceAccountReady condition")
+	}
+
+	live := &falconv1alpha1.FalconAdmission{}
+	if err := c.Get(ctx, types.NamespacedName{Name: "falcon-kac"}, live); err != nil {
+		t.Fatal(err)
+	}
+	if live.Spec.FalconAPI != nil {
+		t.Error("Create() wrote the spec merged with the FalconConfig to the API server")
+	}
+	if meta.FindStatusCondition(live.Status.Conditions, "ServiceAccountReady") == nil {
+		t.Error("Create() did not record the ServiceAccountReady condition")
+	}
+}
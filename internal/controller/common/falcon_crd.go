@@ -10,4 +10,8 @@ type FalconCRD interface {
 	SetFalconAPISpec(*v1alpha1.FalconAPI)
 	GetFalconSpec() v1alpha1.FalconSensor
 	SetFalconSpec(v1alpha1.FalconSensor)
+	GetFalconConfigRef() *v1alpha1.FalconConfigReference
+	SetFalconSecretSpec(v1alpha1.FalconSecret)
+	GetRegistrySpec() v1alpha1.RegistrySpec
+	SetRegistrySpec(v1alpha1.RegistrySpec)
 }
@@ -0,0 +1,124 @@
+package pullsecret
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type Handler func(context.Context, types.NamespacedName) error
+
+// Refresher periodically calls the registered handlers so that the CrowdStrike registry pull secrets
+// managed by each custom resource are refreshed even when no reconcile is triggered. Registering and unregistering
+// handlers never waits for a refresh cycle, so that reconciles are not blocked by the registry calls of the cycle.
+type Refresher struct {
+	mu              sync.Mutex
+	activeRefreshes map[refreshKey]Handler
+	ctx             context.Context
+	logger          logr.Logger
+	refreshInterval time.Duration
+}
+
+type refreshKey struct {
+	kind string
+	name types.NamespacedName
+}
+
+func NewRefresher(ctx context.Context, refreshInterval time.Duration) *Refresher {
+	return &Refresher{
+		activeRefreshes: make(map[refreshKey]Handler),
+		ctx:             ctx,
+		logger:          log.FromContext(ctx).WithName("pull-secret-refresher"),
+		refreshInterval: refreshInterval,
+	}
+}
+
+func (refresher *Refresher) StartRefreshing() {
+	if refresher == nil || refresher.refreshInterval <= 0 {
+		return
+	}
+
+	refresher.RefreshSecrets()
+}
+
+// Register adds or replaces the handler refreshing pull secrets of the given custom resource.
+// Calling Register on a nil Refresher is a no-op.
+func (refresher *Refresher) Register(kind string, name types.NamespacedName, handler Handler) {
+	if refresher == nil || refresher.refreshInterval <= 0 {
+		return
+	}
+
+	refresher.mu.Lock()
+	defer refresher.mu.Unlock()
+
+	key := refreshKey{kind: kind, name: name}
+	if _, exists := refresher.activeRefreshes[key]; !exists {
+		refresher.logDebug("added pull secret refresh", "kind", kind, "name", name.Name)
+	}
+	refresher.activeRefreshes[key] = handler
+}
+
+// Unregister stops refreshing pull secrets of the given custom resource.
+// Calling Unregister on a nil Refresher is a no-op.
+func (refresher *Refresher) Unregister(kind string, name types.NamespacedName) {
+	if refresher == nil || refresher.refreshInterval <= 0 {
+		return
+	}
+
+	refresher.mu.Lock()
+	defer refresher.mu.Unlock()
+
+	key := refreshKey{kind: kind, name: name}
+	if _, exists := refresher.activeRefreshes[key]; exists {
+		delete(refresher.activeRefreshes, key)
+		refresher.logDebug("deleted pull secret refresh", "kind", kind, "name", name.Name)
+	}
+}
+
+func (refresher *Refresher) RefreshSecrets() {
+	refresher.logDebug("started refreshing pull secrets")
+
+	timer := time.NewTimer(refresher.refreshInterval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-refresher.ctx.Done():
+			refresher.logDebug("stopped refreshing pull secrets")
+			return
+
+		case <-timer.C:
+			refresher.runRefreshCycle()
+
+			timer.Reset(refresher.refreshInterval)
+			refresher.logDebug("waiting for next refresh cycle", "interval", refresher.refreshInterval.String())
+		}
+	}
+}
+
+func (refresher *Refresher) logDebug(msg string, keysAndValues ...any) {
+	refresher.logger.V(1).Info(msg, keysAndValues...)
+}
+
+func (refresher *Refresher) runRefreshCycle() {
+	refresher.logDebug("started refresh cycle")
+
+	// The handlers run on a snapshot of the registry so that they do not hold the lock during their registry calls
+	refresher.mu.Lock()
+	activeRefreshes := make(map[refreshKey]Handler, len(refresher.activeRefreshes))
+	for key, handler := range refresher.activeRefreshes {
+		activeRefreshes[key] = handler
+	}
+	refresher.mu.Unlock()
+
+	for key, handler := range activeRefreshes {
+		// A failure to refresh one resource must not prevent refreshing the others; the next cycle retries it
+		if err := handler(refresher.ctx, key.name); err != nil {
+			refresher.logger.Error(err, "failed to refresh registry pull secrets", "kind", key.kind, "name", key.name.Name)
+		}
+	}
+}
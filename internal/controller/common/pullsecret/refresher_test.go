@@ -0,0 +1,154 @@
+package pullsecret
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const testRefreshInterval = time.Millisecond * 10
+
+func TestRefresher_WhenRegistered_CallsHandler(t *testing.T) {
+	expectedContext, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	expectedName := types.NamespacedName{
+		Namespace: "someNamespace",
+		Name:      "someName",
+	}
+
+	done := make(chan any)
+	channelOpen := true
+	handler := func(actualContext context.Context, actualName types.NamespacedName) error {
+		assert.Same(t, expectedContext, actualContext, "wrong context passed to handler")
+		assert.Equal(t, expectedName, actualName, "wrong name passed to handler")
+
+		if channelOpen {
+			close(done)
+			channelOpen = false
+		}
+
+		return nil
+	}
+
+	refresher := NewRefresher(expectedContext, testRefreshInterval)
+	go refresher.StartRefreshing()
+
+	refresher.Register("FalconNodeSensor", expectedName, handler)
+
+	select {
+	case <-time.After(time.Second):
+		require.Fail(t, "handler never called")
+
+	case <-done:
+		break
+	}
+}
+
+func TestRefresher_WhenHandlerFails_KeepsRefreshingOtherResources(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	failingName := types.NamespacedName{Namespace: "someNamespace", Name: "failing"}
+	workingName := types.NamespacedName{Namespace: "someNamespace", Name: "working"}
+
+	done := make(chan any)
+	calls := 0
+	refresher := NewRefresher(ctx, testRefreshInterval)
+	go refresher.StartRefreshing()
+
+	refresher.Register("FalconAdmission", failingName, func(_ context.Context, _ types.NamespacedName) error {
+		return errors.New("some error")
+	})
+	refresher.Register("FalconAdmission", workingName, func(_ context.Context, _ types.NamespacedName) error {
+		// Wait for a second cycle to verify the refresher survived the failing handler
+		calls++
+		if calls == 2 {
+			close(done)
+		}
+
+		return nil
+	})
+
+	select {
+	case <-time.After(time.Second):
+		require.Fail(t, "handler never called twice")
+
+	case <-done:
+		break
+	}
+}
+
+func TestRefresher_WhenUnregistered_DoesNotCallHandler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	name := types.NamespacedName{Namespace: "someNamespace", Name: "someName"}
+	handler := func(_ context.Context, _ types.NamespacedName) error {
+		require.Fail(t, "handler unexpectedly called")
+		return nil
+	}
+
+	refresher := NewRefresher(ctx, testRefreshInterval)
+	go refresher.StartRefreshing()
+
+	refresher.Register("FalconImageAnalyzer", name, handler)
+	refresher.Unregister("FalconImageAnalyzer", name)
+
+	time.Sleep(testRefreshInterval * 5)
+}
+
+func TestRefresher_WhenNil_IsNoOp(t *testing.T) {
+	var refresher *Refresher
+	name := types.NamespacedName{Namespace: "someNamespace", Name: "someName"}
+
+	refresher.Register("FalconContainer", name, nil)
+	refresher.Unregister("FalconContainer", name)
+	refresher.StartRefreshing()
+}
+
+func TestRefresher_WhileRefreshing_DoesNotBlockRegister(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	started := make(chan any)
+	release := make(chan any)
+	defer close(release)
+
+	refresher := NewRefresher(ctx, testRefreshInterval)
+	go refresher.StartRefreshing()
+
+	once := true
+	refresher.Register("FalconNodeSensor", types.NamespacedName{Name: "slow"}, func(_ context.Context, _ types.NamespacedName) error {
+		if once {
+			once = false
+			close(started)
+			<-release
+		}
+		return nil
+	})
+
+	select {
+	case <-time.After(time.Second):
+		require.Fail(t, "handler never called")
+	case <-started:
+	}
+
+	registered := make(chan any)
+	go func() {
+		refresher.Register("FalconAdmission", types.NamespacedName{Name: "other"}, func(_ context.Context, _ types.NamespacedName) error { return nil })
+		refresher.Unregister("FalconAdmission", types.NamespacedName{Name: "other"})
+		close(registered)
+	}()
+
+	select {
+	case <-time.After(time.Second):
+		require.Fail(t, "register blocked by the refresh cycle")
+	case <-registered:
+	}
+}
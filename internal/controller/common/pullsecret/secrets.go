@@ -0,0 +1,118 @@
+package pullsecret
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	k8sutils "github.com/crowdstrike/falcon-operator/internal/controller/common"
+	"github.com/crowdstrike/falcon-operator/pkg/common"
+	"github.com/crowdstrike/falcon-operator/pkg/registry/pulltoken"
+	"github.com/crowdstrike/gofalcon/falcon"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ManagedSecrets returns the CrowdStrike registry pull secrets controlled by the given custom resource
+func ManagedSecrets(ctx context.Context, cli client.Reader, owner client.Object) ([]corev1.Secret, error) {
+	secretList := &corev1.SecretList{}
+	if err := cli.List(ctx, secretList, client.MatchingLabels{
+		common.FalconInstanceNameKey: "secret",
+		common.FalconInstanceKey:     common.FalconPullSecretName,
+	}); err != nil {
+		return nil, fmt.Errorf("unable to list registry pull secrets: %v", err)
+	}
+
+	secrets := []corev1.Secret{}
+	for _, secret := range secretList.Items {
+		if secret.Name == common.FalconPullSecretName && metav1.IsControlledBy(&secret, owner) {
+			secrets = append(secrets, secret)
+		}
+	}
+
+	return secrets, nil
+}
+
+// Refresh fetches a new CrowdStrike registry pull token and stores it in every pull secret controlled by the given custom resource.
+// It returns the number of pull secrets that are now up to date, which is zero when the custom resource manages no pull secret.
+func Refresh(ctx context.Context, cli client.Client, owner client.Object, apiConfig *falcon.ApiConfig) (int, error) {
+	secrets, err := ManagedSecrets(ctx, cli, owner)
+	if err != nil || len(secrets) == 0 {
+		return 0, err
+	}
+
+	token, err := pulltoken.CrowdStrike(ctx, apiConfig)
+	if err != nil {
+		return 0, fmt.Errorf("unable to get registry pull token: %v", err)
+	}
+
+	secretData := map[string][]byte{corev1.DockerConfigJsonKey: common.CleanDecodedBase64(token)}
+	for i := range secrets {
+		if reflect.DeepEqual(secrets[i].Data, secretData) {
+			continue
+		}
+
+		secrets[i].Data = secretData
+		if err := cli.Update(ctx, &secrets[i]); err != nil {
+			return 0, fmt.Errorf("unable to update registry pull secret in namespace %s: %v", secrets[i].Namespace, err)
+		}
+	}
+
+	return len(secrets), nil
+}
+
+// Accessors give access to the fields of a custom resource managing CrowdStrike registry pull secrets
+type Accessors[T client.Object] struct {
+	// Kind is the kind of the custom resource
+	Kind string
+
+	// New returns an empty custom resource
+	New func() T
+
+	// ApiConfig returns the Falcon API configuration of the custom resource, or nil when it has no Falcon API credentials
+	ApiConfig func(context.Context, T) (*falcon.ApiConfig, error)
+
+	// RefreshTime returns the status field recording the last refresh of the registry token
+	RefreshTime func(T) **metav1.Time
+}
+
+// Handler returns the refresher handler keeping the pull secrets of the custom resource up to date
+func (a Accessors[T]) Handler(cli client.Client) Handler {
+	return func(ctx context.Context, name types.NamespacedName) error {
+		owner := a.New()
+		if err := cli.Get(ctx, name, owner); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+
+		apiConfig, err := a.ApiConfig(ctx, owner)
+		if err != nil || apiConfig == nil {
+			return err
+		}
+
+		refreshed, err := Refresh(ctx, cli, owner, apiConfig)
+		if err != nil || refreshed == 0 {
+			return err
+		}
+
+		log.FromContext(ctx).Info(fmt.Sprintf("Refreshed %s Registry Pull Secrets", a.Kind), "Count", refreshed)
+		return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			if err := cli.Get(ctx, name, owner); err != nil {
+				return err
+			}
+
+			return a.UpdateRefreshTime(ctx, cli, owner)
+		})
+	}
+}
+
+// UpdateRefreshTime records in the status of the custom resource that its registry token was refreshed now. The spec of the custom
+// resource is kept as is, since it may hold the settings merged from its FalconConfig.
+func (a Accessors[T]) UpdateRefreshTime(ctx context.Context, cli client.Client, owner T) error {
+	now := metav1.Now()
+	*a.RefreshTime(owner) = &now
+	return k8sutils.UpdateStatus(ctx, cli, owner)
+}
@@ -178,8 +178,9 @@ func ConditionsUpdate(r client.Client, ctx context.Context, req ctrl.Request, lo
 			// Re-fetch the Custom Resource before update the status
 			// so that we have the latest state of the resource on the cluster and we will avoid
 			// raise the issue "the object has been modified, please apply
-			// your changes to the latest version and try again" which would re-trigger the reconciliation
-			err := r.Get(ctx, req.NamespacedName, falconObject)
+			// your changes to the latest version and try again" which would re-trigger the reconciliation.
+			// The spec is kept, since it may hold the settings resolved from a FalconConfig.
+			err := RefreshStatus(ctx, r, req.NamespacedName, falconObject)
 			if err != nil {
 				log.Error(err, fmt.Sprintf("Failed to re-fetch %s for status update", fgvk.Kind))
 				return err
@@ -187,7 +188,7 @@ func ConditionsUpdate(r client.Client, ctx context.Context, req ctrl.Request, lo
 
 			// The following implementation will update the status
 			meta.SetStatusCondition(&falconStatus.Conditions, falconCondition)
-			return r.Status().Update(ctx, falconObject)
+			return UpdateStatus(ctx, r, falconObject)
 		})
 		if err != nil {
 			log.Error(err, fmt.Sprintf("Failed to update %s status", fgvk.Kind))
@@ -3,7 +3,6 @@ package falcon
 import (
 	"context"
 	"fmt"
-	"reflect"
 	"strconv"
 	"strings"
 
@@ -31,6 +30,7 @@ func (r *FalconContainerReconciler) reconcileGenericConfigMap(name string, genFu
 	if err != nil {
 		return configMap, fmt.Errorf("unable to render expected configmap: %v", err)
 	}
+	assets.ApplyResourceMetadata(configMap, falconContainer.Spec.ResourceMetadata)
 	existingConfigMap := &corev1.ConfigMap{}
 	err = common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: name, Namespace: falconContainer.Spec.InstallNamespace}, existingConfigMap)
 	if err != nil {
@@ -42,12 +42,7 @@ func (r *FalconContainerReconciler) reconcileGenericConfigMap(name string, genFu
 		}
 		return &corev1.ConfigMap{}, fmt.Errorf("unable to query existing config map %s: %v", name, err)
 	}
-	if reflect.DeepEqual(configMap.Data, existingConfigMap.Data) {
-		return existingConfigMap, nil
-	}
-	existingConfigMap.Data = configMap.Data
-	return existingConfigMap, r.Update(ctx, log, falconContainer, existingConfigMap)
-
+	return configMap, r.Apply(ctx, log, falconContainer, existingConfigMap, configMap)
 }
 
 func (r *FalconContainerReconciler) newCABundleConfigMap(ctx context.Context, log logr.Logger, falconContainer *falconv1alpha1.FalconContainer) (*corev1.ConfigMap, error) {
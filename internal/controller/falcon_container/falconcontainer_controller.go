@@ -8,9 +8,11 @@ import (
 
 	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
 	k8sutils "github.com/crowdstrike/falcon-operator/internal/controller/common"
+	"github.com/crowdstrike/falcon-operator/internal/controller/common/pullsecret"
 	"github.com/crowdstrike/falcon-operator/internal/controller/common/sensorversion"
 	"github.com/crowdstrike/falcon-operator/pkg/aws"
 	"github.com/crowdstrike/falcon-operator/pkg/common"
+	"github.com/crowdstrike/falcon-operator/pkg/gcp"
 	"github.com/crowdstrike/falcon-operator/version"
 	"github.com/crowdstrike/gofalcon/falcon"
 	"github.com/go-logr/logr"
@@ -26,8 +28,11 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 // FalconContainerReconciler reconciles a FalconContainer object
@@ -39,10 +44,12 @@ type FalconContainerReconciler struct {
 	RestConfig      *rest.Config
 	reconcileObject func(client.Object)
 	tracker         sensorversion.Tracker
+
+	pullSecretRefresher *pullsecret.Refresher
 }
 
 // SetupWithManager sets up the controller with the Manager.
-func (r *FalconContainerReconciler) SetupWithManager(mgr ctrl.Manager, tracker sensorversion.Tracker) error {
+func (r *FalconContainerReconciler) SetupWithManager(mgr ctrl.Manager, tracker sensorversion.Tracker, pullSecretRefresher *pullsecret.Refresher) error {
 	containerController, err := ctrl.NewControllerManagedBy(mgr).
 		For(&falconv1alpha1.FalconContainer{}).
 		Owns(&appsv1.Deployment{}).
@@ -53,6 +60,8 @@ func (r *FalconContainerReconciler) SetupWithManager(mgr ctrl.Manager, tracker s
 		Owns(&corev1.ServiceAccount{}).
 		Owns(&rbacv1.ClusterRoleBinding{}).
 		Owns(&arv1.MutatingWebhookConfiguration{}).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.namespaceToFalconContainers), builder.WithPredicates(namespaceLabelsChanged())).
+		Watches(&falconv1alpha1.FalconConfig{}, handler.EnqueueRequestsFromMapFunc(r.falconConfigToFalconContainers)).
 		Build(r)
 	if err != nil {
 		return err
@@ -64,9 +73,15 @@ func (r *FalconContainerReconciler) SetupWithManager(mgr ctrl.Manager, tracker s
 	}
 
 	r.tracker = tracker
+	r.pullSecretRefresher = pullSecretRefresher
 	return nil
 }
 
+// falconConfigToFalconContainers maps a FalconConfig to the FalconContainer custom resources referencing it
+func (r *FalconContainerReconciler) falconConfigToFalconContainers(ctx context.Context, obj client.Object) []reconcile.Request {
+	return k8sutils.FalconConfigRequests(ctx, r.Client, &falconv1alpha1.FalconContainerList{}, obj)
+}
+
 func (r *FalconContainerReconciler) GetK8sClient() client.Client {
 	return r.Client
 }
@@ -78,16 +93,17 @@ func (r *FalconContainerReconciler) GetK8sReader() client.Reader {
 //+kubebuilder:rbac:groups=falcon.crowdstrike.com,resources=falconcontainers,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=falcon.crowdstrike.com,resources=falconcontainers/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=falcon.crowdstrike.com,resources=falconcontainers/finalizers,verbs=get;update;patch
+//+kubebuilder:rbac:groups=falcon.crowdstrike.com,resources=falconconfigs,verbs=get;list;watch
 
 // +kubebuilder:rbac:groups=image.openshift.io,resources=imagestreams,verbs=get;list;watch;create;update;delete
-// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
-// +kubebuilder:rbac:groups="",resources=deployments,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups="",resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;delete
-// +kubebuilder:rbac:groups="apps",resources=deployments,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups="apps",resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="admissionregistration.k8s.io",resources=mutatingwebhookconfigurations,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=clusterrolebindings,verbs=get;list;watch;create;update;delete
 
@@ -103,6 +119,7 @@ func (r *FalconContainerReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	if err := r.Get(ctx, req.NamespacedName, falconContainer); err != nil {
 		if errors.IsNotFound(err) {
 			r.tracker.StopTracking(req.NamespacedName)
+			r.pullSecretRefresher.Unregister("FalconContainer", req.NamespacedName)
 
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
@@ -113,6 +130,8 @@ func (r *FalconContainerReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, err
 	}
 
+	r.pullSecretRefresher.Register("FalconContainer", req.NamespacedName, r.registryPullSecrets().Handler(r.Client))
+
 	if len(falconContainer.Status.Conditions) == 0 {
 		err := r.StatusUpdate(ctx, req, log, falconContainer, falconv1alpha1.ConditionPending,
 			metav1.ConditionFalse,
@@ -157,6 +176,13 @@ func (r *FalconContainerReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		}
 	}
 
+	// The FalconConfig is merged into a copy of the FalconContainer. StatusUpdate and k8sutils.UpdateStatus keep the merged spec in memory only.
+	falconContainer, err = k8sutils.ResolveFalconConfig(ctx, r, falconContainer)
+	if err != nil {
+		err = r.StatusUpdate(ctx, req, log, falconContainer, falconv1alpha1.ConditionFailed, metav1.ConditionFalse, "Reconciling", fmt.Sprintf("failed to merge FalconConfig: %v", err))
+		return ctrl.Result{}, err
+	}
+
 	if _, err := r.reconcileNamespace(ctx, log, falconContainer); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to reconcile namespace: %v", err)
 	}
@@ -219,6 +245,10 @@ func (r *FalconContainerReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 
 		if r.imageMirroringEnabled(falconContainer) {
 			if err := r.PushImage(ctx, log, falconContainer); err != nil {
+				if gcp.IsRepositoryCreating(err) {
+					log.Info("Waiting for the creation of the Artifact Registry repository", "Error", err.Error())
+					return ctrl.Result{RequeueAfter: gcp.ArtifactRegistryRequeueInterval}, nil
+				}
 				err = r.StatusUpdate(ctx, req, log, falconContainer, falconv1alpha1.ConditionFailed, metav1.ConditionFalse, "Reconciling", fmt.Sprintf("failed to refresh Falcon Container image: %v", err))
 				if err != nil {
 					return ctrl.Result{}, err
@@ -346,7 +376,7 @@ func (r *FalconContainerReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 
 func (r *FalconContainerReconciler) StatusUpdate(ctx context.Context, req ctrl.Request, log logr.Logger, falconContainer *falconv1alpha1.FalconContainer, condType string, status metav1.ConditionStatus, reason string, message string) error {
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		err := r.Get(ctx, req.NamespacedName, falconContainer)
+		err := k8sutils.RefreshStatus(ctx, r.Client, req.NamespacedName, falconContainer)
 		if err != nil {
 			return err
 		}
@@ -359,7 +389,7 @@ func (r *FalconContainerReconciler) StatusUpdate(ctx context.Context, req ctrl.R
 			ObservedGeneration: falconContainer.GetGeneration(),
 		})
 
-		return r.Status().Update(ctx, falconContainer)
+		return k8sutils.UpdateStatus(ctx, r.Client, falconContainer)
 	})
 	if err != nil {
 		log.Error(err, "Failed to update FalconContainer status")
@@ -4,12 +4,12 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
 
 	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	k8sutils "github.com/crowdstrike/falcon-operator/internal/controller/common"
 	"github.com/crowdstrike/falcon-operator/internal/controller/common/sensor"
 	"github.com/crowdstrike/falcon-operator/internal/controller/image"
 	"github.com/crowdstrike/falcon-operator/pkg/aws"
@@ -17,6 +17,7 @@ import (
 	"github.com/crowdstrike/falcon-operator/pkg/gcp"
 	"github.com/crowdstrike/falcon-operator/pkg/k8s_utils"
 	"github.com/crowdstrike/falcon-operator/pkg/registry/auth"
+	"github.com/crowdstrike/falcon-operator/pkg/registry/falcon_registry"
 	"github.com/crowdstrike/falcon-operator/pkg/registry/pushtoken"
 	"github.com/crowdstrike/gofalcon/falcon"
 	"github.com/go-logr/logr"
@@ -71,7 +72,7 @@ func (r *FalconContainerReconciler) PushImage(ctx context.Context, log logr.Logg
 			Reason:  "Pushed",
 		})
 
-		return r.Client.Status().Update(ctx, falconContainer)
+		return k8sutils.UpdateStatus(ctx, r.Client, falconContainer)
 	})
 
 	return err
@@ -102,7 +103,7 @@ func (r *FalconContainerReconciler) verifyCrowdStrikeRegistry(ctx context.Contex
 			ObservedGeneration: falconContainer.GetGeneration(),
 		})
 
-		return r.Client.Status().Update(ctx, falconContainer)
+		return k8sutils.UpdateStatus(ctx, r.Client, falconContainer)
 	})
 
 	return true, err
@@ -129,6 +130,8 @@ func (r *FalconContainerReconciler) registryUri(ctx context.Context, falconConta
 		}
 
 		return "gcr.io/" + projectId + "/falcon-container", nil
+	case falconv1alpha1.RegistryTypeGAR:
+		return gcp.ArtifactRegistryImageUri(ctx, falconContainer.Spec.Registry, "falcon-container")
 	case falconv1alpha1.RegistryTypeECR:
 		repo, err := aws.UpsertECRRepo(ctx, "falcon-container")
 		if err != nil {
@@ -197,14 +200,14 @@ func (r *FalconContainerReconciler) setImageTag(ctx context.Context, falconConta
 	if falconContainer.Spec.Image != nil && *falconContainer.Spec.Image != "" {
 		falconContainer.Status.Sensor = common.ImageVersion(*falconContainer.Spec.Image)
 
-		return *falconContainer.Status.Sensor, r.Client.Status().Update(ctx, falconContainer)
+		return *falconContainer.Status.Sensor, k8sutils.UpdateStatus(ctx, r.Client, falconContainer)
 	}
 
 	if os.Getenv("RELATED_IMAGE_SIDECAR_SENSOR") != "" && falconContainer.Spec.FalconAPI == nil {
 		image := os.Getenv("RELATED_IMAGE_SIDECAR_SENSOR")
 		falconContainer.Status.Sensor = common.ImageVersion(image)
 
-		return *falconContainer.Status.Sensor, r.Client.Status().Update(ctx, falconContainer)
+		return *falconContainer.Status.Sensor, k8sutils.UpdateStatus(ctx, r.Client, falconContainer)
 	}
 
 	falconApiConfig, apiConfigErr := r.falconApiConfig(ctx, falconContainer)
@@ -226,7 +229,7 @@ func (r *FalconContainerReconciler) setImageTag(ctx context.Context, falconConta
 }
 
 func (r *FalconContainerReconciler) pushAuth(ctx context.Context, falconContainer *falconv1alpha1.FalconContainer) (auth.Credentials, error) {
-	return pushtoken.GetCredentials(ctx, falconContainer.Spec.Registry.Type,
+	return pushtoken.GetCredentials(ctx, falconContainer.Spec.Registry,
 		k8s_utils.QuerySecretsInNamespace(r.Client, r.imageNamespace(falconContainer)),
 	)
 }
@@ -258,5 +261,5 @@ func (r *FalconContainerReconciler) versionLock(falconContainer *falconv1alpha1.
 		return false
 	}
 
-	return falconContainer.Spec.Version == nil || strings.Contains(*falconContainer.Status.Sensor, *falconContainer.Spec.Version)
+	return falcon_registry.VersionMatches(*falconContainer.Status.Sensor, falconContainer.Spec.Version)
 }
@@ -22,6 +22,7 @@ const (
 
 func (r *FalconContainerReconciler) reconcileImageStream(ctx context.Context, log logr.Logger, falconContainer *falconv1alpha1.FalconContainer) (*imagev1.ImageStream, error) {
 	imageStream := assets.ImageStream(imageStreamName, r.imageNamespace(falconContainer), common.FalconSidecarSensor)
+	assets.ApplyResourceMetadata(imageStream, falconContainer.Spec.ResourceMetadata)
 	existingImageStream := &imagev1.ImageStream{}
 
 	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: imageStreamName, Namespace: r.imageNamespace(falconContainer)}, existingImageStream)
@@ -37,7 +38,8 @@ func (r *FalconContainerReconciler) reconcileImageStream(ctx context.Context, lo
 		return &imagev1.ImageStream{}, fmt.Errorf("unable to query existing image stream %s: %v", imageStreamName, err)
 	}
 
-	if reflect.DeepEqual(imageStream.Spec, existingImageStream.Spec) {
+	metadataUpdated := assets.SyncResourceMetadata(existingImageStream, imageStream, falconContainer.Spec.ResourceMetadata)
+	if !metadataUpdated && reflect.DeepEqual(imageStream.Spec, existingImageStream.Spec) {
 		return existingImageStream, nil
 	}
 
@@ -15,7 +15,6 @@ import (
 	"github.com/crowdstrike/falcon-operator/pkg/tls"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	types "k8s.io/apimachinery/pkg/types"
 )
@@ -53,6 +52,7 @@ func (r *FalconContainerReconciler) reconcileInjectorTLSSecret(ctx context.Conte
 				"ca.crt":  b,
 			}
 			injectorTLSSecret := assets.Secret(injectorTLSSecretName, falconContainer.Spec.InstallNamespace, common.FalconSidecarSensor, secretData, corev1.SecretTypeTLS)
+			assets.ApplyResourceMetadata(injectorTLSSecret, falconContainer.Spec.ResourceMetadata)
 			if err = ctrl.SetControllerReference(falconContainer, injectorTLSSecret, r.Scheme); err != nil {
 				return &corev1.Secret{}, fmt.Errorf("unable to set controller reference on injector TLS Secret%s: %v", injectorTLSSecret.ObjectMeta.Name, err)
 			}
@@ -65,16 +65,19 @@ func (r *FalconContainerReconciler) reconcileInjectorTLSSecret(ctx context.Conte
 }
 
 func (r *FalconContainerReconciler) reconcileDeployment(ctx context.Context, log logr.Logger, falconContainer *falconv1alpha1.FalconContainer) (*appsv1.Deployment, error) {
-	update := false
-
 	imageUri, err := r.imageUri(ctx, falconContainer)
 	if err != nil {
 		return &appsv1.Deployment{}, fmt.Errorf("unable to determine falcon container image URI: %v", err)
 	}
 
 	deployment := assets.SideCarDeployment(injectorName, falconContainer.Spec.InstallNamespace, common.FalconSidecarSensor, imageUri, falconContainer)
+	assets.ApplyResourceMetadata(deployment, falconContainer.Spec.ResourceMetadata)
 	existingDeployment := &appsv1.Deployment{}
 
+	if err := assets.ApplyPodTemplateOverride(&deployment.Spec.Template, falconContainer.Spec.Injector.PodTemplateOverride); err != nil {
+		return &appsv1.Deployment{}, fmt.Errorf("unable to apply the injector pod template override: %v", err)
+	}
+
 	if len(proxy.ReadProxyVarsFromEnv()) > 0 {
 		for i, container := range deployment.Spec.Template.Spec.Containers {
 			deployment.Spec.Template.Spec.Containers[i].Env = append(container.Env, proxy.ReadProxyVarsFromEnv()...)
@@ -98,69 +101,5 @@ func (r *FalconContainerReconciler) reconcileDeployment(ctx context.Context, log
 		return &appsv1.Deployment{}, fmt.Errorf("unable to reconcile deployment; label selectors are not equal but are immutable")
 	}
 
-	if len(proxy.ReadProxyVarsFromEnv()) > 0 {
-		for i, container := range existingDeployment.Spec.Template.Spec.Containers {
-			newContainerEnv := common.AppendUniqueEnvVars(container.Env, proxy.ReadProxyVarsFromEnv())
-			updatedContainerEnv := common.UpdateEnvVars(container.Env, proxy.ReadProxyVarsFromEnv())
-			if !equality.Semantic.DeepEqual(existingDeployment.Spec.Template.Spec.Containers[i].Env, newContainerEnv) {
-				existingDeployment.Spec.Template.Spec.Containers[i].Env = newContainerEnv
-				update = true
-			}
-			if !equality.Semantic.DeepEqual(existingDeployment.Spec.Template.Spec.Containers[i].Env, updatedContainerEnv) {
-				existingDeployment.Spec.Template.Spec.Containers[i].Env = updatedContainerEnv
-				update = true
-			}
-			if update {
-				log.Info("Updating FalconNodeSensor Deployment Proxy Settings")
-			}
-		}
-
-	}
-
-	if !reflect.DeepEqual(deployment.Spec.Template.Spec.Containers[0].Image, existingDeployment.Spec.Template.Spec.Containers[0].Image) {
-		existingDeployment.Spec.Template.Spec.Containers[0].Image = deployment.Spec.Template.Spec.Containers[0].Image
-		update = true
-	}
-
-	if !reflect.DeepEqual(deployment.Spec.Template.Spec.Containers[0].Ports, existingDeployment.Spec.Template.Spec.Containers[0].Ports) {
-		existingDeployment.Spec.Template.Spec.Containers[0].Ports = deployment.Spec.Template.Spec.Containers[0].Ports
-		update = true
-	}
-
-	if !reflect.DeepEqual(deployment.Spec.Template.Spec.Containers[0].ImagePullPolicy, existingDeployment.Spec.Template.Spec.Containers[0].ImagePullPolicy) {
-		existingDeployment.Spec.Template.Spec.Containers[0].ImagePullPolicy = deployment.Spec.Template.Spec.Containers[0].ImagePullPolicy
-		update = true
-	}
-
-	if !reflect.DeepEqual(deployment.Spec.Template.Spec.Containers[0].Resources, existingDeployment.Spec.Template.Spec.Containers[0].Resources) {
-		existingDeployment.Spec.Template.Spec.Containers[0].Resources = deployment.Spec.Template.Spec.Containers[0].Resources
-		update = true
-	}
-
-	if !reflect.DeepEqual(deployment.Spec.Replicas, existingDeployment.Spec.Replicas) {
-		existingDeployment.Spec.Replicas = deployment.Spec.Replicas
-		update = true
-	}
-
-	if !reflect.DeepEqual(deployment.Spec.Template.Spec.TopologySpreadConstraints, existingDeployment.Spec.Template.Spec.TopologySpreadConstraints) {
-		existingDeployment.Spec.Template.Spec.TopologySpreadConstraints = deployment.Spec.Template.Spec.TopologySpreadConstraints
-		update = true
-	}
-
-	if !reflect.DeepEqual(deployment.Spec.Template.Spec.ImagePullSecrets, existingDeployment.Spec.Template.Spec.ImagePullSecrets) {
-		existingDeployment.Spec.Template.Spec.ImagePullSecrets = deployment.Spec.Template.Spec.ImagePullSecrets
-		update = true
-	}
-
-	if !reflect.DeepEqual(deployment.Spec.Template.Spec.Affinity.NodeAffinity, existingDeployment.Spec.Template.Spec.Affinity.NodeAffinity) {
-		existingDeployment.Spec.Template.Spec.Affinity.NodeAffinity = deployment.Spec.Template.Spec.Affinity.NodeAffinity
-		update = true
-	}
-
-	if update {
-		return existingDeployment, r.Update(ctx, log, falconContainer, existingDeployment)
-	}
-
-	return existingDeployment, nil
-
+	return deployment, r.Apply(ctx, log, falconContainer, existingDeployment, deployment)
 }
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	k8sutils "github.com/crowdstrike/falcon-operator/internal/controller/common"
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -39,7 +40,7 @@ func (r *FalconContainerReconciler) Create(ctx context.Context, log logr.Logger,
 				Message: fmt.Sprintf("Successfully created %s %s in %s", gvk.Kind, name, namespace),
 			})
 
-			return r.Client.Status().Update(ctx, falconContainer)
+			return k8sutils.UpdateStatus(ctx, r.Client, falconContainer)
 		})
 
 		return err
@@ -71,7 +72,7 @@ func (r *FalconContainerReconciler) Update(ctx context.Context, log logr.Logger,
 				Message: fmt.Sprintf("Successfully updated %s %s in %s", gvk.Kind, name, namespace),
 			})
 
-			return r.Client.Status().Update(ctx, falconContainer)
+			return k8sutils.UpdateStatus(ctx, r.Client, falconContainer)
 		})
 
 		return err
@@ -103,7 +104,7 @@ func (r *FalconContainerReconciler) Delete(ctx context.Context, log logr.Logger,
 				Message: fmt.Sprintf("Successfully deleted %s %s in %s", gvk.Kind, name, namespace),
 			})
 
-			return r.Client.Status().Update(ctx, falconContainer)
+			return k8sutils.UpdateStatus(ctx, r.Client, falconContainer)
 		})
 
 		return err
@@ -111,3 +112,21 @@ func (r *FalconContainerReconciler) Delete(ctx context.Context, log logr.Logger,
 		return fmt.Errorf("Unrecognized kube object type: %T", obj)
 	}
 }
+
+func (r *FalconContainerReconciler) Apply(ctx context.Context, log logr.Logger, falconContainer *falconv1alpha1.FalconContainer, existing client.Object, obj client.Object) error {
+	result, err := k8sutils.ServerSideApply(r.Client, ctx, log, falconContainer, existing, obj, falconContainer.Spec.FieldOwnership)
+	if err != nil {
+		return fmt.Errorf("Cannot apply object %s in namespace %s: %v", result.Resource, obj.GetNamespace(), err)
+	}
+
+	condition := k8sutils.DriftCondition(falconContainer, falconContainer.Status.Conditions, result, falconContainer.Spec.FieldOwnership)
+	if condition == nil {
+		return nil
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		meta.SetStatusCondition(&falconContainer.Status.Conditions, *condition)
+
+		return k8sutils.UpdateStatus(ctx, r.Client, falconContainer)
+	})
+}
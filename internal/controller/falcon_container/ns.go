@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/crowdstrike/falcon-operator/internal/controller/assets"
 	"github.com/crowdstrike/falcon-operator/pkg/common"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -31,6 +32,7 @@ func (r *FalconContainerReconciler) NamespaceLabels(falconContainer *falconv1alp
 
 func (r *FalconContainerReconciler) reconcileNamespace(ctx context.Context, log logr.Logger, falconContainer *falconv1alpha1.FalconContainer) (*corev1.Namespace, error) {
 	namespace := r.newNamespace(falconContainer)
+	assets.ApplyResourceMetadata(namespace, falconContainer.Spec.ResourceMetadata)
 	existingNamespace := &corev1.Namespace{}
 
 	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: falconContainer.Spec.InstallNamespace}, existingNamespace)
@@ -44,6 +46,10 @@ func (r *FalconContainerReconciler) reconcileNamespace(ctx context.Context, log
 		return &corev1.Namespace{}, fmt.Errorf("unable to query existing namespace %s: %v", falconContainer.Spec.InstallNamespace, err)
 	}
 
+	if assets.SyncResourceMetadata(existingNamespace, namespace, falconContainer.Spec.ResourceMetadata) {
+		return existingNamespace, r.Update(ctx, log, falconContainer, existingNamespace)
+	}
+
 	return existingNamespace, nil
 }
 
@@ -6,6 +6,7 @@ import (
 	"reflect"
 
 	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/crowdstrike/falcon-operator/internal/controller/assets"
 	"github.com/crowdstrike/falcon-operator/pkg/common"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -24,6 +25,7 @@ const (
 func (r *FalconContainerReconciler) reconcileServiceAccount(ctx context.Context, log logr.Logger, falconContainer *falconv1alpha1.FalconContainer) (*corev1.ServiceAccount, error) {
 	update := false
 	serviceAccount := r.newServiceAccount(falconContainer)
+	assets.ApplyResourceMetadata(serviceAccount, falconContainer.Spec.ResourceMetadata)
 	existingServiceAccount := &corev1.ServiceAccount{}
 
 	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: common.SidecarServiceAccountName, Namespace: falconContainer.Spec.InstallNamespace}, existingServiceAccount)
@@ -54,6 +56,7 @@ func (r *FalconContainerReconciler) reconcileServiceAccount(ctx context.Context,
 
 func (r *FalconContainerReconciler) reconcileClusterRoleBinding(ctx context.Context, log logr.Logger, falconContainer *falconv1alpha1.FalconContainer) (*rbacv1.ClusterRoleBinding, error) {
 	clusterRoleBinding := r.newClusterRoleBinding(falconContainer)
+	assets.ApplyResourceMetadata(clusterRoleBinding, falconContainer.Spec.ResourceMetadata)
 	existingClusterRoleBinding := &rbacv1.ClusterRoleBinding{}
 
 	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: injectorClusterRoleBindingName}, existingClusterRoleBinding)
@@ -76,7 +79,7 @@ func (r *FalconContainerReconciler) reconcileClusterRoleBinding(ctx context.Cont
 		}
 		return clusterRoleBinding, r.Create(ctx, log, falconContainer, clusterRoleBinding)
 		// If RoleRef is the same but Subjects have changed, update the object and post to k8s api
-	} else if !reflect.DeepEqual(clusterRoleBinding.Subjects, existingClusterRoleBinding.Subjects) {
+	} else if assets.SyncResourceMetadata(existingClusterRoleBinding, clusterRoleBinding, falconContainer.Spec.ResourceMetadata) || !reflect.DeepEqual(clusterRoleBinding.Subjects, existingClusterRoleBinding.Subjects) {
 		existingClusterRoleBinding.Subjects = clusterRoleBinding.Subjects
 		return existingClusterRoleBinding, r.Update(ctx, log, falconContainer, existingClusterRoleBinding)
 	}
@@ -7,23 +7,35 @@ import (
 
 	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
 	"github.com/crowdstrike/falcon-operator/internal/controller/assets"
+	k8sutils "github.com/crowdstrike/falcon-operator/internal/controller/common"
+	"github.com/crowdstrike/falcon-operator/internal/controller/common/pullsecret"
 	"github.com/crowdstrike/falcon-operator/pkg/common"
 	"github.com/crowdstrike/falcon-operator/pkg/registry/pulltoken"
+	"github.com/crowdstrike/gofalcon/falcon"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	types "k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 func (r *FalconContainerReconciler) reconcileRegistrySecrets(ctx context.Context, log logr.Logger, falconContainer *falconv1alpha1.FalconContainer) (*corev1.SecretList, error) {
-	injectionEnabledValue := "enabled"
-	injectionDisabledValue := "disabled"
-	disableDefaultNSInjection := false
 	secretList := &corev1.SecretList{}
 
-	if falconContainer.Spec.Injector.DisableDefaultNSInjection {
-		disableDefaultNSInjection = falconContainer.Spec.Injector.DisableDefaultNSInjection
+	selector := labels.Everything()
+	if falconContainer.Spec.Injector.PullSecretNamespaceSelector != nil {
+		var err error
+		selector, err = metav1.LabelSelectorAsSelector(falconContainer.Spec.Injector.PullSecretNamespaceSelector)
+		if err != nil {
+			return &corev1.SecretList{}, fmt.Errorf("invalid pull secret namespace selector: %v", err)
+		}
 	}
 
 	nsList := &corev1.NamespaceList{}
@@ -41,57 +53,163 @@ func (r *FalconContainerReconciler) reconcileRegistrySecrets(ctx context.Context
 		return &corev1.SecretList{}, fmt.Errorf("unable to get registry pull token: %v", err)
 	}
 
+	refreshed := false
+	selectedNamespaces := map[string]bool{}
 	for _, ns := range nsList.Items {
-		if ns.Name == "kube-public" || ns.Name == "kube-system" {
+		if !pullSecretNamespaceSelected(ns, selector, falconContainer) {
 			continue
 		}
-		if disableDefaultNSInjection {
-			// if default namespace injection is disabled, require that the injection label be set to enabled
-			// in both cases below, ensure that we're not blocking pull secret creation within the injector namespace
-			if (ns.Labels == nil || ns.Labels[common.FalconContainerInjection] != injectionEnabledValue) && ns.Name != falconContainer.Spec.InstallNamespace {
-				continue
-			}
-		} else {
-			// otherwise, just ensure the injection label is not set to disabled
-			if ns.Labels != nil && ns.Labels[common.FalconContainerInjection] == injectionDisabledValue && ns.Name != falconContainer.Spec.InstallNamespace {
-				continue
-			}
-		}
 
-		secret, err := r.reconcileRegistrySecret(ns.Name, pulltoken, ctx, log, falconContainer)
+		secret, updated, err := r.reconcileRegistrySecret(ns.Name, pulltoken, ctx, log, falconContainer)
 		if err != nil {
 			return secretList, fmt.Errorf("unable to reconcile registry secret in namespace %s: %v", ns.Name, err)
 		}
 
+		refreshed = refreshed || updated
+		selectedNamespaces[ns.Name] = true
 		secretList.Items = append(secretList.Items, *secret)
 	}
 
+	if err := r.cleanupRegistrySecrets(ctx, log, falconContainer, selectedNamespaces); err != nil {
+		return secretList, err
+	}
+
+	if refreshed {
+		return secretList, r.registryPullSecrets().UpdateRefreshTime(ctx, r.Client, falconContainer)
+	}
+
 	return secretList, nil
 }
 
-func (r *FalconContainerReconciler) reconcileRegistrySecret(namespace string, pulltoken []byte, ctx context.Context, log logr.Logger, falconContainer *falconv1alpha1.FalconContainer) (*corev1.Secret, error) {
+// pullSecretNamespaceSelected reports whether the registry pull secret should be present in the given namespace
+func pullSecretNamespaceSelected(ns corev1.Namespace, selector labels.Selector, falconContainer *falconv1alpha1.FalconContainer) bool {
+	injectionEnabledValue := "enabled"
+	injectionDisabledValue := "disabled"
+
+	// never block pull secret creation within the injector namespace
+	if ns.Name == falconContainer.Spec.InstallNamespace {
+		return true
+	}
+
+	if ns.Name == "kube-public" || ns.Name == "kube-system" {
+		return false
+	}
+
+	if falconContainer.Spec.Injector.DisableDefaultNSInjection {
+		// if default namespace injection is disabled, require that the injection label be set to enabled
+		if ns.Labels == nil || ns.Labels[common.FalconContainerInjection] != injectionEnabledValue {
+			return false
+		}
+	} else {
+		// otherwise, just ensure the injection label is not set to disabled
+		if ns.Labels != nil && ns.Labels[common.FalconContainerInjection] == injectionDisabledValue {
+			return false
+		}
+	}
+
+	return selector.Matches(labels.Set(ns.Labels))
+}
+
+// cleanupRegistrySecrets removes the registry pull secrets from namespaces that no longer receive them
+func (r *FalconContainerReconciler) cleanupRegistrySecrets(ctx context.Context, log logr.Logger, falconContainer *falconv1alpha1.FalconContainer, selectedNamespaces map[string]bool) error {
+	secrets, err := pullsecret.ManagedSecrets(ctx, r.Client, falconContainer)
+	if err != nil {
+		return err
+	}
+
+	for i := range secrets {
+		if selectedNamespaces[secrets[i].Namespace] {
+			continue
+		}
+
+		log.Info("Deleting Falcon Container registry pull secret from deselected namespace", "Namespace", secrets[i].Namespace)
+		if err := r.Client.Delete(ctx, &secrets[i]); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("unable to delete registry secret in namespace %s: %v", secrets[i].Namespace, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *FalconContainerReconciler) reconcileRegistrySecret(namespace string, pulltoken []byte, ctx context.Context, log logr.Logger, falconContainer *falconv1alpha1.FalconContainer) (*corev1.Secret, bool, error) {
 	secretData := map[string][]byte{corev1.DockerConfigJsonKey: common.CleanDecodedBase64(pulltoken)}
 	secret := assets.Secret(common.FalconPullSecretName, namespace, "falcon-operator", secretData, corev1.SecretTypeDockerConfigJson)
+	assets.ApplyResourceMetadata(secret, falconContainer.Spec.ResourceMetadata)
 	existingSecret := &corev1.Secret{}
 
 	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: common.FalconPullSecretName, Namespace: namespace}, existingSecret)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			if err := ctrl.SetControllerReference(falconContainer, secret, r.Scheme); err != nil {
-				return &corev1.Secret{}, fmt.Errorf("failed to set controller reference on registry pull token secret %s: %v", secret.ObjectMeta.Name, err)
+				return &corev1.Secret{}, false, fmt.Errorf("failed to set controller reference on registry pull token secret %s: %v", secret.ObjectMeta.Name, err)
 			}
 
-			return secret, r.Create(ctx, log, falconContainer, secret)
+			return secret, true, r.Create(ctx, log, falconContainer, secret)
 		}
 
-		return &corev1.Secret{}, fmt.Errorf("unable to query existing secret %s in namespace %s: %v", common.FalconPullSecretName, namespace, err)
+		return &corev1.Secret{}, false, fmt.Errorf("unable to query existing secret %s in namespace %s: %v", common.FalconPullSecretName, namespace, err)
 	}
 
+	metadataUpdated := assets.SyncResourceMetadata(existingSecret, secret, falconContainer.Spec.ResourceMetadata)
 	if reflect.DeepEqual(secret.Data, existingSecret.Data) {
-		return existingSecret, nil
+		if metadataUpdated {
+			return existingSecret, false, r.Update(ctx, log, falconContainer, existingSecret)
+		}
+
+		return existingSecret, false, nil
 	}
 
 	existingSecret.Data = secret.Data
 
-	return existingSecret, r.Update(ctx, log, falconContainer, existingSecret)
+	return existingSecret, true, r.Update(ctx, log, falconContainer, existingSecret)
+}
+
+// registryPullSecrets gives the pull secret refresher access to the registry pull secrets of the FalconContainer
+func (r *FalconContainerReconciler) registryPullSecrets() pullsecret.Accessors[*falconv1alpha1.FalconContainer] {
+	return pullsecret.Accessors[*falconv1alpha1.FalconContainer]{
+		Kind: "FalconContainer",
+		New:  func() *falconv1alpha1.FalconContainer { return &falconv1alpha1.FalconContainer{} },
+		ApiConfig: func(ctx context.Context, falconContainer *falconv1alpha1.FalconContainer) (*falcon.ApiConfig, error) {
+			falconContainer, err := k8sutils.ResolveFalconConfig(ctx, r, falconContainer)
+			if err != nil {
+				return nil, err
+			}
+
+			if falconContainer.Spec.FalconAPI == nil && !falconContainer.Spec.FalconSecret.Enabled {
+				return nil, nil
+			}
+			return r.falconApiConfig(ctx, falconContainer)
+		},
+		RefreshTime: func(falconContainer *falconv1alpha1.FalconContainer) **metav1.Time {
+			return &falconContainer.Status.RegistryTokenRefreshTime
+		},
+	}
+}
+
+// namespaceToFalconContainers enqueues every FalconContainer so that namespaces created or relabelled get their registry pull secret reconciled immediately
+func (r *FalconContainerReconciler) namespaceToFalconContainers(ctx context.Context, _ client.Object) []reconcile.Request {
+	falconContainers := &falconv1alpha1.FalconContainerList{}
+	if err := r.List(ctx, falconContainers); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list FalconContainers for namespace event")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(falconContainers.Items))
+	for _, falconContainer := range falconContainers.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: falconContainer.Name}})
+	}
+
+	return requests
+}
+
+// namespaceLabelsChanged filters Namespace events down to creations and label changes, the only events affecting pull secret distribution
+func namespaceLabelsChanged() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(event.CreateEvent) bool { return true },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !reflect.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+		},
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
 }
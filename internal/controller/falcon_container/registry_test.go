@@ -0,0 +1,52 @@
+package falcon
+
+import (
+	"testing"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/crowdstrike/falcon-operator/pkg/common"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func testNamespace(name string, nsLabels map[string]string) corev1.Namespace {
+	return corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nsLabels}}
+}
+
+func TestPullSecretNamespaceSelected_WithDefaults(t *testing.T) {
+	container := &falconv1alpha1.FalconContainer{}
+	container.Spec.InstallNamespace = "falcon-system"
+
+	assert.True(t, pullSecretNamespaceSelected(testNamespace("default", nil), labels.Everything(), container))
+	assert.True(t, pullSecretNamespaceSelected(testNamespace("falcon-system", nil), labels.Everything(), container))
+	assert.False(t, pullSecretNamespaceSelected(testNamespace("kube-system", nil), labels.Everything(), container))
+}
+
+func TestPullSecretNamespaceSelected_WithInjectionDisabled(t *testing.T) {
+	container := &falconv1alpha1.FalconContainer{}
+	container.Spec.InstallNamespace = "falcon-system"
+	disabled := map[string]string{common.FalconContainerInjection: "disabled"}
+
+	assert.False(t, pullSecretNamespaceSelected(testNamespace("default", disabled), labels.Everything(), container))
+	assert.True(t, pullSecretNamespaceSelected(testNamespace("falcon-system", disabled), labels.Everything(), container))
+}
+
+func TestPullSecretNamespaceSelected_WithDefaultNSInjectionDisabled(t *testing.T) {
+	container := &falconv1alpha1.FalconContainer{}
+	container.Spec.Injector.DisableDefaultNSInjection = true
+
+	assert.False(t, pullSecretNamespaceSelected(testNamespace("default", nil), labels.Everything(), container))
+	assert.True(t, pullSecretNamespaceSelected(testNamespace("default", map[string]string{common.FalconContainerInjection: "enabled"}), labels.Everything(), container))
+}
+
+func TestPullSecretNamespaceSelected_WithSelector(t *testing.T) {
+	container := &falconv1alpha1.FalconContainer{}
+	container.Spec.InstallNamespace = "falcon-system"
+	selector := labels.SelectorFromSet(labels.Set{"team": "payments"})
+
+	assert.True(t, pullSecretNamespaceSelected(testNamespace("payments", map[string]string{"team": "payments"}), selector, container))
+	assert.False(t, pullSecretNamespaceSelected(testNamespace("default", nil), selector, container))
+	assert.True(t, pullSecretNamespaceSelected(testNamespace("falcon-system", nil), selector, container))
+}
@@ -18,6 +18,7 @@ import (
 func (r *FalconContainerReconciler) reconcileService(ctx context.Context, log logr.Logger, falconContainer *falconv1alpha1.FalconContainer) (*corev1.Service, error) {
 	selector := map[string]string{common.FalconComponentKey: common.FalconSidecarSensor}
 	service := assets.Service(injectorName, falconContainer.Spec.InstallNamespace, common.FalconSidecarSensor, selector, common.FalconServiceHTTPSName, *falconContainer.Spec.Injector.ListenPort)
+	assets.ApplyResourceMetadata(service, falconContainer.Spec.ResourceMetadata)
 	updated := false
 	existingService := &corev1.Service{}
 
@@ -34,6 +35,10 @@ func (r *FalconContainerReconciler) reconcileService(ctx context.Context, log lo
 		return &corev1.Service{}, fmt.Errorf("unable to query existing service %s: %v", injectorName, err)
 	}
 
+	if assets.SyncResourceMetadata(existingService, service, falconContainer.Spec.ResourceMetadata) {
+		updated = true
+	}
+
 	if !reflect.DeepEqual(service.Spec.Selector, existingService.Spec.Selector) {
 		existingService.Spec.Selector = service.Spec.Selector
 		updated = true
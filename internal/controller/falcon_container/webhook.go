@@ -27,6 +27,7 @@ func (r *FalconContainerReconciler) reconcileWebhook(ctx context.Context, log lo
 	}
 
 	webhook := assets.MutatingWebhook(injectorName, falconContainer.Spec.InstallNamespace, webhookName, caBundle, disableDefaultNSInjection, falconContainer)
+	assets.ApplyResourceMetadata(webhook, falconContainer.Spec.ResourceMetadata)
 	existingWebhook := &arv1.MutatingWebhookConfiguration{}
 
 	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: webhookName}, existingWebhook)
@@ -42,7 +43,8 @@ func (r *FalconContainerReconciler) reconcileWebhook(ctx context.Context, log lo
 		return &arv1.MutatingWebhookConfiguration{}, fmt.Errorf("unable to query existing mutating webhook configuration %s: %v", webhookName, err)
 	}
 
-	if !reflect.DeepEqual(webhook.Webhooks[0], existingWebhook.Webhooks[0]) {
+	metadataUpdated := assets.SyncResourceMetadata(existingWebhook, webhook, falconContainer.Spec.ResourceMetadata)
+	if metadataUpdated || !reflect.DeepEqual(webhook.Webhooks[0], existingWebhook.Webhooks[0]) {
 		existingWebhook.Webhooks[0] = webhook.Webhooks[0]
 
 		return webhook, r.Update(ctx, log, falconContainer, existingWebhook)
@@ -0,0 +1,228 @@
+package falcon
+
+import (
+	"context"
+	"fmt"
+
+	"dario.cat/mergo"
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// adoption is a standalone custom resource about to be adopted, along with the change merging its spec into the FalconDeployment
+type adoption struct {
+	kind  string
+	obj   client.Object
+	merge func(spec *falconv1alpha1.FalconDeploymentSpec) error
+}
+
+// adoptChildren takes controller ownership of the standalone Falcon custom resources matching the components of the
+// FalconDeployment. The spec of each adopted resource is merged into the FalconDeployment first, so that the following
+// reconciliation leaves the running sensors untouched. It reports whether any resource was adopted.
+func (r *FalconDeploymentReconciler) adoptChildren(ctx context.Context, req ctrl.Request, log logr.Logger, falconDeployment *falconv1alpha1.FalconDeployment) (bool, error) {
+	if !falconDeployment.GetAdoptExistingResources() {
+		return false, nil
+	}
+
+	adoptions, err := r.findAdoptions(ctx, falconDeployment)
+	if err != nil || len(adoptions) == 0 {
+		return false, err
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := r.Get(ctx, req.NamespacedName, falconDeployment)
+		if err != nil {
+			return err
+		}
+
+		for _, a := range adoptions {
+			if err := a.merge(&falconDeployment.Spec); err != nil {
+				return fmt.Errorf("unable to merge the spec of %s %s: %v", a.kind, a.obj.GetName(), err)
+			}
+		}
+
+		return r.Update(ctx, falconDeployment)
+	})
+	if err != nil {
+		log.Error(err, "Failed to merge adopted resources into FalconDeployment")
+		return false, err
+	}
+
+	adopted := []falconv1alpha1.FalconDeploymentAdoptedResource{}
+	for _, a := range adoptions {
+		if err := ctrl.SetControllerReference(falconDeployment, a.obj, r.Scheme); err != nil {
+			return false, fmt.Errorf("unable to set controller reference for %s: %v", a.obj.GetName(), err)
+		}
+
+		if err := r.Update(ctx, a.obj); err != nil {
+			return false, fmt.Errorf("unable to adopt %s %s: %v", a.kind, a.obj.GetName(), err)
+		}
+
+		log.Info(fmt.Sprintf("Adopted %s %s", a.kind, a.obj.GetName()))
+		adopted = append(adopted, falconv1alpha1.FalconDeploymentAdoptedResource{
+			Kind:         a.kind,
+			Name:         a.obj.GetName(),
+			AdoptionTime: metav1.Now(),
+		})
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := r.Get(ctx, req.NamespacedName, falconDeployment)
+		if err != nil {
+			return err
+		}
+
+		falconDeployment.Status.AdoptedResources = append(falconDeployment.Status.AdoptedResources, adopted...)
+		return r.Status().Update(ctx, falconDeployment)
+	})
+	if err != nil {
+		log.Error(err, "Failed to update FalconDeployment status for FalconDeployment.Status.AdoptedResources")
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *FalconDeploymentReconciler) findAdoptions(ctx context.Context, falconDeployment *falconv1alpha1.FalconDeployment) ([]adoption, error) {
+	adoptions := []adoption{}
+
+	if *falconDeployment.Spec.DeployAdmissionController {
+		var admissionList falconv1alpha1.FalconAdmissionList
+		if err := r.Client.List(ctx, &admissionList); err != nil {
+			return nil, fmt.Errorf("unable to get FalconAdmissionList: %s", err)
+		}
+
+		existing := make([]client.Object, 0, len(admissionList.Items))
+		for i := range admissionList.Items {
+			existing = append(existing, &admissionList.Items[i])
+		}
+
+		for _, obj := range adoptionCandidates(existing, []string{falconDeployment.GetFalconAdmissionName()}) {
+			admission := obj.(*falconv1alpha1.FalconAdmission)
+			adoptions = append(adoptions, adoption{kind: "FalconAdmission", obj: admission, merge: func(spec *falconv1alpha1.FalconDeploymentSpec) error {
+				spec.FalconAdmissionName = admission.Name
+				return mergo.Merge(&spec.FalconAdmission, admission.Spec, mergo.WithOverride)
+			}})
+		}
+	}
+
+	if *falconDeployment.Spec.DeployNodeSensor {
+		var nodeSensorList falconv1alpha1.FalconNodeSensorList
+		if err := r.Client.List(ctx, &nodeSensorList); err != nil {
+			return nil, fmt.Errorf("unable to get FalconNodeSensorList: %s", err)
+		}
+
+		existing := make([]client.Object, 0, len(nodeSensorList.Items))
+		for i := range nodeSensorList.Items {
+			existing = append(existing, &nodeSensorList.Items[i])
+		}
+
+		names := []string{}
+		for _, nodeSensor := range falconDeployment.GetFalconNodeSensors() {
+			names = append(names, nodeSensor.Name)
+		}
+
+		for name, obj := range adoptionCandidates(existing, names) {
+			nodeSensor := obj.(*falconv1alpha1.FalconNodeSensor)
+			adoptions = append(adoptions, adoption{kind: "FalconNodeSensor", obj: nodeSensor, merge: func(spec *falconv1alpha1.FalconDeploymentSpec) error {
+				if len(spec.FalconNodeSensors) == 0 {
+					spec.FalconNodeSensorName = nodeSensor.Name
+					return mergo.Merge(&spec.FalconNodeSensor, nodeSensor.Spec, mergo.WithOverride)
+				}
+
+				for i := range spec.FalconNodeSensors {
+					if spec.FalconNodeSensors[i].Name == name {
+						spec.FalconNodeSensors[i].Name = nodeSensor.Name
+						return mergo.Merge(&spec.FalconNodeSensors[i].Spec, nodeSensor.Spec, mergo.WithOverride)
+					}
+				}
+
+				return nil
+			}})
+		}
+	}
+
+	if *falconDeployment.Spec.DeployImageAnalyzer {
+		var imageAnalyzerList falconv1alpha1.FalconImageAnalyzerList
+		if err := r.Client.List(ctx, &imageAnalyzerList); err != nil {
+			return nil, fmt.Errorf("unable to get FalconImageAnalyzerList: %s", err)
+		}
+
+		existing := make([]client.Object, 0, len(imageAnalyzerList.Items))
+		for i := range imageAnalyzerList.Items {
+			existing = append(existing, &imageAnalyzerList.Items[i])
+		}
+
+		for _, obj := range adoptionCandidates(existing, []string{falconDeployment.GetFalconImageAnalyzerName()}) {
+			imageAnalyzer := obj.(*falconv1alpha1.FalconImageAnalyzer)
+			adoptions = append(adoptions, adoption{kind: "FalconImageAnalyzer", obj: imageAnalyzer, merge: func(spec *falconv1alpha1.FalconDeploymentSpec) error {
+				spec.FalconImageAnalyzerName = imageAnalyzer.Name
+				return mergo.Merge(&spec.FalconImageAnalyzer, imageAnalyzer.Spec, mergo.WithOverride)
+			}})
+		}
+	}
+
+	if *falconDeployment.Spec.DeployContainerSensor {
+		var containerSensorList falconv1alpha1.FalconContainerList
+		if err := r.Client.List(ctx, &containerSensorList); err != nil {
+			return nil, fmt.Errorf("unable to get FalconContainerList: %s", err)
+		}
+
+		existing := make([]client.Object, 0, len(containerSensorList.Items))
+		for i := range containerSensorList.Items {
+			existing = append(existing, &containerSensorList.Items[i])
+		}
+
+		for _, obj := range adoptionCandidates(existing, []string{falconDeployment.GetFalconContainerSensorName()}) {
+			containerSensor := obj.(*falconv1alpha1.FalconContainer)
+			adoptions = append(adoptions, adoption{kind: "FalconContainer", obj: containerSensor, merge: func(spec *falconv1alpha1.FalconDeploymentSpec) error {
+				spec.FalconContainerSensorName = containerSensor.Name
+				return mergo.Merge(&spec.FalconContainerSensor, containerSensor.Spec, mergo.WithOverride)
+			}})
+		}
+	}
+
+	return adoptions, nil
+}
+
+// adoptionCandidates matches standalone custom resources, which have no controller and are not being deleted, to the names
+// of the resources the FalconDeployment deploys. Resources are matched by name. When the FalconDeployment deploys a single
+// resource of the kind and no name matches, the only standalone resource is matched regardless of its name.
+func adoptionCandidates(existing []client.Object, names []string) map[string]client.Object {
+	standalone := []client.Object{}
+	for _, obj := range existing {
+		if metav1.GetControllerOf(obj) == nil && obj.GetDeletionTimestamp() == nil {
+			standalone = append(standalone, obj)
+		}
+	}
+
+	candidates := map[string]client.Object{}
+	for _, name := range names {
+		for _, obj := range standalone {
+			if obj.GetName() == name {
+				candidates[name] = obj
+			}
+		}
+	}
+
+	if len(names) == 1 && len(candidates) == 0 && len(standalone) == 1 && !controlledNameTaken(existing, names[0]) {
+		candidates[names[0]] = standalone[0]
+	}
+
+	return candidates
+}
+
+// controlledNameTaken reports whether a resource with the name already has a controller, in which case nothing is adopted in its place
+func controlledNameTaken(existing []client.Object, name string) bool {
+	for _, obj := range existing {
+		if obj.GetName() == name && metav1.GetControllerOf(obj) != nil {
+			return true
+		}
+	}
+
+	return false
+}
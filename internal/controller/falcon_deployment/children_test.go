@@ -0,0 +1,166 @@
+package falcon
+
+import (
+	"context"
+	"testing"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func childrenReconciler(t *testing.T, objs ...runtime.Object) *FalconDeploymentReconciler {
+	scheme := runtime.NewScheme()
+	require.NoError(t, falconv1alpha1.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&falconv1alpha1.FalconDeployment{}).WithRuntimeObjects(objs...).Build()
+	return &FalconDeploymentReconciler{Client: c, Reader: c, Scheme: scheme}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func childrenDeployment() *falconv1alpha1.FalconDeployment {
+	return &falconv1alpha1.FalconDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "falcon-deployment", UID: "falcon-deployment-uid"},
+		Spec: falconv1alpha1.FalconDeploymentSpec{
+			DeployAdmissionController: boolPtr(false),
+			DeployNodeSensor:          boolPtr(true),
+			DeployImageAnalyzer:       boolPtr(false),
+			DeployContainerSensor:     boolPtr(false),
+		},
+	}
+}
+
+func TestReconcileNodeSensor_MultipleEntries(t *testing.T) {
+	falconDeployment := childrenDeployment()
+	falconDeployment.Spec.FalconNodeSensors = []falconv1alpha1.FalconDeploymentNodeSensor{
+		{Name: "pool-a", Spec: falconv1alpha1.FalconNodeSensorSpec{InstallNamespace: "falcon-system"}},
+		{Name: "pool-b", Spec: falconv1alpha1.FalconNodeSensorSpec{InstallNamespace: "falcon-system"}},
+	}
+	r := childrenReconciler(t, falconDeployment)
+
+	require.NoError(t, r.reconcileNodeSensor(context.Background(), logr.Discard(), falconDeployment))
+
+	for _, name := range []string{"pool-a", "pool-b"} {
+		nodeSensor := &falconv1alpha1.FalconNodeSensor{}
+		require.NoError(t, r.Get(context.Background(), types.NamespacedName{Name: name}, nodeSensor))
+		assert.True(t, metav1.IsControlledBy(nodeSensor, falconDeployment))
+	}
+
+	err := r.Get(context.Background(), types.NamespacedName{Name: falconv1alpha1.FalconDeploymentNodeSensorNameDefault}, &falconv1alpha1.FalconNodeSensor{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestReconcileNodeSensor_RenameKeepsForeignResources(t *testing.T) {
+	falconDeployment := childrenDeployment()
+	falconDeployment.Spec.FalconNodeSensorName = "renamed"
+
+	owned := &falconv1alpha1.FalconNodeSensor{ObjectMeta: metav1.ObjectMeta{Name: falconv1alpha1.FalconDeploymentNodeSensorNameDefault}}
+	foreign := &falconv1alpha1.FalconNodeSensor{ObjectMeta: metav1.ObjectMeta{Name: "standalone"}}
+	r := childrenReconciler(t, falconDeployment, foreign)
+	require.NoError(t, ctrl.SetControllerReference(falconDeployment, owned, r.Scheme))
+	require.NoError(t, r.Create(context.Background(), owned))
+
+	require.NoError(t, r.reconcileNodeSensor(context.Background(), logr.Discard(), falconDeployment))
+
+	err := r.Get(context.Background(), types.NamespacedName{Name: owned.Name}, &falconv1alpha1.FalconNodeSensor{})
+	assert.True(t, apierrors.IsNotFound(err))
+	assert.NoError(t, r.Get(context.Background(), types.NamespacedName{Name: "renamed"}, &falconv1alpha1.FalconNodeSensor{}))
+	assert.NoError(t, r.Get(context.Background(), types.NamespacedName{Name: "standalone"}, &falconv1alpha1.FalconNodeSensor{}))
+}
+
+func TestReconcileNodeSensor_Disabled(t *testing.T) {
+	falconDeployment := childrenDeployment()
+	falconDeployment.Spec.DeployNodeSensor = boolPtr(false)
+
+	owned := &falconv1alpha1.FalconNodeSensor{ObjectMeta: metav1.ObjectMeta{Name: falconv1alpha1.FalconDeploymentNodeSensorNameDefault}}
+	r := childrenReconciler(t, falconDeployment)
+	require.NoError(t, ctrl.SetControllerReference(falconDeployment, owned, r.Scheme))
+	require.NoError(t, r.Create(context.Background(), owned))
+
+	require.NoError(t, r.reconcileNodeSensor(context.Background(), logr.Discard(), falconDeployment))
+
+	err := r.Get(context.Background(), types.NamespacedName{Name: owned.Name}, &falconv1alpha1.FalconNodeSensor{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestAdoptChildren_MergesSpecAndTakesOwnership(t *testing.T) {
+	falconDeployment := childrenDeployment()
+	falconDeployment.Spec.AdoptExistingResources = boolPtr(true)
+	falconDeployment.Spec.FalconNodeSensor.InstallNamespace = "falcon-system"
+
+	standalone := &falconv1alpha1.FalconNodeSensor{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-node-sensor"},
+		Spec: falconv1alpha1.FalconNodeSensorSpec{
+			InstallNamespace: "custom-falcon",
+			Node:             falconv1alpha1.FalconNodeSensorConfig{Backend: "bpf"},
+		},
+	}
+	r := childrenReconciler(t, falconDeployment, standalone)
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: falconDeployment.Name}}
+
+	adopted, err := r.adoptChildren(context.Background(), req, logr.Discard(), falconDeployment)
+	require.NoError(t, err)
+	assert.True(t, adopted)
+
+	assert.Equal(t, "my-node-sensor", falconDeployment.Spec.FalconNodeSensorName)
+	assert.Equal(t, "custom-falcon", falconDeployment.Spec.FalconNodeSensor.InstallNamespace)
+	assert.Equal(t, "bpf", falconDeployment.Spec.FalconNodeSensor.Node.Backend)
+	require.Len(t, falconDeployment.Status.AdoptedResources, 1)
+	assert.Equal(t, "FalconNodeSensor", falconDeployment.Status.AdoptedResources[0].Kind)
+	assert.Equal(t, "my-node-sensor", falconDeployment.Status.AdoptedResources[0].Name)
+
+	nodeSensor := &falconv1alpha1.FalconNodeSensor{}
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{Name: "my-node-sensor"}, nodeSensor))
+	assert.True(t, metav1.IsControlledBy(nodeSensor, falconDeployment))
+
+	// Once adopted, the resource is left alone by later adoptions and kept by the regular reconciliation
+	adopted, err = r.adoptChildren(context.Background(), req, logr.Discard(), falconDeployment)
+	require.NoError(t, err)
+	assert.False(t, adopted)
+
+	require.NoError(t, r.reconcileNodeSensor(context.Background(), logr.Discard(), falconDeployment))
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{Name: "my-node-sensor"}, nodeSensor))
+	assert.Equal(t, "custom-falcon", nodeSensor.Spec.InstallNamespace)
+}
+
+func TestAdoptChildren_Disabled(t *testing.T) {
+	falconDeployment := childrenDeployment()
+	standalone := &falconv1alpha1.FalconNodeSensor{ObjectMeta: metav1.ObjectMeta{Name: falconv1alpha1.FalconDeploymentNodeSensorNameDefault}}
+	r := childrenReconciler(t, falconDeployment, standalone)
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: falconDeployment.Name}}
+
+	adopted, err := r.adoptChildren(context.Background(), req, logr.Discard(), falconDeployment)
+	require.NoError(t, err)
+	assert.False(t, adopted)
+}
+
+func TestAdoptionCandidates(t *testing.T) {
+	owner := childrenDeployment()
+	controlled := &falconv1alpha1.FalconNodeSensor{ObjectMeta: metav1.ObjectMeta{Name: "pool-a"}}
+	require.NoError(t, ctrl.SetControllerReference(owner, controlled, childrenReconciler(t).Scheme))
+	first := &falconv1alpha1.FalconNodeSensor{ObjectMeta: metav1.ObjectMeta{Name: "first"}}
+	second := &falconv1alpha1.FalconNodeSensor{ObjectMeta: metav1.ObjectMeta{Name: "pool-b"}}
+
+	candidates := adoptionCandidates([]client.Object{controlled, first, second}, []string{"pool-a", "pool-b"})
+	assert.Equal(t, map[string]client.Object{"pool-b": second}, candidates)
+
+	candidates = adoptionCandidates([]client.Object{first}, []string{"falcon-node-sensor"})
+	assert.Equal(t, map[string]client.Object{"falcon-node-sensor": first}, candidates)
+
+	candidates = adoptionCandidates([]client.Object{first, second}, []string{"falcon-node-sensor"})
+	assert.Empty(t, candidates)
+
+	candidates = adoptionCandidates([]client.Object{controlled, first}, []string{"pool-a"})
+	assert.Empty(t, candidates)
+}
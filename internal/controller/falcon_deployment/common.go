@@ -0,0 +1,165 @@
+package falcon
+
+import (
+	"reflect"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// The apply*Common functions merge the settings shared by every component into the spec of a managed custom resource.
+// Settings configured on the component take precedence: shared values only fill unset fields, and shared list entries
+// are appended to the ones of the component.
+
+func applyAdmissionCommon(common falconv1alpha1.FalconDeploymentCommonSpec, spec *falconv1alpha1.FalconAdmissionSpec) {
+	applyProxyCommon(common.Proxy, &spec.Falcon)
+
+	spec.AdmissionConfig.Tolerations = appendMissing(spec.AdmissionConfig.Tolerations, common.Tolerations)
+	spec.AdmissionConfig.ImagePullSecrets = appendMissing(spec.AdmissionConfig.ImagePullSecrets, common.ImagePullSecrets)
+
+	if spec.AdmissionConfig.NodeAffinity == nil {
+		spec.AdmissionConfig.NodeAffinity = common.NodeAffinity
+	}
+
+	if spec.ClusterName == nil && common.ClusterName != "" {
+		clusterName := common.ClusterName
+		spec.ClusterName = &clusterName
+	}
+
+	spec.CommonLabels = mergeMissing(spec.CommonLabels, common.Labels)
+}
+
+func applyNodeSensorCommon(common falconv1alpha1.FalconDeploymentCommonSpec, spec *falconv1alpha1.FalconNodeSensorSpec) {
+	applyProxyCommon(common.Proxy, &spec.Falcon.FalconSensor)
+
+	if len(common.Tolerations) > 0 {
+		tolerations := []corev1.Toleration{}
+		if spec.Node.Tolerations != nil {
+			tolerations = *spec.Node.Tolerations
+		}
+		tolerations = appendMissing(tolerations, common.Tolerations)
+		spec.Node.Tolerations = &tolerations
+	}
+
+	spec.Node.ImagePullSecrets = appendMissing(spec.Node.ImagePullSecrets, common.ImagePullSecrets)
+
+	if spec.Node.ClusterName == "" {
+		spec.Node.ClusterName = common.ClusterName
+	}
+
+	if common.NodeAffinity != nil && reflect.DeepEqual(spec.Node.NodeAffinity, corev1.NodeAffinity{}) {
+		spec.Node.NodeAffinity = *common.NodeAffinity
+	}
+
+	// Without a name, a deployed priority class is named after the FalconNodeSensor
+	deployPriorityClass := spec.Node.PriorityClass.Deploy != nil && *spec.Node.PriorityClass.Deploy
+	if spec.Node.PriorityClass.Name == "" && !deployPriorityClass {
+		spec.Node.PriorityClass.Name = common.PriorityClassName
+	}
+
+	spec.CommonLabels = mergeMissing(spec.CommonLabels, common.Labels)
+}
+
+func applyImageAnalyzerCommon(common falconv1alpha1.FalconDeploymentCommonSpec, spec *falconv1alpha1.FalconImageAnalyzerSpec) {
+	spec.Tolerations = appendMissing(spec.Tolerations, common.Tolerations)
+	spec.ImageAnalyzerConfig.ImagePullSecrets = appendMissing(spec.ImageAnalyzerConfig.ImagePullSecrets, common.ImagePullSecrets)
+
+	if spec.NodeAffinity == nil {
+		spec.NodeAffinity = common.NodeAffinity
+	}
+
+	if spec.ImageAnalyzerConfig.PriorityClass.Name == "" {
+		spec.ImageAnalyzerConfig.PriorityClass.Name = common.PriorityClassName
+	}
+
+	if spec.ImageAnalyzerConfig.ClusterName == "" {
+		spec.ImageAnalyzerConfig.ClusterName = common.ClusterName
+	}
+
+	spec.CommonLabels = mergeMissing(spec.CommonLabels, common.Labels)
+}
+
+func applyContainerSensorCommon(common falconv1alpha1.FalconDeploymentCommonSpec, spec *falconv1alpha1.FalconContainerSpec) {
+	applyProxyCommon(common.Proxy, &spec.Falcon)
+
+	spec.Tolerations = appendMissing(spec.Tolerations, common.Tolerations)
+
+	if spec.NodeAffinity == nil {
+		spec.NodeAffinity = common.NodeAffinity
+	}
+
+	spec.CommonLabels = mergeMissing(spec.CommonLabels, common.Labels)
+}
+
+func applyProxyCommon(proxy falconv1alpha1.FalconProxy, falcon *falconv1alpha1.FalconSensor) {
+	if falcon.APH == "" {
+		falcon.APH = proxy.Host
+	}
+
+	if falcon.APP == nil {
+		falcon.APP = proxy.Port
+	}
+}
+
+// applyLabelsCommon adds the shared labels to the metadata of a managed custom resource
+func applyLabelsCommon(common falconv1alpha1.FalconDeploymentCommonSpec, obj client.Object) {
+	if len(common.Labels) == 0 {
+		return
+	}
+
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	for k, v := range common.Labels {
+		if _, ok := labels[k]; !ok {
+			labels[k] = v
+		}
+	}
+
+	obj.SetLabels(labels)
+}
+
+// mergeMissing returns a copy of items with the shared entries whose keys are not already present added
+func mergeMissing(items map[string]string, shared map[string]string) map[string]string {
+	if len(shared) == 0 {
+		return items
+	}
+
+	merged := make(map[string]string, len(items)+len(shared))
+	for k, v := range shared {
+		merged[k] = v
+	}
+
+	for k, v := range items {
+		merged[k] = v
+	}
+
+	return merged
+}
+
+// appendMissing returns a copy of items with the shared entries not already present appended
+func appendMissing[T any](items []T, shared []T) []T {
+	if len(shared) == 0 {
+		return items
+	}
+
+	merged := append([]T{}, items...)
+	for _, s := range shared {
+		found := false
+		for _, item := range items {
+			if reflect.DeepEqual(item, s) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			merged = append(merged, s)
+		}
+	}
+
+	return merged
+}
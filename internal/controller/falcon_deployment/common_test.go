@@ -0,0 +1,104 @@
+package falcon
+
+import (
+	"context"
+	"testing"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func commonSettings() falconv1alpha1.FalconDeploymentCommonSpec {
+	port := 8080
+	return falconv1alpha1.FalconDeploymentCommonSpec{
+		Proxy:             falconv1alpha1.FalconProxy{Host: "proxy.example.com", Port: &port},
+		Tolerations:       []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}},
+		PriorityClassName: "falcon-priority",
+		ImagePullSecrets:  []corev1.LocalObjectReference{{Name: "shared-pull-secret"}},
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "kubernetes.io/os", Operator: corev1.NodeSelectorOpIn, Values: []string{"linux"}}}}},
+			},
+		},
+		ClusterName: "shared-cluster",
+		Labels:      map[string]string{"cost-center": "security"},
+	}
+}
+
+func TestApplyAdmissionCommon(t *testing.T) {
+	common := commonSettings()
+	clusterName := "admission-cluster"
+	spec := falconv1alpha1.FalconAdmissionSpec{ClusterName: &clusterName}
+	spec.AdmissionConfig.ImagePullSecrets = []corev1.LocalObjectReference{{Name: "admission-pull-secret"}, {Name: "shared-pull-secret"}}
+
+	applyAdmissionCommon(common, &spec)
+
+	assert.Equal(t, "proxy.example.com", spec.Falcon.APH)
+	assert.Equal(t, 8080, *spec.Falcon.APP)
+	assert.Equal(t, "admission-cluster", *spec.ClusterName)
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "admission-pull-secret"}, {Name: "shared-pull-secret"}}, spec.AdmissionConfig.ImagePullSecrets)
+	assert.Equal(t, common.Tolerations, spec.AdmissionConfig.Tolerations)
+	assert.Equal(t, common.NodeAffinity, spec.AdmissionConfig.NodeAffinity)
+	assert.Equal(t, common.Labels, spec.CommonLabels)
+}
+
+func TestApplyNodeSensorCommon(t *testing.T) {
+	common := commonSettings()
+	tolerations := []corev1.Toleration{{Key: "node-role.kubernetes.io/control-plane", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}}
+	spec := falconv1alpha1.FalconNodeSensorSpec{}
+	spec.Falcon.APH = "node-proxy.example.com"
+	spec.Node.Tolerations = &tolerations
+
+	applyNodeSensorCommon(common, &spec)
+
+	assert.Equal(t, "node-proxy.example.com", spec.Falcon.APH)
+	assert.Equal(t, 8080, *spec.Falcon.APP)
+	assert.Equal(t, append(tolerations, common.Tolerations...), *spec.Node.Tolerations)
+	assert.Len(t, tolerations, 1, "the tolerations of the component must not be modified in place")
+	assert.Equal(t, *common.NodeAffinity, spec.Node.NodeAffinity)
+	assert.Equal(t, "falcon-priority", spec.Node.PriorityClass.Name)
+	assert.Equal(t, "shared-cluster", spec.Node.ClusterName)
+
+	deployed := falconv1alpha1.FalconNodeSensorSpec{}
+	deployed.Node.PriorityClass.Deploy = boolPtr(true)
+	applyNodeSensorCommon(common, &deployed)
+	assert.Empty(t, deployed.Node.PriorityClass.Name)
+}
+
+func TestApplyImageAnalyzerAndContainerSensorCommon(t *testing.T) {
+	common := commonSettings()
+
+	imageAnalyzer := falconv1alpha1.FalconImageAnalyzerSpec{}
+	imageAnalyzer.ImageAnalyzerConfig.PriorityClass.Name = "iar-priority"
+	applyImageAnalyzerCommon(common, &imageAnalyzer)
+	assert.Equal(t, "iar-priority", imageAnalyzer.ImageAnalyzerConfig.PriorityClass.Name)
+	assert.Equal(t, "shared-cluster", imageAnalyzer.ImageAnalyzerConfig.ClusterName)
+	assert.Equal(t, common.Tolerations, imageAnalyzer.Tolerations)
+	assert.Equal(t, common.ImagePullSecrets, imageAnalyzer.ImageAnalyzerConfig.ImagePullSecrets)
+
+	containerSensor := falconv1alpha1.FalconContainerSpec{NodeAffinity: &corev1.NodeAffinity{}}
+	containerSensor.CommonLabels = map[string]string{"cost-center": "containers", "team": "platform"}
+	applyContainerSensorCommon(common, &containerSensor)
+	assert.Equal(t, map[string]string{"cost-center": "containers", "team": "platform"}, containerSensor.CommonLabels)
+	assert.Equal(t, "proxy.example.com", containerSensor.Falcon.APH)
+	assert.Equal(t, &corev1.NodeAffinity{}, containerSensor.NodeAffinity)
+	assert.Equal(t, common.Tolerations, containerSensor.Tolerations)
+}
+
+func TestReconcileNodeSensor_CommonLabels(t *testing.T) {
+	falconDeployment := childrenDeployment()
+	r := childrenReconciler(t, falconDeployment)
+
+	require.NoError(t, r.reconcileNodeSensor(context.Background(), logr.Discard(), falconDeployment))
+
+	falconDeployment.Spec.Common.Labels = map[string]string{"cost-center": "security"}
+	require.NoError(t, r.reconcileNodeSensor(context.Background(), logr.Discard(), falconDeployment))
+
+	nodeSensor := &falconv1alpha1.FalconNodeSensor{}
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{Name: falconv1alpha1.FalconDeploymentNodeSensorNameDefault}, nodeSensor))
+	assert.Equal(t, "security", nodeSensor.Labels["cost-center"])
+}
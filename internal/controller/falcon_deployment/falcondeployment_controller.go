@@ -4,13 +4,11 @@ import (
 	"context"
 	"fmt"
 	"reflect"
-	"strings"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -104,6 +102,15 @@ func (r *FalconDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		}
 	}
 
+	// Adopt standalone resources before resolving the cloud region, which must not be persisted in the spec
+	adopted, err := r.adoptChildren(ctx, req, log, falconDeployment)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if adopted {
+		return ctrl.Result{Requeue: true}, nil
+	}
+
 	if falconDeployment.Spec.FalconAPI != nil {
 		cloud, err := falconDeployment.Spec.FalconAPI.FalconCloudWithSecret(ctx, r.Reader, falconDeployment.Spec.FalconSecret)
 		if err != nil {
@@ -130,10 +137,7 @@ func (r *FalconDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, err
 	}
 
-	err = r.statusUpdate(ctx, req, log, falconDeployment, falconv1alpha1.ConditionSuccess,
-		metav1.ConditionTrue,
-		falconv1alpha1.ReasonInstallSucceeded,
-		"FalconDeployment installation completed")
+	err = r.reconcileStatus(ctx, req, log, falconDeployment)
 
 	return ctrl.Result{}, err
 }
@@ -151,266 +155,251 @@ func (r *FalconDeploymentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 func (r *FalconDeploymentReconciler) reconcileAdmissionController(ctx context.Context, log logr.Logger, falconDeployment *falconv1alpha1.FalconDeployment) error {
 	var admissionList falconv1alpha1.FalconAdmissionList
-	existingFalconAdmission := &falconv1alpha1.FalconAdmission{}
-	updated := false
+	desired := []client.Object{}
 
 	if err := r.Client.List(ctx, &admissionList); err != nil {
 		return fmt.Errorf("unable to get FalconAdmissionList: %s", err)
 	}
 
-	if len(admissionList.Items) != 0 {
-		existingFalconAdmission.ObjectMeta = metav1.ObjectMeta{
-			Name:      "falcon-kac",
-			Namespace: admissionList.Items[0].GetNamespace(),
-		}
-	}
-
 	if *falconDeployment.Spec.DeployAdmissionController {
 		newFalconAdmission := &falconv1alpha1.FalconAdmission{}
 		newFalconAdmission.Spec.FalconAPI = falconDeployment.Spec.FalconAPI
+		newFalconAdmission.Spec.FalconConfigRef = falconDeployment.Spec.FalconConfigRef
 		newFalconAdmission.Spec.Registry = falconDeployment.Spec.Registry
 		newFalconAdmission.Spec.FalconSecret = falconDeployment.Spec.FalconSecret
 		newFalconAdmission.ObjectMeta = metav1.ObjectMeta{
-			Name:      "falcon-kac",
-			Namespace: falconDeployment.Spec.FalconAdmission.InstallNamespace,
+			Name: falconDeployment.GetFalconAdmissionName(),
 		}
 
 		if err := mergo.Merge(&newFalconAdmission.Spec, falconDeployment.Spec.FalconAdmission, mergo.WithOverride); err != nil {
 			return fmt.Errorf("unable to merge specs for FalconAdmission: %v", err)
 		}
+		applyAdmissionCommon(falconDeployment.Spec.Common, &newFalconAdmission.Spec)
+		applyLabelsCommon(falconDeployment.Spec.Common, newFalconAdmission)
 
-		if len(admissionList.Items) == 0 {
-			if err := ctrl.SetControllerReference(falconDeployment, newFalconAdmission, r.Scheme); err != nil {
-				return fmt.Errorf("unable to set controller reference for %s: %v", newFalconAdmission.Name, err)
-			}
-			return r.create(ctx, log, falconDeployment, newFalconAdmission)
-		}
-
-		err := r.Client.Get(ctx, types.NamespacedName{Name: existingFalconAdmission.Name, Namespace: existingFalconAdmission.Namespace}, existingFalconAdmission)
-
-		if err != nil {
-			log.Error(err, "Failed to get FalconAdmission resource")
-			return err
-		}
+		desired = append(desired, newFalconAdmission)
+	}
 
-		if !reflect.DeepEqual(newFalconAdmission.Spec, existingFalconAdmission.Spec) {
-			existingFalconAdmission.Spec = newFalconAdmission.Spec
-			updated = true
-		}
+	existing := make([]client.Object, 0, len(admissionList.Items))
+	for i := range admissionList.Items {
+		existing = append(existing, &admissionList.Items[i])
+	}
 
-		if updated {
-			if err := r.update(ctx, log, falconDeployment, existingFalconAdmission); err != nil {
-				return err
-			}
+	return r.reconcileChildren(ctx, log, falconDeployment, existing, desired, func(existing client.Object, desired client.Object) bool {
+		existingFalconAdmission, newFalconAdmission := existing.(*falconv1alpha1.FalconAdmission), desired.(*falconv1alpha1.FalconAdmission)
+		if reflect.DeepEqual(newFalconAdmission.Spec, existingFalconAdmission.Spec) {
+			return false
 		}
-	} else if len(admissionList.Items) != 0 {
-		err := r.Client.Get(ctx, types.NamespacedName{Name: existingFalconAdmission.Name, Namespace: existingFalconAdmission.Namespace}, existingFalconAdmission)
-		if err != nil {
-			log.Error(err, "Failed to get FalconAdmission resource")
-			return err
-		}
-		return r.delete(ctx, log, falconDeployment, existingFalconAdmission)
-	}
 
-	return nil
+		existingFalconAdmission.Spec = newFalconAdmission.Spec
+		return true
+	})
 }
 
 func (r *FalconDeploymentReconciler) reconcileNodeSensor(ctx context.Context, log logr.Logger, falconDeployment *falconv1alpha1.FalconDeployment) error {
 	var nodeSensorList falconv1alpha1.FalconNodeSensorList
-	existingNodeSensor := &falconv1alpha1.FalconNodeSensor{}
-	updated := false
+	desired := []client.Object{}
 
 	if err := r.Client.List(ctx, &nodeSensorList); err != nil {
 		return fmt.Errorf("unable to get FalconNodeSensorList: %s", err)
 	}
 
-	if len(nodeSensorList.Items) != 0 {
-		existingNodeSensor.ObjectMeta = metav1.ObjectMeta{
-			Name:      "falcon-node-sensor",
-			Namespace: nodeSensorList.Items[0].GetNamespace(),
-		}
-	}
-
 	if *falconDeployment.Spec.DeployNodeSensor {
-		newNodeSensor := &falconv1alpha1.FalconNodeSensor{}
-		newNodeSensor.Spec.FalconAPI = falconDeployment.Spec.FalconAPI
-		newNodeSensor.Spec.FalconSecret = falconDeployment.Spec.FalconSecret
-		newNodeSensor.ObjectMeta = metav1.ObjectMeta{
-			Name:      "falcon-node-sensor",
-			Namespace: falconDeployment.Spec.FalconNodeSensor.InstallNamespace,
-		}
-
-		if err := mergo.Merge(&newNodeSensor.Spec, falconDeployment.Spec.FalconNodeSensor, mergo.WithOverride); err != nil {
-			return fmt.Errorf("unable to merge specs for FalconNodeSensor: %v", err)
-		}
-
-		if len(nodeSensorList.Items) == 0 {
-			if err := ctrl.SetControllerReference(falconDeployment, newNodeSensor, r.Scheme); err != nil {
-				return fmt.Errorf("unable to set controller reference for %s: %v", newNodeSensor.Name, err)
+		for _, nodeSensor := range falconDeployment.GetFalconNodeSensors() {
+			newNodeSensor := &falconv1alpha1.FalconNodeSensor{}
+			newNodeSensor.Spec.FalconAPI = falconDeployment.Spec.FalconAPI
+			newNodeSensor.Spec.FalconConfigRef = falconDeployment.Spec.FalconConfigRef
+			newNodeSensor.Spec.FalconSecret = falconDeployment.Spec.FalconSecret
+			newNodeSensor.ObjectMeta = metav1.ObjectMeta{
+				Name: nodeSensor.Name,
 			}
-			return r.create(ctx, log, falconDeployment, newNodeSensor)
-		}
 
-		err := r.Client.Get(ctx, types.NamespacedName{Name: existingNodeSensor.Name, Namespace: existingNodeSensor.Namespace}, existingNodeSensor)
+			if err := mergo.Merge(&newNodeSensor.Spec, nodeSensor.Spec, mergo.WithOverride); err != nil {
+				return fmt.Errorf("unable to merge specs for FalconNodeSensor %s: %v", nodeSensor.Name, err)
+			}
+			applyNodeSensorCommon(falconDeployment.Spec.Common, &newNodeSensor.Spec)
+			applyLabelsCommon(falconDeployment.Spec.Common, newNodeSensor)
 
-		if err != nil {
-			log.Error(err, "Failed to get FalconNodeSensor resource")
-			return err
+			desired = append(desired, newNodeSensor)
 		}
+	}
 
-		if !reflect.DeepEqual(newNodeSensor.Spec, existingNodeSensor.Spec) {
-			existingNodeSensor.Spec = newNodeSensor.Spec
-			updated = true
-		}
+	existing := make([]client.Object, 0, len(nodeSensorList.Items))
+	for i := range nodeSensorList.Items {
+		existing = append(existing, &nodeSensorList.Items[i])
+	}
 
-		if updated {
-			if err := r.update(ctx, log, falconDeployment, existingNodeSensor); err != nil {
-				return err
-			}
+	return r.reconcileChildren(ctx, log, falconDeployment, existing, desired, func(existing client.Object, desired client.Object) bool {
+		existingNodeSensor, newNodeSensor := existing.(*falconv1alpha1.FalconNodeSensor), desired.(*falconv1alpha1.FalconNodeSensor)
+		if reflect.DeepEqual(newNodeSensor.Spec, existingNodeSensor.Spec) {
+			return false
 		}
-	} else if len(nodeSensorList.Items) != 0 {
-		err := r.Client.Get(ctx, types.NamespacedName{Name: existingNodeSensor.Name, Namespace: existingNodeSensor.Namespace}, existingNodeSensor)
-		if err != nil {
-			log.Error(err, "Failed to get FalconNodeSensor resource")
-			return err
-		}
-		return r.delete(ctx, log, falconDeployment, existingNodeSensor)
-	}
 
-	return nil
+		existingNodeSensor.Spec = newNodeSensor.Spec
+		return true
+	})
 }
 
 func (r *FalconDeploymentReconciler) reconcileImageAnalyzer(ctx context.Context, log logr.Logger, falconDeployment *falconv1alpha1.FalconDeployment) error {
 	var imageAnalyzerList falconv1alpha1.FalconImageAnalyzerList
-	existingImageAnalyzer := &falconv1alpha1.FalconImageAnalyzer{}
-	updated := false
+	desired := []client.Object{}
 
 	if err := r.Client.List(ctx, &imageAnalyzerList); err != nil {
 		return fmt.Errorf("unable to get FalconImageAnalyzerList: %s", err)
 	}
 
-	if len(imageAnalyzerList.Items) != 0 {
-		existingImageAnalyzer.ObjectMeta = metav1.ObjectMeta{
-			Name:      "falcon-image-analyzer",
-			Namespace: imageAnalyzerList.Items[0].GetNamespace(),
-		}
-	}
-
 	if *falconDeployment.Spec.DeployImageAnalyzer {
 		newImageAnalyzer := &falconv1alpha1.FalconImageAnalyzer{}
 		newImageAnalyzer.Spec.FalconAPI = falconDeployment.Spec.FalconAPI
+		newImageAnalyzer.Spec.FalconConfigRef = falconDeployment.Spec.FalconConfigRef
 		newImageAnalyzer.Spec.Registry = falconDeployment.Spec.Registry
 		newImageAnalyzer.Spec.FalconSecret = falconDeployment.Spec.FalconSecret
 		newImageAnalyzer.ObjectMeta = metav1.ObjectMeta{
-			Name:      "falcon-image-analyzer",
-			Namespace: falconDeployment.Spec.FalconNodeSensor.InstallNamespace,
+			Name: falconDeployment.GetFalconImageAnalyzerName(),
 		}
+
 		if err := mergo.Merge(&newImageAnalyzer.Spec, falconDeployment.Spec.FalconImageAnalyzer, mergo.WithOverride); err != nil {
 			return fmt.Errorf("unable to merge specs for FalconImageAnalyzer: %v", err)
 		}
+		applyImageAnalyzerCommon(falconDeployment.Spec.Common, &newImageAnalyzer.Spec)
+		applyLabelsCommon(falconDeployment.Spec.Common, newImageAnalyzer)
 
-		if len(imageAnalyzerList.Items) == 0 {
-			if err := ctrl.SetControllerReference(falconDeployment, newImageAnalyzer, r.Scheme); err != nil {
-				return fmt.Errorf("unable to set controller reference for %s: %v", newImageAnalyzer.Name, err)
-			}
-			return r.create(ctx, log, falconDeployment, newImageAnalyzer)
-		}
-
-		err := r.Client.Get(ctx, types.NamespacedName{Name: existingImageAnalyzer.Name, Namespace: existingImageAnalyzer.Namespace}, existingImageAnalyzer)
-
-		if err != nil {
-			log.Error(err, "Failed to get FalconImageAnalyzer resource")
-			return err
-		}
+		desired = append(desired, newImageAnalyzer)
+	}
 
-		if !reflect.DeepEqual(newImageAnalyzer.Spec, existingImageAnalyzer.Spec) {
-			existingImageAnalyzer.Spec = newImageAnalyzer.Spec
-			updated = true
-		}
+	existing := make([]client.Object, 0, len(imageAnalyzerList.Items))
+	for i := range imageAnalyzerList.Items {
+		existing = append(existing, &imageAnalyzerList.Items[i])
+	}
 
-		if updated {
-			if err := r.update(ctx, log, falconDeployment, existingImageAnalyzer); err != nil {
-				return err
-			}
-		}
-	} else if len(imageAnalyzerList.Items) != 0 {
-		err := r.Client.Get(ctx, types.NamespacedName{Name: existingImageAnalyzer.Name, Namespace: existingImageAnalyzer.Namespace}, existingImageAnalyzer)
-		if err != nil {
-			log.Error(err, "Failed to get FalconImageAnalyzer resource")
-			return err
+	return r.reconcileChildren(ctx, log, falconDeployment, existing, desired, func(existing client.Object, desired client.Object) bool {
+		existingImageAnalyzer, newImageAnalyzer := existing.(*falconv1alpha1.FalconImageAnalyzer), desired.(*falconv1alpha1.FalconImageAnalyzer)
+		if reflect.DeepEqual(newImageAnalyzer.Spec, existingImageAnalyzer.Spec) {
+			return false
 		}
-		return r.delete(ctx, log, falconDeployment, existingImageAnalyzer)
-	}
 
-	return nil
+		existingImageAnalyzer.Spec = newImageAnalyzer.Spec
+		return true
+	})
 }
 
 func (r *FalconDeploymentReconciler) reconcileContainerSensor(ctx context.Context, log logr.Logger, falconDeployment *falconv1alpha1.FalconDeployment) error {
 	var containerSensorList falconv1alpha1.FalconContainerList
-	existingContainerSensor := &falconv1alpha1.FalconContainer{}
-	updated := false
+	desired := []client.Object{}
 
 	if err := r.Client.List(ctx, &containerSensorList); err != nil {
 		return fmt.Errorf("unable to get FalconContainerList: %s", err)
 	}
 
-	if len(containerSensorList.Items) != 0 {
-		existingContainerSensor.ObjectMeta = metav1.ObjectMeta{
-			Name:      "falcon-container-sensor",
-			Namespace: containerSensorList.Items[0].GetNamespace(),
-		}
-	}
-
 	if *falconDeployment.Spec.DeployContainerSensor {
 		newContainerSensor := &falconv1alpha1.FalconContainer{}
 		newContainerSensor.Spec.FalconAPI = falconDeployment.Spec.FalconAPI
+		newContainerSensor.Spec.FalconConfigRef = falconDeployment.Spec.FalconConfigRef
 		newContainerSensor.Spec.Registry = falconDeployment.Spec.Registry
 		newContainerSensor.Spec.FalconSecret = falconDeployment.Spec.FalconSecret
 		newContainerSensor.ObjectMeta = metav1.ObjectMeta{
-			Name:      "falcon-container-sensor",
-			Namespace: falconDeployment.Spec.FalconContainerSensor.InstallNamespace,
+			Name: falconDeployment.GetFalconContainerSensorName(),
 		}
 
 		if err := mergo.Merge(&newContainerSensor.Spec, falconDeployment.Spec.FalconContainerSensor, mergo.WithOverride); err != nil {
 			return fmt.Errorf("unable to merge specs for FalconContainerSensor: %v", err)
 		}
+		applyContainerSensorCommon(falconDeployment.Spec.Common, &newContainerSensor.Spec)
+		applyLabelsCommon(falconDeployment.Spec.Common, newContainerSensor)
 
-		if len(containerSensorList.Items) == 0 {
-			if err := ctrl.SetControllerReference(falconDeployment, newContainerSensor, r.Scheme); err != nil {
-				return fmt.Errorf("unable to set controller reference for %s: %v", newContainerSensor.Name, err)
-			}
-			return r.create(ctx, log, falconDeployment, newContainerSensor)
+		desired = append(desired, newContainerSensor)
+	}
+
+	existing := make([]client.Object, 0, len(containerSensorList.Items))
+	for i := range containerSensorList.Items {
+		existing = append(existing, &containerSensorList.Items[i])
+	}
+
+	return r.reconcileChildren(ctx, log, falconDeployment, existing, desired, func(existing client.Object, desired client.Object) bool {
+		existingContainerSensor, newContainerSensor := existing.(*falconv1alpha1.FalconContainer), desired.(*falconv1alpha1.FalconContainer)
+		if reflect.DeepEqual(newContainerSensor.Spec, existingContainerSensor.Spec) {
+			return false
 		}
 
-		err := r.Client.Get(ctx, types.NamespacedName{Name: existingContainerSensor.Name, Namespace: existingContainerSensor.Namespace}, existingContainerSensor)
+		existingContainerSensor.Spec = newContainerSensor.Spec
+		return true
+	})
+}
 
-		if err != nil {
-			log.Error(err, "Failed to get FalconContainerSensor resource")
-			return err
+// reconcileChildren makes the custom resources of one kind controlled by the FalconDeployment match the desired ones.
+// Children are tracked by their controller reference and matched by name: controlled resources that are no longer
+// desired are deleted, the others get their spec synced by syncSpec and their labels by syncLabels, and missing ones are created.
+func (r *FalconDeploymentReconciler) reconcileChildren(ctx context.Context, log logr.Logger, falconDeployment *falconv1alpha1.FalconDeployment, existing []client.Object, desired []client.Object, syncSpec func(existing client.Object, desired client.Object) bool) error {
+	missing := make(map[string]client.Object, len(desired))
+	for _, obj := range desired {
+		missing[obj.GetName()] = obj
+	}
+
+	for _, obj := range existing {
+		if !metav1.IsControlledBy(obj, falconDeployment) {
+			continue
 		}
 
-		if !reflect.DeepEqual(newContainerSensor.Spec, existingContainerSensor.Spec) {
-			existingContainerSensor.Spec = newContainerSensor.Spec
+		want, ok := missing[obj.GetName()]
+		if !ok {
+			if err := r.delete(ctx, log, falconDeployment, obj); err != nil {
+				return err
+			}
+			continue
+		}
+		delete(missing, obj.GetName())
+
+		updated := syncSpec(obj, want)
+		if syncLabels(obj, want) {
 			updated = true
 		}
 
 		if updated {
-			if err := r.update(ctx, log, falconDeployment, existingContainerSensor); err != nil {
+			if err := r.update(ctx, log, falconDeployment, obj); err != nil {
 				return err
 			}
 		}
-	} else if len(containerSensorList.Items) != 0 {
-		err := r.Client.Get(ctx, types.NamespacedName{Name: existingContainerSensor.Name, Namespace: existingContainerSensor.Namespace}, existingContainerSensor)
-		if err != nil {
-			log.Error(err, "Failed to get FalconContainerSensor resource")
+	}
+
+	for _, obj := range desired {
+		if _, ok := missing[obj.GetName()]; !ok {
+			continue
+		}
+
+		if err := ctrl.SetControllerReference(falconDeployment, obj, r.Scheme); err != nil {
+			return fmt.Errorf("unable to set controller reference for %s: %v", obj.GetName(), err)
+		}
+
+		if err := r.create(ctx, log, falconDeployment, obj); err != nil {
 			return err
 		}
-		return r.delete(ctx, log, falconDeployment, existingContainerSensor)
 	}
 
 	return nil
 }
 
+// syncLabels adds the labels of the desired custom resource to the existing one. It reports whether any label changed.
+func syncLabels(existing client.Object, desired client.Object) bool {
+	labels := existing.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	changed := false
+	for k, v := range desired.GetLabels() {
+		if labels[k] != v {
+			labels[k] = v
+			changed = true
+		}
+	}
+
+	if changed {
+		existing.SetLabels(labels)
+	}
+
+	return changed
+}
+
 func (r *FalconDeploymentReconciler) statusUpdate(ctx context.Context, req ctrl.Request, log logr.Logger, falconDeployment *falconv1alpha1.FalconDeployment, condType string, status metav1.ConditionStatus, reason string, message string) error {
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		err := r.Get(ctx, req.NamespacedName, falconDeployment)
@@ -456,18 +445,7 @@ func (r *FalconDeploymentReconciler) create(ctx context.Context, log logr.Logger
 			}
 		}
 
-		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
-			meta.SetStatusCondition(&falconDeployment.Status.Conditions, metav1.Condition{
-				Type:    fmt.Sprintf("%sReady", strings.ToUpper(gvk.Kind[:1])+gvk.Kind[1:]),
-				Status:  metav1.ConditionTrue,
-				Reason:  "Created",
-				Message: fmt.Sprintf("Successfully created %s %s in %s", gvk.Kind, name, namespace),
-			})
-
-			return r.Client.Status().Update(ctx, falconDeployment)
-		})
-
-		return err
+		return nil
 	default:
 		return fmt.Errorf("unrecognized kube object type: %T", obj)
 	}
@@ -488,18 +466,7 @@ func (r *FalconDeploymentReconciler) update(ctx context.Context, log logr.Logger
 			return fmt.Errorf("cannot update object %s %s in namespace %s: %v", gvk.Kind, name, namespace, err)
 		}
 
-		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
-			meta.SetStatusCondition(&falconDeployment.Status.Conditions, metav1.Condition{
-				Type:    fmt.Sprintf("%sReady", strings.ToUpper(gvk.Kind[:1])+gvk.Kind[1:]),
-				Status:  metav1.ConditionTrue,
-				Reason:  "Updated",
-				Message: fmt.Sprintf("Successfully updated %s %s in %s", gvk.Kind, name, namespace),
-			})
-
-			return r.Client.Status().Update(ctx, falconDeployment)
-		})
-
-		return err
+		return nil
 	default:
 		return fmt.Errorf("unrecognized kube object type: %T", obj)
 	}
@@ -520,18 +487,7 @@ func (r *FalconDeploymentReconciler) delete(ctx context.Context, log logr.Logger
 			return fmt.Errorf("cannot delete object %s %s in namespace %s: %v", gvk.Kind, name, namespace, err)
 		}
 
-		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
-			meta.SetStatusCondition(&falconDeployment.Status.Conditions, metav1.Condition{
-				Type:    fmt.Sprintf("%sReady", strings.ToUpper(gvk.Kind[:1])+gvk.Kind[1:]),
-				Status:  metav1.ConditionTrue,
-				Reason:  "Deleted",
-				Message: fmt.Sprintf("Successfully deleted %s %s in %s", gvk.Kind, name, namespace),
-			})
-
-			return r.Client.Status().Update(ctx, falconDeployment)
-		})
-
-		return err
+		return nil
 	default:
 		return fmt.Errorf("unrecognized kube object type: %T", obj)
 	}
@@ -0,0 +1,227 @@
+package falcon
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// component describes the custom resources of one kind managed by a FalconDeployment
+type component struct {
+	kind  string
+	names []string
+	// get fetches a custom resource of the kind and returns its sensor version and status conditions
+	get func(ctx context.Context, name string) (*string, []metav1.Condition, error)
+	// set stores the health of the deployed custom resources in the FalconDeployment status
+	set func(status *falconv1alpha1.FalconDeploymentStatus, components []falconv1alpha1.FalconDeploymentComponentStatus)
+}
+
+func (r *FalconDeploymentReconciler) components(falconDeployment *falconv1alpha1.FalconDeployment) []component {
+	admission := component{
+		kind: "FalconAdmission",
+		get: func(ctx context.Context, name string) (*string, []metav1.Condition, error) {
+			obj := &falconv1alpha1.FalconAdmission{}
+			err := r.Client.Get(ctx, types.NamespacedName{Name: name}, obj)
+			return obj.Status.Sensor, obj.Status.Conditions, err
+		},
+		set: func(status *falconv1alpha1.FalconDeploymentStatus, components []falconv1alpha1.FalconDeploymentComponentStatus) {
+			status.FalconAdmission = firstComponent(components)
+		},
+	}
+	if *falconDeployment.Spec.DeployAdmissionController {
+		admission.names = []string{falconDeployment.GetFalconAdmissionName()}
+	}
+
+	nodeSensor := component{
+		kind: "FalconNodeSensor",
+		get: func(ctx context.Context, name string) (*string, []metav1.Condition, error) {
+			obj := &falconv1alpha1.FalconNodeSensor{}
+			err := r.Client.Get(ctx, types.NamespacedName{Name: name}, obj)
+			return obj.Status.Sensor, obj.Status.Conditions, err
+		},
+		set: func(status *falconv1alpha1.FalconDeploymentStatus, components []falconv1alpha1.FalconDeploymentComponentStatus) {
+			status.FalconNodeSensors = components
+		},
+	}
+	if *falconDeployment.Spec.DeployNodeSensor {
+		for _, sensor := range falconDeployment.GetFalconNodeSensors() {
+			nodeSensor.names = append(nodeSensor.names, sensor.Name)
+		}
+	}
+
+	imageAnalyzer := component{
+		kind: "FalconImageAnalyzer",
+		get: func(ctx context.Context, name string) (*string, []metav1.Condition, error) {
+			obj := &falconv1alpha1.FalconImageAnalyzer{}
+			err := r.Client.Get(ctx, types.NamespacedName{Name: name}, obj)
+			return obj.Status.Sensor, obj.Status.Conditions, err
+		},
+		set: func(status *falconv1alpha1.FalconDeploymentStatus, components []falconv1alpha1.FalconDeploymentComponentStatus) {
+			status.FalconImageAnalyzer = firstComponent(components)
+		},
+	}
+	if *falconDeployment.Spec.DeployImageAnalyzer {
+		imageAnalyzer.names = []string{falconDeployment.GetFalconImageAnalyzerName()}
+	}
+
+	containerSensor := component{
+		kind: "FalconContainer",
+		get: func(ctx context.Context, name string) (*string, []metav1.Condition, error) {
+			obj := &falconv1alpha1.FalconContainer{}
+			err := r.Client.Get(ctx, types.NamespacedName{Name: name}, obj)
+			return obj.Status.Sensor, obj.Status.Conditions, err
+		},
+		set: func(status *falconv1alpha1.FalconDeploymentStatus, components []falconv1alpha1.FalconDeploymentComponentStatus) {
+			status.FalconContainerSensor = firstComponent(components)
+		},
+	}
+	if *falconDeployment.Spec.DeployContainerSensor {
+		containerSensor.names = []string{falconDeployment.GetFalconContainerSensorName()}
+	}
+
+	return []component{admission, nodeSensor, imageAnalyzer, containerSensor}
+}
+
+// reconcileStatus rolls the health of every managed custom resource up into the FalconDeployment status.
+// The FalconDeployment only reports success once every enabled component is healthy.
+func (r *FalconDeploymentReconciler) reconcileStatus(ctx context.Context, req ctrl.Request, log logr.Logger, falconDeployment *falconv1alpha1.FalconDeployment) error {
+	components := r.components(falconDeployment)
+	statuses := make([][]falconv1alpha1.FalconDeploymentComponentStatus, len(components))
+
+	for i, c := range components {
+		for _, name := range c.names {
+			sensor, conditions, err := c.get(ctx, name)
+			switch {
+			case apierrors.IsNotFound(err):
+				statuses[i] = append(statuses[i], falconv1alpha1.FalconDeploymentComponentStatus{
+					Name:    name,
+					Message: fmt.Sprintf("%s %s has not been created yet", c.kind, name),
+				})
+			case err != nil:
+				log.Error(err, fmt.Sprintf("Failed to get %s resource", c.kind))
+				return err
+			default:
+				statuses[i] = append(statuses[i], *componentStatus(c.kind, name, sensor, conditions))
+			}
+		}
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := r.Get(ctx, req.NamespacedName, falconDeployment)
+		if err != nil {
+			return err
+		}
+
+		notReady := []string{}
+		for i, c := range components {
+			condType := fmt.Sprintf("%sReady", c.kind)
+			c.set(&falconDeployment.Status, statuses[i])
+
+			if len(statuses[i]) == 0 {
+				meta.RemoveStatusCondition(&falconDeployment.Status.Conditions, condType)
+				continue
+			}
+
+			condition := metav1.Condition{
+				Type:               condType,
+				Status:             metav1.ConditionTrue,
+				Reason:             falconv1alpha1.ReasonComponentReady,
+				ObservedGeneration: falconDeployment.GetGeneration(),
+			}
+
+			messages := []string{}
+			for _, status := range statuses[i] {
+				messages = append(messages, status.Message)
+				if !status.Ready {
+					condition.Status = metav1.ConditionFalse
+					condition.Reason = falconv1alpha1.ReasonComponentNotReady
+				}
+			}
+			condition.Message = strings.Join(messages, "; ")
+
+			if condition.Status == metav1.ConditionFalse {
+				notReady = append(notReady, c.kind)
+			}
+			meta.SetStatusCondition(&falconDeployment.Status.Conditions, condition)
+		}
+
+		falconDeployment.Status.Sensor = deploymentSensor(falconDeployment.Status)
+
+		success := metav1.Condition{
+			Type:               falconv1alpha1.ConditionSuccess,
+			Status:             metav1.ConditionTrue,
+			Reason:             falconv1alpha1.ReasonInstallSucceeded,
+			Message:            "FalconDeployment installation completed",
+			ObservedGeneration: falconDeployment.GetGeneration(),
+		}
+		if len(notReady) > 0 {
+			success.Status = metav1.ConditionFalse
+			success.Reason = falconv1alpha1.ReasonComponentNotReady
+			success.Message = fmt.Sprintf("Waiting for %s to become ready", strings.Join(notReady, ", "))
+		}
+		meta.SetStatusCondition(&falconDeployment.Status.Conditions, success)
+
+		return r.Status().Update(ctx, falconDeployment)
+	})
+	if err != nil {
+		log.Error(err, "Failed to update FalconDeployment status")
+		return err
+	}
+
+	return nil
+}
+
+func firstComponent(components []falconv1alpha1.FalconDeploymentComponentStatus) *falconv1alpha1.FalconDeploymentComponentStatus {
+	if len(components) == 0 {
+		return nil
+	}
+
+	return &components[0]
+}
+
+// componentStatus derives the health of a managed custom resource from its status conditions
+func componentStatus(kind string, name string, sensor *string, conditions []metav1.Condition) *falconv1alpha1.FalconDeploymentComponentStatus {
+	status := &falconv1alpha1.FalconDeploymentComponentStatus{
+		Name:   name,
+		Sensor: sensor,
+	}
+
+	success := meta.FindStatusCondition(conditions, falconv1alpha1.ConditionSuccess)
+	failed := meta.FindStatusCondition(conditions, falconv1alpha1.ConditionFailed)
+
+	switch {
+	case success != nil && success.Status == metav1.ConditionTrue:
+		status.Ready = true
+		status.Message = success.Message
+	case failed != nil:
+		status.Message = fmt.Sprintf("%s %s failed: %s", kind, name, failed.Message)
+	default:
+		status.Message = fmt.Sprintf("%s %s has not completed its installation yet", kind, name)
+	}
+
+	return status
+}
+
+// deploymentSensor reports the version of the first node sensor, or of the container sensor when no node sensor is deployed
+func deploymentSensor(status falconv1alpha1.FalconDeploymentStatus) *string {
+	for _, nodeSensor := range status.FalconNodeSensors {
+		if nodeSensor.Sensor != nil {
+			return nodeSensor.Sensor
+		}
+	}
+
+	if status.FalconContainerSensor != nil {
+		return status.FalconContainerSensor.Sensor
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package falcon
+
+import (
+	"testing"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestComponentStatus_Ready(t *testing.T) {
+	sensor := "7.20.0-1234"
+	status := componentStatus("FalconNodeSensor", "falcon-node-sensor", &sensor, []metav1.Condition{
+		{Type: falconv1alpha1.ConditionSuccess, Status: metav1.ConditionTrue, Message: "FalconNodeSensor installation completed"},
+	})
+
+	assert.True(t, status.Ready)
+	assert.Equal(t, "falcon-node-sensor", status.Name)
+	assert.Equal(t, &sensor, status.Sensor)
+	assert.Equal(t, "FalconNodeSensor installation completed", status.Message)
+}
+
+func TestComponentStatus_Failed(t *testing.T) {
+	status := componentStatus("FalconAdmission", "falcon-kac", nil, []metav1.Condition{
+		{Type: falconv1alpha1.ConditionPending, Status: metav1.ConditionUnknown},
+		{Type: falconv1alpha1.ConditionFailed, Status: metav1.ConditionFalse, Message: "namespace has other workloads"},
+	})
+
+	assert.False(t, status.Ready)
+	assert.Equal(t, "FalconAdmission falcon-kac failed: namespace has other workloads", status.Message)
+}
+
+func TestComponentStatus_Pending(t *testing.T) {
+	status := componentStatus("FalconImageAnalyzer", "falcon-image-analyzer", nil, nil)
+
+	assert.False(t, status.Ready)
+	assert.Equal(t, "FalconImageAnalyzer falcon-image-analyzer has not completed its installation yet", status.Message)
+}
+
+func TestDeploymentSensor(t *testing.T) {
+	node := "7.20.0-1234"
+	container := "7.19.0-5678"
+
+	assert.Nil(t, deploymentSensor(falconv1alpha1.FalconDeploymentStatus{}))
+	assert.Equal(t, &container, deploymentSensor(falconv1alpha1.FalconDeploymentStatus{
+		FalconContainerSensor: &falconv1alpha1.FalconDeploymentComponentStatus{Sensor: &container},
+	}))
+	assert.Equal(t, &node, deploymentSensor(falconv1alpha1.FalconDeploymentStatus{
+		FalconNodeSensors:     []falconv1alpha1.FalconDeploymentComponentStatus{{Name: "pending"}, {Sensor: &node}},
+		FalconContainerSensor: &falconv1alpha1.FalconDeploymentComponentStatus{Sensor: &container},
+	}))
+}
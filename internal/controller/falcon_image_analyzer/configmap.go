@@ -35,6 +35,7 @@ func (r *FalconImageAnalyzerReconciler) reconcileGenericConfigMap(name string, g
 	if err != nil {
 		return false, err
 	}
+	assets.ApplyResourceMetadata(cm, falconImageAnalyzer.Spec.ResourceMetadata)
 
 	existingCM := &corev1.ConfigMap{}
 	err = common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: name, Namespace: falconImageAnalyzer.Spec.InstallNamespace}, existingCM)
@@ -50,16 +51,13 @@ func (r *FalconImageAnalyzerReconciler) reconcileGenericConfigMap(name string, g
 		return false, err
 	}
 
-	if !reflect.DeepEqual(cm.Data, existingCM.Data) {
-		existingCM.Data = cm.Data
-		if err := k8sutils.Update(r.Client, ctx, req, log, falconImageAnalyzer, &falconImageAnalyzer.Status, existingCM); err != nil {
-			return false, err
-		}
-		return true, nil
+	existingData := existingCM.Data
+	if _, err := k8sutils.Apply(r.Client, ctx, req, log, falconImageAnalyzer, &falconImageAnalyzer.Status, existingCM, cm, falconImageAnalyzer.Spec.FieldOwnership); err != nil {
+		return false, err
 	}
 
-	return false, nil
-
+	// Metadata changes do not require the Image Analyzer to be restarted
+	return !reflect.DeepEqual(existingData, cm.Data), nil
 }
 
 func (r *FalconImageAnalyzerReconciler) newConfigMap(ctx context.Context, name string, falconImageAnalyzer *falconv1alpha1.FalconImageAnalyzer) (*corev1.ConfigMap, error) {
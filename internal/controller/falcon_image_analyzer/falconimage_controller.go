@@ -11,18 +11,21 @@ import (
 	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
 	"github.com/crowdstrike/falcon-operator/internal/controller/assets"
 	k8sutils "github.com/crowdstrike/falcon-operator/internal/controller/common"
+	"github.com/crowdstrike/falcon-operator/internal/controller/common/pullsecret"
+	"github.com/crowdstrike/falcon-operator/internal/controller/common/sensorversion"
 	"github.com/crowdstrike/falcon-operator/pkg/aws"
 	"github.com/crowdstrike/falcon-operator/pkg/common"
+	"github.com/crowdstrike/falcon-operator/pkg/gcp"
 	"github.com/crowdstrike/falcon-operator/pkg/registry/pulltoken"
 	"github.com/crowdstrike/falcon-operator/pkg/tls"
 	"github.com/crowdstrike/falcon-operator/version"
+	"github.com/crowdstrike/gofalcon/falcon"
 	"github.com/go-logr/logr"
 	imagev1 "github.com/openshift/api/image/v1"
 	"github.com/operator-framework/operator-lib/proxy"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
-	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -32,19 +35,28 @@ import (
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+// configVersionKey is the pod template annotation bumped to roll out the Image Analyzer after a configuration change
+const configVersionKey = "falcon.config.version"
+
 // FalconImageAnalyzerReconciler reconciles a FalconImageAnalyzer object
 type FalconImageAnalyzerReconciler struct {
 	client.Client
 	Reader client.Reader
 	Scheme *runtime.Scheme
+
+	reconcileObject     func(client.Object)
+	tracker             sensorversion.Tracker
+	pullSecretRefresher *pullsecret.Refresher
 }
 
 // SetupWithManager sets up the controller with the Manager.
-func (r *FalconImageAnalyzerReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+func (r *FalconImageAnalyzerReconciler) SetupWithManager(mgr ctrl.Manager, tracker sensorversion.Tracker, pullSecretRefresher *pullsecret.Refresher) error {
+	imageAnalyzerController, err := ctrl.NewControllerManagedBy(mgr).
 		For(&falconv1alpha1.FalconImageAnalyzer{}).
 		Owns(&corev1.Namespace{}).
 		Owns(&corev1.ConfigMap{}).
@@ -53,7 +65,25 @@ func (r *FalconImageAnalyzerReconciler) SetupWithManager(mgr ctrl.Manager) error
 		Owns(&appsv1.Deployment{}).
 		Owns(&corev1.ServiceAccount{}).
 		Owns(&rbacv1.ClusterRoleBinding{}).
-		Complete(r)
+		Watches(&falconv1alpha1.FalconConfig{}, handler.EnqueueRequestsFromMapFunc(r.falconConfigToFalconImageAnalyzers)).
+		Build(r)
+	if err != nil {
+		return err
+	}
+
+	r.reconcileObject, err = k8sutils.NewReconcileTrigger(imageAnalyzerController)
+	if err != nil {
+		return err
+	}
+
+	r.tracker = tracker
+	r.pullSecretRefresher = pullSecretRefresher
+	return nil
+}
+
+// falconConfigToFalconImageAnalyzers maps a FalconConfig to the FalconImageAnalyzer custom resources referencing it
+func (r *FalconImageAnalyzerReconciler) falconConfigToFalconImageAnalyzers(ctx context.Context, obj client.Object) []reconcile.Request {
+	return k8sutils.FalconConfigRequests(ctx, r.Client, &falconv1alpha1.FalconImageAnalyzerList{}, obj)
 }
 
 func (r *FalconImageAnalyzerReconciler) GetK8sClient() client.Client {
@@ -67,13 +97,14 @@ func (r *FalconImageAnalyzerReconciler) GetK8sReader() client.Reader {
 //+kubebuilder:rbac:groups=falcon.crowdstrike.com,resources=falconimageanalyzers,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=falcon.crowdstrike.com,resources=falconimageanalyzers/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=falcon.crowdstrike.com,resources=falconimageanalyzers/finalizers,verbs=update
+//+kubebuilder:rbac:groups=falcon.crowdstrike.com,resources=falconconfigs,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create;update;delete
-//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;delete
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;delete
 //+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;delete
 //+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;delete
 //+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;update
-//+kubebuilder:rbac:groups="apps",resources=deployments,verbs=get;list;watch;create;update;delete
+//+kubebuilder:rbac:groups="apps",resources=deployments,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="security.openshift.io",resources=securitycontextconstraints,resourceNames=privileged,verbs=use
 //+kubebuilder:rbac:groups="image.openshift.io",resources=imagestreams,verbs=get;list;watch;create;update;delete
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=create;get;list;update;watch;delete
@@ -97,6 +128,9 @@ func (r *FalconImageAnalyzerReconciler) Reconcile(ctx context.Context, req ctrl.
 	err := r.Get(ctx, req.NamespacedName, falconImageAnalyzer)
 	if err != nil {
 		if errors.IsNotFound(err) {
+			r.tracker.StopTracking(req.NamespacedName)
+			r.pullSecretRefresher.Unregister("FalconImageAnalyzer", req.NamespacedName)
+
 			// If the custom resource is not found then, it usually means that it was deleted or not created
 			// In this way, we will stop the reconciliation
 			log.Info("FalconImageAnalyzer resource not found. Ignoring since object must be deleted")
@@ -107,6 +141,8 @@ func (r *FalconImageAnalyzerReconciler) Reconcile(ctx context.Context, req ctrl.
 		return ctrl.Result{}, err
 	}
 
+	r.pullSecretRefresher.Register("FalconImageAnalyzer", req.NamespacedName, r.registryPullSecrets().Handler(r.Client))
+
 	validate, err := k8sutils.CheckRunningPodLabels(r.Reader, ctx, falconImageAnalyzer.Spec.InstallNamespace, common.CRLabels("deployment", falconImageAnalyzer.Name, common.FalconImageAnalyzer))
 	if err != nil {
 		return ctrl.Result{}, err
@@ -163,6 +199,25 @@ func (r *FalconImageAnalyzerReconciler) Reconcile(ctx context.Context, req ctrl.
 
 	}
 
+	// Merge the referenced FalconConfig into a copy of the FalconImageAnalyzer, whose spec the status helpers of k8sutils keep
+	falconImageAnalyzer, err = k8sutils.ResolveFalconConfig(ctx, r, falconImageAnalyzer)
+	if err != nil {
+		log.Error(err, "Failed to merge FalconConfig")
+		return ctrl.Result{}, err
+	}
+
+	if shouldTrackSensorVersions(falconImageAnalyzer) {
+		falconApiConfig, apiConfigErr := r.falconApiConfig(ctx, falconImageAnalyzer)
+		if apiConfigErr != nil {
+			return ctrl.Result{}, apiConfigErr
+		}
+
+		getSensorVersion := sensorversion.NewFalconCloudQuery(falcon.ImageSensor, falconApiConfig)
+		r.tracker.Track(req.NamespacedName, getSensorVersion, r.reconcileObjectWithName, falconImageAnalyzer.Spec.Advanced.IsAutoUpdatingForced())
+	} else {
+		r.tracker.StopTracking(req.NamespacedName)
+	}
+
 	if err := r.reconcileNamespace(ctx, req, log, falconImageAnalyzer); err != nil {
 		return ctrl.Result{}, err
 	}
@@ -194,6 +249,10 @@ func (r *FalconImageAnalyzerReconciler) Reconcile(ctx context.Context, req ctrl.
 
 		if r.imageMirroringEnabled(falconImageAnalyzer) {
 			if err := r.PushImage(ctx, log, falconImageAnalyzer); err != nil {
+				if gcp.IsRepositoryCreating(err) {
+					log.Info("Waiting for the creation of the Artifact Registry repository", "Error", err.Error())
+					return ctrl.Result{RequeueAfter: gcp.ArtifactRegistryRequeueInterval}, nil
+				}
 				return ctrl.Result{}, fmt.Errorf("cannot refresh Falcon Image  image: %v", err)
 			}
 		} else {
@@ -276,7 +335,11 @@ func (r *FalconImageAnalyzerReconciler) reconcileImageAnalyzerDeployment(ctx con
 
 	existingDeployment := &appsv1.Deployment{}
 	dep := assets.ImageAnalyzerDeployment(falconImageAnalyzer.Name, falconImageAnalyzer.Spec.InstallNamespace, common.FalconImageAnalyzer, imageUri, falconImageAnalyzer)
-	updated := false
+	assets.ApplyResourceMetadata(dep, falconImageAnalyzer.Spec.ResourceMetadata)
+
+	if err := assets.ApplyPodTemplateOverride(&dep.Spec.Template, falconImageAnalyzer.Spec.PodTemplateOverride); err != nil {
+		return fmt.Errorf("unable to apply the Image Analyzer pod template override: %v", err)
+	}
 
 	if len(proxy.ReadProxyVarsFromEnv()) > 0 {
 		for i, container := range dep.Spec.Template.Spec.Containers {
@@ -297,58 +360,16 @@ func (r *FalconImageAnalyzerReconciler) reconcileImageAnalyzerDeployment(ctx con
 		return err
 	}
 
-	if len(proxy.ReadProxyVarsFromEnv()) > 0 {
-		for i, container := range existingDeployment.Spec.Template.Spec.Containers {
-			newContainerEnv := common.AppendUniqueEnvVars(container.Env, proxy.ReadProxyVarsFromEnv())
-			updatedContainerEnv := common.UpdateEnvVars(container.Env, proxy.ReadProxyVarsFromEnv())
-			if !equality.Semantic.DeepEqual(existingDeployment.Spec.Template.Spec.Containers[i].Env, newContainerEnv) {
-				existingDeployment.Spec.Template.Spec.Containers[i].Env = newContainerEnv
-				updated = true
-			}
-			if !equality.Semantic.DeepEqual(existingDeployment.Spec.Template.Spec.Containers[i].Env, updatedContainerEnv) {
-				existingDeployment.Spec.Template.Spec.Containers[i].Env = updatedContainerEnv
-				updated = true
-			}
-			if updated {
-				log.Info("Updating FalconNodeSensor Deployment Proxy Settings")
-			}
+	// Keep the configuration version bumped by imageAnalyzerDeploymentUpdate so that applying the Deployment does not roll it out again
+	if version, ok := existingDeployment.Spec.Template.Annotations[configVersionKey]; ok {
+		if dep.Spec.Template.Annotations == nil {
+			dep.Spec.Template.Annotations = map[string]string{}
 		}
+		dep.Spec.Template.Annotations[configVersionKey] = version
 	}
 
-	if !reflect.DeepEqual(dep.Spec.Template.Spec.Containers[0].Image, existingDeployment.Spec.Template.Spec.Containers[0].Image) {
-		existingDeployment.Spec.Template.Spec.Containers[0].Image = dep.Spec.Template.Spec.Containers[0].Image
-		updated = true
-	}
-
-	if !reflect.DeepEqual(dep.Spec.Template.Spec.Containers[0].ImagePullPolicy, existingDeployment.Spec.Template.Spec.Containers[0].ImagePullPolicy) {
-		existingDeployment.Spec.Template.Spec.Containers[0].ImagePullPolicy = dep.Spec.Template.Spec.Containers[0].ImagePullPolicy
-		updated = true
-	}
-
-	if !reflect.DeepEqual(dep.Spec.Template.Spec.ImagePullSecrets, existingDeployment.Spec.Template.Spec.ImagePullSecrets) {
-		existingDeployment.Spec.Template.Spec.ImagePullSecrets = dep.Spec.Template.Spec.ImagePullSecrets
-		updated = true
-	}
-
-	if !reflect.DeepEqual(dep.Spec.Template.Spec.Containers[0].Ports, existingDeployment.Spec.Template.Spec.Containers[0].Ports) {
-		existingDeployment.Spec.Template.Spec.Containers[0].Ports = dep.Spec.Template.Spec.Containers[0].Ports
-		updated = true
-	}
-
-	if !reflect.DeepEqual(existingDeployment.Spec.Strategy.RollingUpdate, dep.Spec.Strategy.RollingUpdate) {
-		existingDeployment.Spec.Strategy.RollingUpdate = dep.Spec.Strategy.RollingUpdate
-		updated = true
-	}
-
-	if !reflect.DeepEqual(existingDeployment.Spec.Template.Spec.Affinity.NodeAffinity, dep.Spec.Template.Spec.Affinity.NodeAffinity) {
-		existingDeployment.Spec.Template.Spec.Affinity.NodeAffinity = dep.Spec.Template.Spec.Affinity.NodeAffinity
-		updated = true
-	}
-
-	if updated {
-		if err := k8sutils.Update(r.Client, ctx, req, log, falconImageAnalyzer, &falconImageAnalyzer.Status, existingDeployment); err != nil {
-			return err
-		}
+	if _, err := k8sutils.Apply(r.Client, ctx, req, log, falconImageAnalyzer, &falconImageAnalyzer.Status, existingDeployment, dep, falconImageAnalyzer.Spec.FieldOwnership); err != nil {
+		return err
 	}
 
 	return nil
@@ -367,6 +388,7 @@ func (r *FalconImageAnalyzerReconciler) reconcileRegistrySecret(ctx context.Cont
 
 	secretData := map[string][]byte{corev1.DockerConfigJsonKey: common.CleanDecodedBase64(pulltoken)}
 	secret := assets.Secret(common.FalconPullSecretName, falconImageAnalyzer.Spec.InstallNamespace, "falcon-operator", secretData, corev1.SecretTypeDockerConfigJson)
+	assets.ApplyResourceMetadata(secret, falconImageAnalyzer.Spec.ResourceMetadata)
 	existingSecret := &corev1.Secret{}
 
 	err = common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: common.FalconPullSecretName, Namespace: falconImageAnalyzer.Spec.InstallNamespace}, existingSecret)
@@ -376,26 +398,57 @@ func (r *FalconImageAnalyzerReconciler) reconcileRegistrySecret(ctx context.Cont
 			return err
 		}
 
-		return nil
+		return r.registryPullSecrets().UpdateRefreshTime(ctx, r.Client, falconImageAnalyzer)
 	} else if err != nil {
 		log.Error(err, "Failed to get FalconImageAnalyzer Registry Pull Secret")
 		return err
 	}
 
+	metadataUpdated := assets.SyncResourceMetadata(existingSecret, secret, falconImageAnalyzer.Spec.ResourceMetadata)
 	if !reflect.DeepEqual(secret.Data, existingSecret.Data) {
+		existingSecret.Data = secret.Data
 		err = k8sutils.Update(r.Client, ctx, req, log, falconImageAnalyzer, &falconImageAnalyzer.Status, existingSecret)
 		if err != nil {
 			return err
 		}
+
+		return r.registryPullSecrets().UpdateRefreshTime(ctx, r.Client, falconImageAnalyzer)
+	}
+
+	if metadataUpdated {
+		return k8sutils.Update(r.Client, ctx, req, log, falconImageAnalyzer, &falconImageAnalyzer.Status, existingSecret)
 	}
 
 	return nil
 }
 
+// registryPullSecrets gives the pull secret refresher access to the registry pull secrets of the FalconImageAnalyzer
+func (r *FalconImageAnalyzerReconciler) registryPullSecrets() pullsecret.Accessors[*falconv1alpha1.FalconImageAnalyzer] {
+	return pullsecret.Accessors[*falconv1alpha1.FalconImageAnalyzer]{
+		Kind: "FalconImageAnalyzer",
+		New:  func() *falconv1alpha1.FalconImageAnalyzer { return &falconv1alpha1.FalconImageAnalyzer{} },
+		ApiConfig: func(ctx context.Context, falconImageAnalyzer *falconv1alpha1.FalconImageAnalyzer) (*falcon.ApiConfig, error) {
+			falconImageAnalyzer, err := k8sutils.ResolveFalconConfig(ctx, r, falconImageAnalyzer)
+			if err != nil {
+				return nil, err
+			}
+
+			if falconImageAnalyzer.Spec.FalconAPI == nil && !falconImageAnalyzer.Spec.FalconSecret.Enabled {
+				return nil, nil
+			}
+			return r.falconApiConfig(ctx, falconImageAnalyzer)
+		},
+		RefreshTime: func(falconImageAnalyzer *falconv1alpha1.FalconImageAnalyzer) **metav1.Time {
+			return &falconImageAnalyzer.Status.RegistryTokenRefreshTime
+		},
+	}
+}
+
 func (r *FalconImageAnalyzerReconciler) reconcileImageStream(ctx context.Context, req ctrl.Request, log logr.Logger, falconImageAnalyzer *falconv1alpha1.FalconImageAnalyzer) (*imagev1.ImageStream, error) {
 	const imageStreamName = "falcon-image-analyzer"
 	namespace := r.imageNamespace(falconImageAnalyzer)
 	imageStream := assets.ImageStream(imageStreamName, namespace, common.FalconImageAnalyzer)
+	assets.ApplyResourceMetadata(imageStream, falconImageAnalyzer.Spec.ResourceMetadata)
 	existingImageStream := &imagev1.ImageStream{}
 
 	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: imageStreamName, Namespace: namespace}, existingImageStream)
@@ -411,7 +464,8 @@ func (r *FalconImageAnalyzerReconciler) reconcileImageStream(ctx context.Context
 		return existingImageStream, err
 	}
 
-	if !reflect.DeepEqual(imageStream.Spec, existingImageStream.Spec) {
+	metadataUpdated := assets.SyncResourceMetadata(existingImageStream, imageStream, falconImageAnalyzer.Spec.ResourceMetadata)
+	if metadataUpdated || !reflect.DeepEqual(imageStream.Spec, existingImageStream.Spec) {
 		existingImageStream.Spec = imageStream.Spec
 		err = k8sutils.Update(r.Client, ctx, req, log, falconImageAnalyzer, &falconImageAnalyzer.Status, existingImageStream)
 		if err != nil {
@@ -425,6 +479,7 @@ func (r *FalconImageAnalyzerReconciler) reconcileImageStream(ctx context.Context
 func (r *FalconImageAnalyzerReconciler) reconcileNamespace(ctx context.Context, req ctrl.Request, log logr.Logger, falconImageAnalyzer *falconv1alpha1.FalconImageAnalyzer) error {
 	namespace := assets.Namespace(falconImageAnalyzer.Spec.InstallNamespace)
 	namespace.ObjectMeta.Labels = common.CRLabels("namespace", falconImageAnalyzer.Spec.InstallNamespace, common.FalconImageAnalyzer)
+	assets.ApplyResourceMetadata(namespace, falconImageAnalyzer.Spec.ResourceMetadata)
 	existingNamespace := &corev1.Namespace{}
 
 	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: falconImageAnalyzer.Spec.InstallNamespace}, existingNamespace)
@@ -440,12 +495,15 @@ func (r *FalconImageAnalyzerReconciler) reconcileNamespace(ctx context.Context,
 		return err
 	}
 
+	if assets.SyncResourceMetadata(existingNamespace, namespace, falconImageAnalyzer.Spec.ResourceMetadata) {
+		return k8sutils.Update(r.Client, ctx, req, log, falconImageAnalyzer, &falconImageAnalyzer.Status, existingNamespace)
+	}
+
 	return nil
 }
 
 func (r *FalconImageAnalyzerReconciler) imageAnalyzerDeploymentUpdate(ctx context.Context, req ctrl.Request, log logr.Logger, falconImageAnalyzer *falconv1alpha1.FalconImageAnalyzer) error {
 	existingDeployment := &appsv1.Deployment{}
-	configVersion := "falcon.config.version"
 	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: falconImageAnalyzer.Name, Namespace: falconImageAnalyzer.Spec.InstallNamespace}, existingDeployment)
 	if err != nil && apierrors.IsNotFound(err) {
 		return err
@@ -454,16 +512,16 @@ func (r *FalconImageAnalyzerReconciler) imageAnalyzerDeploymentUpdate(ctx contex
 		return err
 	}
 
-	_, ok := existingDeployment.Spec.Template.Annotations[configVersion]
+	_, ok := existingDeployment.Spec.Template.Annotations[configVersionKey]
 	if ok {
-		i, err := strconv.Atoi(existingDeployment.Spec.Template.Annotations[configVersion])
+		i, err := strconv.Atoi(existingDeployment.Spec.Template.Annotations[configVersionKey])
 		if err != nil {
 			return err
 		}
 
-		existingDeployment.Spec.Template.Annotations[configVersion] = strconv.Itoa(i + 1)
+		existingDeployment.Spec.Template.Annotations[configVersionKey] = strconv.Itoa(i + 1)
 	} else {
-		existingDeployment.Spec.Template.Annotations[configVersion] = "1"
+		existingDeployment.Spec.Template.Annotations[configVersionKey] = "1"
 	}
 
 	log.Info("Rolling FalconImageAnalyzer Deployment due to non-deployment configuration change")
@@ -484,11 +542,28 @@ func (r *FalconImageAnalyzerReconciler) injectFalconSecretData(
 	return k8sutils.InjectFalconSecretData(ctx, r, falconImageAnalyzer)
 }
 
+func (r *FalconImageAnalyzerReconciler) reconcileObjectWithName(ctx context.Context, name types.NamespacedName) error {
+	obj := &falconv1alpha1.FalconImageAnalyzer{}
+	err := r.Get(ctx, name, obj)
+	if err != nil {
+		return err
+	}
+
+	log.FromContext(ctx).Info("reconciling FalconImageAnalyzer object", "namespace", obj.Namespace, "name", obj.Name)
+	r.reconcileObject(obj)
+	return nil
+}
+
+func shouldTrackSensorVersions(obj *falconv1alpha1.FalconImageAnalyzer) bool {
+	return obj.Spec.FalconAPI != nil && obj.Spec.Advanced.IsAutoUpdating()
+}
+
 func (r *FalconImageAnalyzerReconciler) reconcileIARAgentService(ctx context.Context, req ctrl.Request, log logr.Logger, falconImageAnalyzer *falconv1alpha1.FalconImageAnalyzer) error {
 	selector := map[string]string{common.FalconComponentKey: common.FalconImageAnalyzer}
 	port := falconImageAnalyzer.Spec.ImageAnalyzerConfig.IARAgentService.Port
 
 	service := assets.Service(common.FalconImageAnalyzerAgentService, falconImageAnalyzer.Spec.InstallNamespace, common.FalconImageAnalyzerAgentService, selector, common.FalconImageAnalyzerHTTPSName, port)
+	assets.ApplyResourceMetadata(service, falconImageAnalyzer.Spec.ResourceMetadata)
 
 	existingService := &corev1.Service{}
 	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: common.FalconImageAnalyzerAgentService, Namespace: falconImageAnalyzer.Spec.InstallNamespace}, existingService)
@@ -504,7 +579,8 @@ func (r *FalconImageAnalyzerReconciler) reconcileIARAgentService(ctx context.Con
 		return err
 	}
 
-	if !reflect.DeepEqual(service.Spec, existingService.Spec) {
+	metadataUpdated := assets.SyncResourceMetadata(existingService, service, falconImageAnalyzer.Spec.ResourceMetadata)
+	if metadataUpdated || !reflect.DeepEqual(service.Spec, existingService.Spec) {
 		existingService.Spec = service.Spec
 		err = k8sutils.Update(r.Client, ctx, req, log, falconImageAnalyzer, &falconImageAnalyzer.Status, existingService)
 		if err != nil {
@@ -555,6 +631,7 @@ func (r *FalconImageAnalyzerReconciler) reconcileIARTLSSecret(ctx context.Contex
 		}
 
 		iarTLSSecret := assets.Secret(name, falconImageAnalyzer.Spec.InstallNamespace, common.FalconImageAnalyzer, secretData, corev1.SecretTypeTLS)
+		assets.ApplyResourceMetadata(iarTLSSecret, falconImageAnalyzer.Spec.ResourceMetadata)
 		err = k8sutils.Create(r.Client, r.Scheme, ctx, req, log, falconImageAnalyzer, &falconImageAnalyzer.Status, iarTLSSecret)
 		if err != nil {
 			return &corev1.Secret{}, err
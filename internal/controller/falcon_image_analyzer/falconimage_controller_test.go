@@ -6,6 +6,7 @@ import (
 	"time"
 
 	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/crowdstrike/falcon-operator/internal/controller/common/sensorversion"
 	"github.com/crowdstrike/falcon-operator/pkg/common"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -118,10 +119,14 @@ var _ = Describe("FalconImageAnalyzer controller", func() {
 			}, 6*time.Second, time.Second).Should(Succeed())
 
 			By("Reconciling the custom resource created")
+			tracker, cancel := sensorversion.NewTestTracker()
+			defer cancel()
+
 			falconImageAnalyzerReconciler := &FalconImageAnalyzerReconciler{
-				Client: k8sClient,
-				Reader: k8sReader,
-				Scheme: k8sClient.Scheme(),
+				Client:  k8sClient,
+				Reader:  k8sReader,
+				Scheme:  k8sClient.Scheme(),
+				tracker: tracker,
 			}
 
 			_, err = falconImageAnalyzerReconciler.Reconcile(ctx, reconcile.Request{
@@ -236,10 +241,14 @@ var _ = Describe("FalconImageAnalyzer controller", func() {
 			}, 6*time.Second, time.Second).Should(Succeed())
 
 			By("Reconciling the custom resource created")
+			tracker, cancel := sensorversion.NewTestTracker()
+			defer cancel()
+
 			falconImageAnalyzerReconciler := &FalconImageAnalyzerReconciler{
-				Client: k8sClient,
-				Reader: k8sReader,
-				Scheme: k8sClient.Scheme(),
+				Client:  k8sClient,
+				Reader:  k8sReader,
+				Scheme:  k8sClient.Scheme(),
+				tracker: tracker,
 			}
 
 			_, err = falconImageAnalyzerReconciler.Reconcile(ctx, reconcile.Request{
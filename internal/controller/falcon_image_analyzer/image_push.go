@@ -4,12 +4,13 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	k8sutils "github.com/crowdstrike/falcon-operator/internal/controller/common"
+	"github.com/crowdstrike/falcon-operator/internal/controller/common/sensor"
 	"github.com/crowdstrike/falcon-operator/internal/controller/image"
 	"github.com/crowdstrike/falcon-operator/pkg/aws"
 	"github.com/crowdstrike/falcon-operator/pkg/common"
@@ -69,7 +70,7 @@ func (r *FalconImageAnalyzerReconciler) PushImage(ctx context.Context, log logr.
 		Reason:  "Pushed",
 	})
 
-	return r.Client.Status().Update(ctx, falconImageAnalyzer)
+	return k8sutils.UpdateStatus(ctx, r.Client, falconImageAnalyzer)
 }
 
 func (r *FalconImageAnalyzerReconciler) verifyCrowdStrike(ctx context.Context, log logr.Logger, falconImageAnalyzer *falconv1alpha1.FalconImageAnalyzer) (bool, error) {
@@ -96,7 +97,7 @@ func (r *FalconImageAnalyzerReconciler) verifyCrowdStrike(ctx context.Context, l
 		ObservedGeneration: falconImageAnalyzer.GetGeneration(),
 	})
 
-	return true, r.Status().Update(ctx, falconImageAnalyzer)
+	return true, k8sutils.UpdateStatus(ctx, r.Client, falconImageAnalyzer)
 }
 
 func (r *FalconImageAnalyzerReconciler) registryUri(ctx context.Context, falconImageAnalyzer *falconv1alpha1.FalconImageAnalyzer) (string, error) {
@@ -120,6 +121,8 @@ func (r *FalconImageAnalyzerReconciler) registryUri(ctx context.Context, falconI
 		}
 
 		return "gcr.io/" + projectId + "/falcon-imageanalyzer", nil
+	case falconv1alpha1.RegistryTypeGAR:
+		return gcp.ArtifactRegistryImageUri(ctx, falconImageAnalyzer.Spec.Registry, "falcon-imageanalyzer")
 	case falconv1alpha1.RegistryTypeECR:
 		repo, err := aws.UpsertECRRepo(ctx, "falcon-image-analyzer")
 		if err != nil {
@@ -188,28 +191,28 @@ func (r *FalconImageAnalyzerReconciler) setImageTag(ctx context.Context, falconI
 	if falconImageAnalyzer.Spec.Image != "" {
 		falconImageAnalyzer.Status.Sensor = common.ImageVersion(falconImageAnalyzer.Spec.Image)
 
-		return *falconImageAnalyzer.Status.Sensor, r.Client.Status().Update(ctx, falconImageAnalyzer)
+		return *falconImageAnalyzer.Status.Sensor, k8sutils.UpdateStatus(ctx, r.Client, falconImageAnalyzer)
 	}
 
 	if os.Getenv("RELATED_IMAGE_IMAGE_ANALYZER") != "" && falconImageAnalyzer.Spec.FalconAPI == nil {
 		image := os.Getenv("RELATED_IMAGE_IMAGE_ANALYZER")
 		falconImageAnalyzer.Status.Sensor = common.ImageVersion(image)
 
-		return *falconImageAnalyzer.Status.Sensor, r.Client.Status().Update(ctx, falconImageAnalyzer)
+		return *falconImageAnalyzer.Status.Sensor, k8sutils.UpdateStatus(ctx, r.Client, falconImageAnalyzer)
 	}
 
-	// Otherwise, get the newest version matching the requested version string
+	// Otherwise, get the newest version matching the requested version string or the sensor update policy
 	falconApiConfig, err := r.falconApiConfig(ctx, falconImageAnalyzer)
 	if err != nil {
 		return "", err
 	}
 
-	registry, err := falcon_registry.NewFalconRegistry(ctx, falconApiConfig)
+	imageRepo, err := sensor.NewImageRepository(ctx, falconApiConfig)
 	if err != nil {
 		return "", err
 	}
 
-	tag, err := registry.LastContainerTag(ctx, falcon.ImageSensor, falconImageAnalyzer.Spec.Version)
+	tag, err := imageRepo.GetPreferredImage(ctx, falcon.ImageSensor, falconImageAnalyzer.Spec.Version, falconImageAnalyzer.Spec.Advanced.UpdatePolicy)
 	if err == nil {
 		falconImageAnalyzer.Status.Sensor = common.ImageVersion(tag)
 	}
@@ -218,7 +221,7 @@ func (r *FalconImageAnalyzerReconciler) setImageTag(ctx context.Context, falconI
 }
 
 func (r *FalconImageAnalyzerReconciler) pushAuth(ctx context.Context, falconImageAnalyzer *falconv1alpha1.FalconImageAnalyzer) (auth.Credentials, error) {
-	return pushtoken.GetCredentials(ctx, falconImageAnalyzer.Spec.Registry.Type,
+	return pushtoken.GetCredentials(ctx, falconImageAnalyzer.Spec.Registry,
 		k8s_utils.QuerySecretsInNamespace(r.Client, r.imageNamespace(falconImageAnalyzer)),
 	)
 }
@@ -246,5 +249,9 @@ func (r *FalconImageAnalyzerReconciler) imageMirroringEnabled(falconImageAnalyze
 }
 
 func (r *FalconImageAnalyzerReconciler) versionLock(falconImageAnalyzer *falconv1alpha1.FalconImageAnalyzer) bool {
-	return (falconImageAnalyzer.Spec.Version != nil && falconImageAnalyzer.Status.Sensor != nil && strings.Contains(*falconImageAnalyzer.Status.Sensor, *falconImageAnalyzer.Spec.Version)) || (falconImageAnalyzer.Spec.Version == nil && falconImageAnalyzer.Status.Sensor != nil)
+	if falconImageAnalyzer.Status.Sensor == nil || falconImageAnalyzer.Spec.Advanced.HasUpdatePolicy() || falconImageAnalyzer.Spec.Advanced.IsAutoUpdating() {
+		return false
+	}
+
+	return falcon_registry.VersionMatches(*falconImageAnalyzer.Status.Sensor, falconImageAnalyzer.Spec.Version)
 }
@@ -37,6 +37,7 @@ func (r *FalconImageAnalyzerReconciler) reconcileServiceAccount(ctx context.Cont
 		common.FalconImageAnalyzer,
 		falconImageAnalyzer.Spec.ImageAnalyzerConfig.ServiceAccount.Annotations,
 		imagePullSecrets)
+	assets.ApplyResourceMetadata(serviceAccount, falconImageAnalyzer.Spec.ResourceMetadata)
 
 	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: common.ImageServiceAccountName, Namespace: falconImageAnalyzer.Spec.InstallNamespace}, existingServiceAccount)
 	if err != nil && apierrors.IsNotFound(err) {
@@ -78,6 +79,7 @@ func (r *FalconImageAnalyzerReconciler) reconcileClusterRoleBinding(ctx context.
 		common.ImageServiceAccountName,
 		common.FalconImageAnalyzer,
 		[]rbacv1.Subject{})
+	assets.ApplyResourceMetadata(clusterRoleBinding, falconImageAnalyzer.Spec.ResourceMetadata)
 	existingClusterRoleBinding := &rbacv1.ClusterRoleBinding{}
 
 	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: imageClusterRoleBindingName}, existingClusterRoleBinding)
@@ -104,7 +106,7 @@ func (r *FalconImageAnalyzerReconciler) reconcileClusterRoleBinding(ctx context.
 			return err
 		}
 		// If RoleRef is the same but Subjects have changed, update the object and post to k8s api
-	} else if !reflect.DeepEqual(clusterRoleBinding.Subjects, existingClusterRoleBinding.Subjects) {
+	} else if assets.SyncResourceMetadata(existingClusterRoleBinding, clusterRoleBinding, falconImageAnalyzer.Spec.ResourceMetadata) || !reflect.DeepEqual(clusterRoleBinding.Subjects, existingClusterRoleBinding.Subjects) {
 		existingClusterRoleBinding.Subjects = clusterRoleBinding.Subjects
 		err = k8sutils.Update(r.Client, ctx, req, log, falconImageAnalyzer, &falconImageAnalyzer.Status, existingClusterRoleBinding)
 		if err != nil {
@@ -0,0 +1,264 @@
+package falcon
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/crowdstrike/falcon-operator/internal/controller/assets"
+	"github.com/crowdstrike/falcon-operator/pkg/common"
+	"github.com/crowdstrike/falcon-operator/pkg/node"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// baseSensors returns the FalconNodeSensor and the FalconNodeSensors derived for its node pools, before their split per backend
+func baseSensors(nodesensor *falconv1alpha1.FalconNodeSensor) []*falconv1alpha1.FalconNodeSensor {
+	sensors := []*falconv1alpha1.FalconNodeSensor{nodesensor.DeepCopy()}
+	for i := range nodesensor.GetNodePools() {
+		sensors = append(sensors, assets.NodePoolSensor(nodesensor, i))
+	}
+
+	return sensors
+}
+
+// autoBackendSensors returns the sensors using the auto backend. GKE Autopilot always runs the eBPF backend.
+func autoBackendSensors(sensors []*falconv1alpha1.FalconNodeSensor) []*falconv1alpha1.FalconNodeSensor {
+	return slices.DeleteFunc(slices.Clone(sensors), func(sensor *falconv1alpha1.FalconNodeSensor) bool {
+		return sensor.Spec.Node.Backend != node.BackendAuto || (sensor.Spec.Node.GKE.Enabled != nil && *sensor.Spec.Node.GKE.Enabled)
+	})
+}
+
+// renderedSensors returns the FalconNodeSensors rendering the DaemonSets and ConfigMaps of the sensor. The first one renders the sensor DaemonSet
+// of the FalconNodeSensor, the others the DaemonSets of its node pools and the kernel backend DaemonSets of the sensors using the auto backend.
+func renderedSensors(sensors []*falconv1alpha1.FalconNodeSensor, kernelNodes bool) ([]*falconv1alpha1.FalconNodeSensor, error) {
+	rendered := []*falconv1alpha1.FalconNodeSensor{}
+	for _, sensor := range sensors {
+		if sensor.Spec.Node.Backend != node.BackendAuto {
+			rendered = append(rendered, sensor)
+			continue
+		}
+
+		bpf, kernel := assets.AutoBackendSensors(sensor, kernelNodes)
+		rendered = append(rendered, bpf)
+		if kernel != nil {
+			rendered = append(rendered, kernel)
+		}
+	}
+
+	names := map[string]bool{}
+	for _, sensor := range rendered {
+		if names[sensor.Name] {
+			return nil, fmt.Errorf("DaemonSet %s is rendered twice, rename the node pool conflicting with the kernel backend DaemonSet of another node pool", sensor.Name)
+		}
+		names[sensor.Name] = true
+	}
+
+	return rendered, nil
+}
+
+// handleNodeBackends classifies the nodes in the scope of the sensors using the auto backend against the support matrix of the sensor,
+// records the selected backend in the falcon.crowdstrike.com/selected-backend label of each node, and reports the unsupported nodes
+// in the status. It returns whether any node runs the kernel backend.
+func (r *FalconNodeSensorReconciler) handleNodeBackends(ctx context.Context, nodesensor *falconv1alpha1.FalconNodeSensor, sensors []*falconv1alpha1.FalconNodeSensor, logger logr.Logger) (bool, error) {
+	autoSensors := autoBackendSensors(sensors)
+	if len(autoSensors) == 0 {
+		return false, r.clearNodeBackendsStatus(ctx, nodesensor, logger)
+	}
+
+	scopes := []*corev1.PodSpec{}
+	for _, sensor := range autoSensors {
+		scopes = append(scopes, &assets.Daemonset(sensor.Name, "", "", sensor).Spec.Template.Spec)
+	}
+
+	nodes := corev1.NodeList{}
+	if err := r.List(ctx, &nodes, client.MatchingLabels(common.NodeSelector)); err != nil {
+		if err = r.Reader.List(ctx, &nodes, client.MatchingLabels(common.NodeSelector)); err != nil {
+			return false, err
+		}
+	}
+
+	kernelNodes := false
+	unsupportedNodes := []string{}
+	unsupported := []falconv1alpha1.FalconNodeUnsupportedNode{}
+	for i := range nodes.Items {
+		n := &nodes.Items[i]
+		if !slices.ContainsFunc(scopes, func(spec *corev1.PodSpec) bool { return nodeEligible(spec, n) }) {
+			continue
+		}
+
+		backend, reason := node.SelectBackend(n)
+		switch backend {
+		case node.BackendKernel:
+			kernelNodes = true
+		case "":
+			backend = node.BackendUnsupported
+			unsupportedNodes = append(unsupportedNodes, n.Name)
+			unsupported = append(unsupported, falconv1alpha1.FalconNodeUnsupportedNode{Node: n.Name, Reason: reason})
+		}
+
+		if err := r.labelNodeBackend(ctx, n, backend); err != nil {
+			return false, err
+		}
+	}
+
+	// Sorted node names keep the status stable across reconciliations
+	sort.Strings(unsupportedNodes)
+	sort.Slice(unsupported, func(i, j int) bool {
+		return unsupported[i].Node < unsupported[j].Node
+	})
+
+	if len(unsupported) > 0 && !reflect.DeepEqual(nodesensor.Status.UnsupportedNodes, unsupported) {
+		logger.Info("Nodes are not supported by any backend of the sensor. The sensor will not run on them.", "Nodes", unsupportedNodes)
+	}
+
+	if err := r.unsupportedNodesStatusUpdate(ctx, nodesensor, unsupported, logger); err != nil {
+		return false, err
+	}
+
+	if len(unsupported) == 0 {
+		err := r.conditionsUpdate(falconv1alpha1.ConditionNodesSupported,
+			metav1.ConditionTrue,
+			falconv1alpha1.ReasonSucceeded,
+			"All nodes are supported by a backend of the FalconNodeSensor",
+			ctx, types.NamespacedName{Name: nodesensor.Name}, nodesensor, logger)
+		return kernelNodes, err
+	}
+
+	err := r.conditionsUpdate(falconv1alpha1.ConditionNodesSupported,
+		metav1.ConditionFalse,
+		falconv1alpha1.ReasonUnsupportedNodes,
+		"Nodes not supported by any backend of the FalconNodeSensor do not run the sensor. See status.unsupportedNodes",
+		ctx, types.NamespacedName{Name: nodesensor.Name}, nodesensor, logger)
+	return kernelNodes, err
+}
+
+// labelNodeBackend records the backend selected for the node in its falcon.crowdstrike.com/selected-backend label, which the DaemonSets
+// of the sensors using the auto backend select nodes on
+func (r *FalconNodeSensorReconciler) labelNodeBackend(ctx context.Context, n *corev1.Node, backend string) error {
+	if n.Labels[common.FalconNodeSelectedBackendKey] == backend {
+		return nil
+	}
+
+	patch := client.MergeFrom(n.DeepCopy())
+	if n.Labels == nil {
+		n.Labels = map[string]string{}
+	}
+	n.Labels[common.FalconNodeSelectedBackendKey] = backend
+
+	if err := r.Patch(ctx, n, patch); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("unable to label node %s with its backend: %w", n.Name, err)
+	}
+
+	return nil
+}
+
+// unsupportedNodesStatusUpdate records the nodes not supported by any backend in the status of the FalconNodeSensor
+func (r *FalconNodeSensorReconciler) unsupportedNodesStatusUpdate(ctx context.Context, nodesensor *falconv1alpha1.FalconNodeSensor, unsupported []falconv1alpha1.FalconNodeUnsupportedNode, logger logr.Logger) error {
+	if len(unsupported) == 0 {
+		unsupported = nil
+	}
+
+	if reflect.DeepEqual(nodesensor.Status.UnsupportedNodes, unsupported) {
+		return nil
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := r.Get(ctx, types.NamespacedName{Name: nodesensor.Name}, nodesensor)
+		if err != nil {
+			return err
+		}
+
+		nodesensor.Status.UnsupportedNodes = unsupported
+		return r.Status().Update(ctx, nodesensor)
+	})
+	if err != nil {
+		logger.Error(err, "Failed to update FalconNodeSensor status for nodesensor.Status.UnsupportedNodes")
+		return err
+	}
+
+	return nil
+}
+
+// clearNodeBackendsStatus removes the unsupported nodes and the NodesSupported condition from the status once the auto backend is no longer used
+func (r *FalconNodeSensorReconciler) clearNodeBackendsStatus(ctx context.Context, nodesensor *falconv1alpha1.FalconNodeSensor, logger logr.Logger) error {
+	if nodesensor.Status.UnsupportedNodes == nil && meta.FindStatusCondition(nodesensor.Status.Conditions, falconv1alpha1.ConditionNodesSupported) == nil {
+		return nil
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := r.Get(ctx, types.NamespacedName{Name: nodesensor.Name}, nodesensor)
+		if err != nil {
+			return err
+		}
+
+		nodesensor.Status.UnsupportedNodes = nil
+		meta.RemoveStatusCondition(&nodesensor.Status.Conditions, falconv1alpha1.ConditionNodesSupported)
+		return r.Status().Update(ctx, nodesensor)
+	})
+	if err != nil {
+		logger.Error(err, "Failed to update FalconNodeSensor status for nodesensor.Status.UnsupportedNodes")
+		return err
+	}
+
+	return nil
+}
+
+// nodeToFalconNodeSensors maps a Node to the FalconNodeSensors using the auto backend, which select the backend of the node,
+// or using tag templates, which render the sensor grouping tags of the node
+func (r *FalconNodeSensorReconciler) nodeToFalconNodeSensors(ctx context.Context, obj client.Object) []reconcile.Request {
+	nodesensors := &falconv1alpha1.FalconNodeSensorList{}
+	if err := r.List(ctx, nodesensors); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list FalconNodeSensors for node event")
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for i := range nodesensors.Items {
+		if len(autoBackendSensors(baseSensors(&nodesensors.Items[i]))) > 0 || len(nodesensors.Items[i].GetTagTemplates()) > 0 {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: nodesensors.Items[i].Name}})
+		}
+	}
+
+	return requests
+}
+
+// nodeBackendChanged filters Node events down to creations, deletions and changes of the labels or the system info, the only events affecting
+// the backend and the sensor grouping tags of a node. The backend label set by the operator is ignored.
+func nodeBackendChanged() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(event.CreateEvent) bool { return true },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldNode, okOld := e.ObjectOld.(*corev1.Node)
+			newNode, okNew := e.ObjectNew.(*corev1.Node)
+			if !okOld || !okNew {
+				return false
+			}
+
+			return !reflect.DeepEqual(userLabels(oldNode), userLabels(newNode)) || oldNode.Status.NodeInfo != newNode.Status.NodeInfo
+		},
+		DeleteFunc:  func(event.DeleteEvent) bool { return true },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
+
+// userLabels returns the labels of the node without the backend label set by the operator
+func userLabels(n *corev1.Node) map[string]string {
+	labels := maps.Clone(n.Labels)
+	delete(labels, common.FalconNodeSelectedBackendKey)
+	return labels
+}
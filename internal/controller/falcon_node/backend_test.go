@@ -0,0 +1,128 @@
+package falcon
+
+import (
+	"context"
+	"testing"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestRenderedSensors(t *testing.T) {
+	autopilot := false
+	nodesensor := &falconv1alpha1.FalconNodeSensor{ObjectMeta: metav1.ObjectMeta{Name: "falcon-node-sensor"}}
+	nodesensor.Spec.Node.Backend = "auto"
+	nodesensor.Spec.Node.GKE.Enabled = &autopilot
+	nodesensor.Spec.Node.NodePools = []falconv1alpha1.FalconNodePool{
+		{Name: "gpu", NodeSelector: map[string]string{"pool": "gpu"}, Backend: "bpf"},
+		{Name: "arm", NodeSelector: map[string]string{"kubernetes.io/arch": "arm64"}},
+	}
+
+	sensors := baseSensors(nodesensor)
+	if got := len(autoBackendSensors(sensors)); got != 2 {
+		t.Errorf("autoBackendSensors() returned %d sensors, want the sensor and the arm node pool", got)
+	}
+
+	rendered, err := renderedSensors(sensors, true)
+	if err != nil {
+		t.Fatalf("renderedSensors() error = %v", err)
+	}
+
+	names := []string{}
+	for _, sensor := range rendered {
+		names = append(names, sensor.Name+"/"+sensor.Spec.Node.Backend)
+	}
+	want := []string{
+		"falcon-node-sensor/bpf",
+		"falcon-node-sensor-kernel/kernel",
+		"falcon-node-sensor-pool-gpu/bpf",
+		"falcon-node-sensor-pool-arm/bpf",
+		"falcon-node-sensor-pool-arm-kernel/kernel",
+	}
+	if diff := cmp.Diff(want, names); diff != "" {
+		t.Errorf("renderedSensors() mismatch (-want +got): %s", diff)
+	}
+
+	// The kernel backend DaemonSet of the arm node pool conflicts with the arm-kernel node pool
+	nodesensor.Spec.Node.NodePools = append(nodesensor.Spec.Node.NodePools, falconv1alpha1.FalconNodePool{Name: "arm-kernel", NodeSelector: map[string]string{"kernel": "legacy"}})
+	if _, err := renderedSensors(baseSensors(nodesensor), true); err == nil {
+		t.Error("renderedSensors() error = nil with conflicting DaemonSet names, want an error")
+	}
+}
+
+func TestNodeBackendChanged(t *testing.T) {
+	oldNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{"pool": "general"}},
+		Status:     corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{KernelVersion: "5.15.0"}},
+	}
+
+	newNode := oldNode.DeepCopy()
+	newNode.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}}
+	if nodeBackendChanged().Update(event.UpdateEvent{ObjectOld: oldNode, ObjectNew: newNode}) {
+		t.Error("nodeBackendChanged() = true for a node condition change, want false")
+	}
+
+	newNode.Labels = map[string]string{"pool": "general", "falcon.crowdstrike.com/selected-backend": "bpf"}
+	if nodeBackendChanged().Update(event.UpdateEvent{ObjectOld: oldNode, ObjectNew: newNode}) {
+		t.Error("nodeBackendChanged() = true for a change of the backend label set by the operator, want false")
+	}
+
+	newNode.Status.NodeInfo.KernelVersion = "6.1.0"
+	if !nodeBackendChanged().Update(event.UpdateEvent{ObjectOld: oldNode, ObjectNew: newNode}) {
+		t.Error("nodeBackendChanged() = false for a kernel upgrade, want true")
+	}
+}
+
+func TestHandleNodeBackends_LabelsNodes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := falconv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	autopilot := false
+	nodesensor := &falconv1alpha1.FalconNodeSensor{ObjectMeta: metav1.ObjectMeta{Name: "falcon-node-sensor"}}
+	nodesensor.Spec.Node.Backend = "auto"
+	nodesensor.Spec.Node.GKE.Enabled = &autopilot
+
+	linuxNode := func(name, kernel string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"kubernetes.io/os": "linux"}},
+			Status:     corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{KernelVersion: kernel, Architecture: "amd64"}},
+		}
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(nodesensor, linuxNode("node-bpf", "6.1.0"), linuxNode("node-kernel", "4.4.0"), linuxNode("node-legacy", "2.6.32")).
+		WithStatusSubresource(nodesensor).Build()
+	r := &FalconNodeSensorReconciler{Client: c, Reader: c, Scheme: scheme}
+
+	kernelNodes, err := r.handleNodeBackends(context.Background(), nodesensor, baseSensors(nodesensor), logr.Discard())
+	if err != nil {
+		t.Fatalf("handleNodeBackends() error = %v", err)
+	}
+	if !kernelNodes {
+		t.Error("handleNodeBackends() = false, want kernel nodes")
+	}
+
+	want := map[string]string{"node-bpf": "bpf", "node-kernel": "kernel", "node-legacy": "unsupported"}
+	for name, backend := range want {
+		n := &corev1.Node{}
+		if err := c.Get(context.Background(), types.NamespacedName{Name: name}, n); err != nil {
+			t.Fatal(err)
+		}
+		if got := n.Labels["falcon.crowdstrike.com/selected-backend"]; got != backend {
+			t.Errorf("handleNodeBackends() labeled node %s with backend %q, want %q", name, got, backend)
+		}
+	}
+}
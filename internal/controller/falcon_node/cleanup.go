@@ -0,0 +1,144 @@
+package falcon
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	k8sutils "github.com/crowdstrike/falcon-operator/internal/controller/common"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// cleanupRequeueInterval is the interval at which the progress of the node cleanup is checked
+	cleanupRequeueInterval = 10 * time.Second
+
+	// defaultCleanupTimeout is the cleanup timeout used when the FalconNodeSensor does not set one
+	defaultCleanupTimeout = 5 * time.Minute
+)
+
+// cleanupTimeout returns the maximum time to wait for the cleanup of the nodes
+func cleanupTimeout(nodesensor *falconv1alpha1.FalconNodeSensor) time.Duration {
+	if nodesensor.Spec.Node.CleanupTimeout == nil {
+		return defaultCleanupTimeout
+	}
+
+	return nodesensor.Spec.Node.CleanupTimeout.Duration
+}
+
+// cleanupResults returns the result of the cleanup on each node from the pods of the cleanup DaemonSet, sorted by node name
+func cleanupResults(pods []corev1.Pod) []falconv1alpha1.FalconNodeCleanupResult {
+	states := map[string]falconv1alpha1.FalconNodeCleanupState{}
+	for i := range pods {
+		pod := &pods[i]
+		node := podNodeName(pod)
+		if node == "" {
+			continue
+		}
+
+		// The pods should be running the sleep command once they have cleaned up /opt/CrowdStrike
+		state := falconv1alpha1.FalconNodeCleanupPending
+		switch pod.Status.Phase {
+		case corev1.PodRunning, corev1.PodSucceeded:
+			state = falconv1alpha1.FalconNodeCleanupCompleted
+		case corev1.PodFailed:
+			state = falconv1alpha1.FalconNodeCleanupFailed
+		case corev1.PodPending:
+			if k8sutils.IsInitPodCrashLooping(pod) {
+				state = falconv1alpha1.FalconNodeCleanupFailed
+			}
+		}
+
+		// A replaced pod may still be terminating on the node, so the most advanced state wins
+		if current, ok := states[node]; !ok || current != falconv1alpha1.FalconNodeCleanupCompleted {
+			states[node] = state
+		}
+	}
+
+	results := make([]falconv1alpha1.FalconNodeCleanupResult, 0, len(states))
+	for node, state := range states {
+		results = append(results, falconv1alpha1.FalconNodeCleanupResult{Node: node, State: state})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Node < results[j].Node
+	})
+
+	return results
+}
+
+// podNodeName returns the node of a DaemonSet pod. Pods not scheduled yet are pinned to their node by the node affinity set by the DaemonSet controller.
+func podNodeName(pod *corev1.Pod) string {
+	if pod.Spec.NodeName != "" {
+		return pod.Spec.NodeName
+	}
+
+	if pod.Spec.Affinity == nil || pod.Spec.Affinity.NodeAffinity == nil || pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return ""
+	}
+
+	for _, term := range pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		for _, field := range term.MatchFields {
+			if field.Key == "metadata.name" && field.Operator == corev1.NodeSelectorOpIn && len(field.Values) == 1 {
+				return field.Values[0]
+			}
+		}
+	}
+
+	return ""
+}
+
+// cleanupDone reports whether every node scheduled to run the cleanup DaemonSet has completed or failed its cleanup
+func cleanupDone(ds *appsv1.DaemonSet, results []falconv1alpha1.FalconNodeCleanupResult) bool {
+	if ds.Status.ObservedGeneration < ds.Generation {
+		return false
+	}
+
+	finished := countCleanupState(results, falconv1alpha1.FalconNodeCleanupCompleted) + countCleanupState(results, falconv1alpha1.FalconNodeCleanupFailed)
+	return int32(finished) >= ds.Status.DesiredNumberScheduled
+}
+
+// timeOutPendingNodes marks the nodes still waiting for their cleanup as timed out
+func timeOutPendingNodes(results []falconv1alpha1.FalconNodeCleanupResult) {
+	for i := range results {
+		if results[i].State == falconv1alpha1.FalconNodeCleanupPending {
+			results[i].State = falconv1alpha1.FalconNodeCleanupTimedOut
+		}
+	}
+}
+
+// countCleanupState returns the number of nodes in the given cleanup state
+func countCleanupState(results []falconv1alpha1.FalconNodeCleanupResult, state falconv1alpha1.FalconNodeCleanupState) int {
+	count := 0
+	for _, result := range results {
+		if result.State == state {
+			count++
+		}
+	}
+
+	return count
+}
+
+// cleanupStatusUpdate records the progress of the node cleanup in the status of the FalconNodeSensor
+func (r *FalconNodeSensorReconciler) cleanupStatusUpdate(ctx context.Context, nsType types.NamespacedName, nodesensor *falconv1alpha1.FalconNodeSensor, cleanup *falconv1alpha1.FalconNodeCleanupStatus, logger logr.Logger) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := r.Get(ctx, nsType, nodesensor)
+		if err != nil {
+			return err
+		}
+
+		nodesensor.Status.Cleanup = cleanup
+		return r.Status().Update(ctx, nodesensor)
+	})
+	if err != nil {
+		logger.Error(err, "Failed to update FalconNodeSensor status for nodesensor.Status.Cleanup")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package falcon
+
+import (
+	"context"
+	"testing"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/crowdstrike/falcon-operator/pkg/common"
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func cleanupPod(node string, phase corev1.PodPhase) corev1.Pod {
+	return corev1.Pod{Spec: corev1.PodSpec{NodeName: node}, Status: corev1.PodStatus{Phase: phase}}
+}
+
+func TestCleanupResults(t *testing.T) {
+	crashlooping := cleanupPod("node-c", corev1.PodPending)
+	crashlooping.Status.InitContainerStatuses = []corev1.ContainerStatus{
+		{State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+	}
+
+	unscheduled := cleanupPod("", corev1.PodPending)
+	unscheduled.Spec.Affinity = &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{MatchFields: []corev1.NodeSelectorRequirement{{Key: "metadata.name", Operator: corev1.NodeSelectorOpIn, Values: []string{"node-a"}}}},
+				},
+			},
+		},
+	}
+
+	pods := []corev1.Pod{
+		cleanupPod("node-d", corev1.PodFailed),
+		cleanupPod("node-b", corev1.PodRunning),
+		crashlooping,
+		unscheduled,
+		cleanupPod("node-e", corev1.PodSucceeded),
+		cleanupPod("node-e", corev1.PodPending),
+		cleanupPod("", corev1.PodPending),
+	}
+
+	want := []falconv1alpha1.FalconNodeCleanupResult{
+		{Node: "node-a", State: falconv1alpha1.FalconNodeCleanupPending},
+		{Node: "node-b", State: falconv1alpha1.FalconNodeCleanupCompleted},
+		{Node: "node-c", State: falconv1alpha1.FalconNodeCleanupFailed},
+		{Node: "node-d", State: falconv1alpha1.FalconNodeCleanupFailed},
+		{Node: "node-e", State: falconv1alpha1.FalconNodeCleanupCompleted},
+	}
+	if diff := cmp.Diff(want, cleanupResults(pods)); diff != "" {
+		t.Errorf("cleanupResults() mismatch (-want +got): %s", diff)
+	}
+}
+
+func TestCleanupDone(t *testing.T) {
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Generation: 1},
+		Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: 2},
+	}
+	results := []falconv1alpha1.FalconNodeCleanupResult{
+		{Node: "node-a", State: falconv1alpha1.FalconNodeCleanupCompleted},
+		{Node: "node-b", State: falconv1alpha1.FalconNodeCleanupPending},
+	}
+
+	// The DaemonSet controller has not scheduled the cleanup pods yet
+	if cleanupDone(ds, nil) {
+		t.Error("cleanupDone() = true with an unobserved generation, want false")
+	}
+
+	ds.Status.ObservedGeneration = 1
+	if cleanupDone(ds, results) {
+		t.Error("cleanupDone() = true with a pending node, want false")
+	}
+
+	results[1].State = falconv1alpha1.FalconNodeCleanupFailed
+	if !cleanupDone(ds, results) {
+		t.Error("cleanupDone() = false with all nodes finished, want true")
+	}
+}
+
+func TestTimeOutPendingNodes(t *testing.T) {
+	results := []falconv1alpha1.FalconNodeCleanupResult{
+		{Node: "node-a", State: falconv1alpha1.FalconNodeCleanupCompleted},
+		{Node: "node-b", State: falconv1alpha1.FalconNodeCleanupPending},
+		{Node: "node-c", State: falconv1alpha1.FalconNodeCleanupFailed},
+	}
+
+	timeOutPendingNodes(results)
+
+	want := []falconv1alpha1.FalconNodeCleanupResult{
+		{Node: "node-a", State: falconv1alpha1.FalconNodeCleanupCompleted},
+		{Node: "node-b", State: falconv1alpha1.FalconNodeCleanupTimedOut},
+		{Node: "node-c", State: falconv1alpha1.FalconNodeCleanupFailed},
+	}
+	if diff := cmp.Diff(want, results); diff != "" {
+		t.Errorf("timeOutPendingNodes() mismatch (-want +got): %s", diff)
+	}
+}
+
+func TestHandleDeletion_WithForcedFinalization(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := falconv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	disableCleanup := false
+	now := metav1.Now()
+	nodesensor := &falconv1alpha1.FalconNodeSensor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "falcon-node-sensor",
+			Annotations:       map[string]string{common.FalconForceFinalizeKey: "true"},
+			Finalizers:        []string{common.FalconFinalizer},
+			DeletionTimestamp: &now,
+		},
+	}
+	nodesensor.Spec.InstallNamespace = "falcon-system"
+	nodesensor.Spec.Node.NodeCleanup = &disableCleanup
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(nodesensor).WithStatusSubresource(nodesensor).Build()
+	r := &FalconNodeSensorReconciler{Client: c, Reader: c, Scheme: scheme}
+
+	// No image is needed since the cleanup DaemonSet is never created when the finalization is forced
+	if _, err := r.handleDeletion(context.Background(), "", nodesensor, logr.Discard()); err != nil {
+		t.Fatalf("handleDeletion() error: %v", err)
+	}
+
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "falcon-node-sensor-cleanup", Namespace: "falcon-system"}, &appsv1.DaemonSet{}); !errors.IsNotFound(err) {
+		t.Errorf("handleDeletion() created the cleanup DaemonSet, err = %v", err)
+	}
+
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "falcon-node-sensor"}, &falconv1alpha1.FalconNodeSensor{}); !errors.IsNotFound(err) {
+		t.Errorf("handleDeletion() did not remove the finalizer, err = %v", err)
+	}
+}
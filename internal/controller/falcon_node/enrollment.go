@@ -0,0 +1,238 @@
+package falcon
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/crowdstrike/falcon-operator/pkg/common"
+	"github.com/crowdstrike/falcon-operator/pkg/node"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// enrollmentCheckInterval is the interval between two queries of the Falcon Hosts API, which is rate limited
+	enrollmentCheckInterval = 10 * time.Minute
+
+	// hostReportingWindow is the time since a host last reported after which it is no longer considered reporting
+	hostReportingWindow = time.Hour
+
+	// maxEnrollmentNodes bounds the size of the enrollment status on large clusters
+	maxEnrollmentNodes = 1000
+)
+
+// handleNodeEnrollment periodically queries the Falcon Hosts API for the hosts of the nodes running the sensor and reports their enrollment
+// in the status. It returns the time until the next query.
+func (r *FalconNodeSensorReconciler) handleNodeEnrollment(ctx context.Context, config *node.ConfigCache, nodesensor *falconv1alpha1.FalconNodeSensor, daemonsets []*appsv1.DaemonSet, logger logr.Logger) (time.Duration, error) {
+	if !config.FalconAPIConfigured() {
+		return 0, r.enrollmentStatusUpdate(ctx, nodesensor, nil, logger)
+	}
+
+	if enrollment := nodesensor.Status.Enrollment; enrollment != nil {
+		if next := time.Until(enrollment.LastCheckTime.Add(enrollmentCheckInterval)); next > 0 {
+			return next, nil
+		}
+	}
+
+	nodes, err := r.sensorNodes(ctx, daemonsets)
+	if err != nil {
+		return 0, err
+	}
+
+	hostnames := []string{}
+	for _, n := range nodes {
+		hostnames = append(hostnames, nodeHostnames(n)...)
+	}
+
+	devices, err := config.GetHosts(ctx, hostnames)
+	if err != nil {
+		logger.Error(err, "Failed to query the hosts of the nodes from the Falcon Hosts API")
+
+		// The last results are kept, and the failed query is recorded so that the API is not queried again before the next check
+		failed := &falconv1alpha1.FalconNodeEnrollmentStatus{}
+		if nodesensor.Status.Enrollment != nil {
+			failed = nodesensor.Status.Enrollment.DeepCopy()
+		}
+		failed.LastCheckTime = metav1.Now()
+		if err := r.enrollmentStatusUpdate(ctx, nodesensor, failed, logger); err != nil {
+			return 0, err
+		}
+
+		return enrollmentCheckInterval, r.conditionsUpdate(falconv1alpha1.ConditionNodesReporting,
+			metav1.ConditionUnknown,
+			falconv1alpha1.ReasonHostsQueryFailed,
+			"The hosts of the nodes could not be queried from the Falcon Hosts API: "+err.Error(),
+			ctx, types.NamespacedName{Name: nodesensor.Name}, nodesensor, logger)
+	}
+
+	enrollment := nodeEnrollment(nodes, devices, config.CID(), time.Now())
+	if err := r.enrollmentStatusUpdate(ctx, nodesensor, enrollment, logger); err != nil {
+		return 0, err
+	}
+
+	if enrollment.NotReportingNodes == 0 {
+		return enrollmentCheckInterval, r.conditionsUpdate(falconv1alpha1.ConditionNodesReporting,
+			metav1.ConditionTrue,
+			falconv1alpha1.ReasonSucceeded,
+			"The sensors of all the nodes report to the Falcon platform",
+			ctx, types.NamespacedName{Name: nodesensor.Name}, nodesensor, logger)
+	}
+
+	return enrollmentCheckInterval, r.conditionsUpdate(falconv1alpha1.ConditionNodesReporting,
+		metav1.ConditionFalse,
+		falconv1alpha1.ReasonNodesNotReporting,
+		"Nodes running the sensor have no host reporting to the Falcon platform. See status.enrollment",
+		ctx, types.NamespacedName{Name: nodesensor.Name}, nodesensor, logger)
+}
+
+// sensorNodes returns the nodes running a pod of the sensor DaemonSets
+func (r *FalconNodeSensorReconciler) sensorNodes(ctx context.Context, daemonsets []*appsv1.DaemonSet) ([]*corev1.Node, error) {
+	installed := map[string]bool{}
+	for _, ds := range daemonsets {
+		names, err := r.nodesWithSensor(ctx, ds)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range names {
+			installed[name] = true
+		}
+	}
+
+	list := corev1.NodeList{}
+	if err := r.List(ctx, &list, client.MatchingLabels(common.NodeSelector)); err != nil {
+		if err = r.Reader.List(ctx, &list, client.MatchingLabels(common.NodeSelector)); err != nil {
+			return nil, err
+		}
+	}
+
+	nodes := []*corev1.Node{}
+	for i := range list.Items {
+		if installed[list.Items[i].Name] {
+			nodes = append(nodes, &list.Items[i])
+		}
+	}
+
+	return nodes, nil
+}
+
+// nodeHostnames returns the hostnames the sensor of a node may report: the node name and the hostname address of the node,
+// and their short forms, in lowercase
+func nodeHostnames(n *corev1.Node) []string {
+	names := []string{n.Name}
+	for _, address := range n.Status.Addresses {
+		if address.Type == corev1.NodeHostName {
+			names = append(names, address.Address)
+		}
+	}
+
+	hostnames := []string{}
+	for _, name := range names {
+		name = strings.ToLower(name)
+		short, _, _ := strings.Cut(name, ".")
+		for _, hostname := range []string{name, short} {
+			if hostname != "" && !slices.Contains(hostnames, hostname) {
+				hostnames = append(hostnames, hostname)
+			}
+		}
+	}
+
+	return hostnames
+}
+
+// nodeEnrollment matches the nodes to the most recently seen host of the CID with one of their hostnames
+func nodeEnrollment(nodes []*corev1.Node, devices []*models.DeviceapiDeviceSwagger, cid string, now time.Time) *falconv1alpha1.FalconNodeEnrollmentStatus {
+	cid = hostCID(cid)
+
+	enrollment := &falconv1alpha1.FalconNodeEnrollmentStatus{LastCheckTime: metav1.NewTime(now)}
+	for _, n := range nodes {
+		hostnames := nodeHostnames(n)
+		result := falconv1alpha1.FalconNodeEnrollment{Node: n.Name}
+
+		for _, device := range devices {
+			if device == nil || device.DeviceID == nil || !slices.Contains(hostnames, strings.ToLower(device.Hostname)) {
+				continue
+			}
+			if cid != "" && device.Cid != nil && strings.ToLower(*device.Cid) != cid {
+				continue
+			}
+
+			lastSeen, err := time.Parse(time.RFC3339, device.LastSeen)
+			if err != nil {
+				continue
+			}
+			if result.LastSeen != nil && !lastSeen.After(result.LastSeen.Time) {
+				continue
+			}
+
+			seen := metav1.NewTime(lastSeen)
+			result.AID = *device.DeviceID
+			result.LastSeen = &seen
+			result.SensorVersion = device.AgentVersion
+		}
+
+		result.Reporting = result.LastSeen != nil && now.Sub(result.LastSeen.Time) <= hostReportingWindow
+		if result.Reporting {
+			enrollment.ReportingNodes++
+		} else {
+			enrollment.NotReportingNodes++
+		}
+		enrollment.Nodes = append(enrollment.Nodes, result)
+	}
+
+	sort.Slice(enrollment.Nodes, func(i, j int) bool {
+		if enrollment.Nodes[i].Reporting != enrollment.Nodes[j].Reporting {
+			return !enrollment.Nodes[i].Reporting
+		}
+		return enrollment.Nodes[i].Node < enrollment.Nodes[j].Node
+	})
+	if len(enrollment.Nodes) > maxEnrollmentNodes {
+		enrollment.Nodes = enrollment.Nodes[:maxEnrollmentNodes]
+	}
+
+	return enrollment
+}
+
+// hostCID returns the CID as reported by hosts: without its checksum, in lowercase
+func hostCID(cid string) string {
+	cid, _, _ = strings.Cut(strings.ToLower(cid), "-")
+	return cid
+}
+
+// enrollmentStatusUpdate records the enrollment of the nodes in the status of the FalconNodeSensor. A nil enrollment removes it,
+// along with the NodesReporting condition.
+func (r *FalconNodeSensorReconciler) enrollmentStatusUpdate(ctx context.Context, nodesensor *falconv1alpha1.FalconNodeSensor, enrollment *falconv1alpha1.FalconNodeEnrollmentStatus, logger logr.Logger) error {
+	if enrollment == nil && nodesensor.Status.Enrollment == nil && meta.FindStatusCondition(nodesensor.Status.Conditions, falconv1alpha1.ConditionNodesReporting) == nil {
+		return nil
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := r.Get(ctx, types.NamespacedName{Name: nodesensor.Name}, nodesensor)
+		if err != nil {
+			return err
+		}
+
+		nodesensor.Status.Enrollment = enrollment
+		if enrollment == nil {
+			meta.RemoveStatusCondition(&nodesensor.Status.Conditions, falconv1alpha1.ConditionNodesReporting)
+		}
+		return r.Status().Update(ctx, nodesensor)
+	})
+	if err != nil {
+		logger.Error(err, "Failed to update FalconNodeSensor status for nodesensor.Status.Enrollment")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package falcon
+
+import (
+	"testing"
+	"time"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNodeHostnames(t *testing.T) {
+	n := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "ip-10-0-0-1.ec2.internal"},
+		Status: corev1.NodeStatus{Addresses: []corev1.NodeAddress{
+			{Type: corev1.NodeInternalIP, Address: "10.0.0.1"},
+			{Type: corev1.NodeHostName, Address: "IP-10-0-0-1.ec2.internal"},
+		}},
+	}
+
+	want := []string{"ip-10-0-0-1.ec2.internal", "ip-10-0-0-1"}
+	if diff := cmp.Diff(want, nodeHostnames(n)); diff != "" {
+		t.Errorf("nodeHostnames() mismatch (-want +got): %s", diff)
+	}
+}
+
+func TestNodeEnrollment(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	device := func(aid, cid, hostname string, lastSeen time.Time, version string) *models.DeviceapiDeviceSwagger {
+		return &models.DeviceapiDeviceSwagger{DeviceID: &aid, Cid: &cid, Hostname: hostname, LastSeen: lastSeen.Format(time.RFC3339), AgentVersion: version}
+	}
+
+	nodes := []*corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-b.example.com"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-c"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-d"}},
+	}
+	devices := []*models.DeviceapiDeviceSwagger{
+		device("aid-a-old", "0123456789abcdef", "node-a", now.Add(-48*time.Hour), "7.10.0"),
+		device("aid-a", "0123456789abcdef", "node-a", now.Add(-5*time.Minute), "7.11.0"),
+		device("aid-b", "0123456789abcdef", "NODE-B", now.Add(-2*time.Hour), "7.11.0"),
+		device("aid-c-other", "fedcba9876543210", "node-c", now, "7.11.0"),
+	}
+
+	got := nodeEnrollment(nodes, devices, "0123456789ABCDEF-12", now)
+
+	lastSeenA := metav1.NewTime(now.Add(-5 * time.Minute))
+	lastSeenB := metav1.NewTime(now.Add(-2 * time.Hour))
+	want := &falconv1alpha1.FalconNodeEnrollmentStatus{
+		LastCheckTime:     metav1.NewTime(now),
+		ReportingNodes:    1,
+		NotReportingNodes: 3,
+		Nodes: []falconv1alpha1.FalconNodeEnrollment{
+			{Node: "node-b.example.com", AID: "aid-b", LastSeen: &lastSeenB, SensorVersion: "7.11.0"},
+			{Node: "node-c"},
+			{Node: "node-d"},
+			{Node: "node-a", AID: "aid-a", LastSeen: &lastSeenA, SensorVersion: "7.11.0", Reporting: true},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("nodeEnrollment() mismatch (-want +got): %s", diff)
+	}
+}
@@ -4,11 +4,12 @@ import (
 	"context"
 	"fmt"
 	"reflect"
-	"slices"
+	"time"
 
 	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
 	"github.com/crowdstrike/falcon-operator/internal/controller/assets"
 	k8sutils "github.com/crowdstrike/falcon-operator/internal/controller/common"
+	"github.com/crowdstrike/falcon-operator/internal/controller/common/pullsecret"
 	"github.com/crowdstrike/falcon-operator/internal/controller/common/sensorversion"
 	"github.com/crowdstrike/falcon-operator/pkg/common"
 	"github.com/crowdstrike/falcon-operator/pkg/k8s_utils"
@@ -28,11 +29,15 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	clog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 // FalconNodeSensorReconciler reconciles a FalconNodeSensor object
@@ -41,17 +46,24 @@ type FalconNodeSensorReconciler struct {
 	Reader          client.Reader
 	Log             logr.Logger
 	Scheme          *runtime.Scheme
+	Recorder        record.EventRecorder
 	reconcileObject func(client.Object)
 	tracker         sensorversion.Tracker
+	deletedNodes    deletedNodeTracker
+
+	pullSecretRefresher *pullsecret.Refresher
 }
 
 // SetupWithManager sets up the controller with the Manager.
-func (r *FalconNodeSensorReconciler) SetupWithManager(mgr ctrl.Manager, tracker sensorversion.Tracker) error {
+func (r *FalconNodeSensorReconciler) SetupWithManager(mgr ctrl.Manager, tracker sensorversion.Tracker, pullSecretRefresher *pullsecret.Refresher) error {
 	nodeSensorController, err := ctrl.NewControllerManagedBy(mgr).
 		For(&falconv1alpha1.FalconNodeSensor{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&appsv1.DaemonSet{}).
 		Owns(&corev1.Secret{}).
+		Watches(&falconv1alpha1.FalconConfig{}, handler.EnqueueRequestsFromMapFunc(r.falconConfigToFalconNodeSensors)).
+		Watches(&corev1.Node{}, handler.EnqueueRequestsFromMapFunc(r.nodeToFalconNodeSensors), builder.WithPredicates(nodeBackendChanged())).
+		Watches(&corev1.Node{}, r.nodeDeletionHandler()).
 		Build(r)
 	if err != nil {
 		return err
@@ -63,9 +75,15 @@ func (r *FalconNodeSensorReconciler) SetupWithManager(mgr ctrl.Manager, tracker
 	}
 
 	r.tracker = tracker
+	r.pullSecretRefresher = pullSecretRefresher
 	return nil
 }
 
+// falconConfigToFalconNodeSensors maps a FalconConfig to the FalconNodeSensor custom resources referencing it
+func (r *FalconNodeSensorReconciler) falconConfigToFalconNodeSensors(ctx context.Context, obj client.Object) []reconcile.Request {
+	return k8sutils.FalconConfigRequests(ctx, r.Client, &falconv1alpha1.FalconNodeSensorList{}, obj)
+}
+
 func (r *FalconNodeSensorReconciler) GetK8sClient() client.Client {
 	return r.Client
 }
@@ -74,11 +92,13 @@ func (r *FalconNodeSensorReconciler) GetK8sReader() client.Reader {
 	return r.Reader
 }
 
-// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;delete;deletecollection
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;create;delete;patch
 
 //+kubebuilder:rbac:groups=falcon.crowdstrike.com,resources=falconnodesensors,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=falcon.crowdstrike.com,resources=falconnodesensors/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=falcon.crowdstrike.com,resources=falconnodesensors/finalizers,verbs=update
+//+kubebuilder:rbac:groups=falcon.crowdstrike.com,resources=falconconfigs,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 //+kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;create;update
@@ -88,6 +108,7 @@ func (r *FalconNodeSensorReconciler) GetK8sReader() client.Reader {
 //+kubebuilder:rbac:groups="security.openshift.io",resources=securitycontextconstraints,resourceNames=privileged,verbs=use
 //+kubebuilder:rbac:groups="scheduling.k8s.io",resources=priorityclasses,verbs=get;list;watch;create;delete;update
 //+kubebuilder:rbac:groups="",resources=pods;services;nodes;daemonsets;replicasets;deployments;jobs;ingresses;cronjobs;persistentvolumes,verbs=get;watch;list
+//+kubebuilder:rbac:groups="",resources=nodes,verbs=patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -106,6 +127,7 @@ func (r *FalconNodeSensorReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	if err != nil {
 		if errors.IsNotFound(err) {
 			r.tracker.StopTracking(req.NamespacedName)
+			r.pullSecretRefresher.Unregister("FalconNodeSensor", req.NamespacedName)
 
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
@@ -118,7 +140,16 @@ func (r *FalconNodeSensorReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, err
 	}
 
-	validate, err := k8sutils.CheckRunningPodLabels(r.Reader, ctx, nodesensor.Spec.InstallNamespace, common.CRLabels("daemonset", nodesensor.Name, common.FalconKernelSensor))
+	r.pullSecretRefresher.Register("FalconNodeSensor", req.NamespacedName, r.registryPullSecrets().Handler(r.Client))
+
+	// Forced finalizations and disabled cleanups need neither the Falcon API nor the registry, whose failures may be the reason
+	// the deletion is stuck, so they are handled before any step depending on them
+	if nodesensor.GetDeletionTimestamp() != nil && (forceFinalize(nodesensor) || *nodesensor.Spec.Node.NodeCleanup) {
+		return r.handleDeletion(ctx, "", nodesensor, logger)
+	}
+
+	// Several FalconNodeSensors, for example one per node pool, may share an install namespace, so only pods that do not belong to any node sensor are rejected
+	validate, err := k8sutils.CheckRunningPodLabels(r.Reader, ctx, nodesensor.Spec.InstallNamespace, client.MatchingLabels{common.FalconComponentKey: common.FalconKernelSensor, common.FalconProviderKey: common.FalconProviderValue})
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -193,31 +224,67 @@ func (r *FalconNodeSensorReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		}
 	}
 
-	if shouldTrackSensorVersions(nodesensor) {
-		apiConfig, apiConfigErr := nodesensor.Spec.FalconAPI.ApiConfigWithSecret(ctx, r.Reader, nodesensor.Spec.FalconSecret)
+	// The referenced FalconConfig and the Falcon secret are merged into a copy of the FalconNodeSensor, so that the credentials
+	// they hold are never written back to the API server and are not lost when status updates refresh the FalconNodeSensor
+	effective, err := k8sutils.ResolveFalconConfig(ctx, r, nodesensor)
+	if err != nil {
+		logger.Error(err, "Failed to merge FalconConfig")
+		return ctrl.Result{}, err
+	}
+
+	if shouldTrackSensorVersions(effective) {
+		apiConfig, apiConfigErr := effective.Spec.FalconAPI.ApiConfigWithSecret(ctx, r.Reader, effective.Spec.FalconSecret)
 		if apiConfigErr != nil {
 			return ctrl.Result{}, apiConfigErr
 		}
 
 		getSensorVersion := sensorversion.NewFalconCloudQuery(falcon.NodeSensor, apiConfig)
-		r.tracker.Track(req.NamespacedName, getSensorVersion, r.reconcileObjectWithName, nodesensor.Spec.Node.Advanced.IsAutoUpdatingForced())
+		r.tracker.Track(req.NamespacedName, getSensorVersion, r.reconcileObjectWithName, effective.Spec.Node.Advanced.IsAutoUpdatingForced())
 	} else {
 		r.tracker.StopTracking(req.NamespacedName)
 	}
 
 	// Inject Falcon secrets before handling config map updates
-	if nodesensor.Spec.FalconSecret.Enabled {
-		if err = r.injectFalconSecretData(ctx, nodesensor, logger); err != nil {
+	if effective.Spec.FalconSecret.Enabled {
+		if err = r.injectFalconSecretData(ctx, effective, logger); err != nil {
 			return ctrl.Result{}, err
 		}
 	}
 
-	config, err := node.NewConfigCache(ctx, nodesensor)
+	config, err := node.NewConfigCache(ctx, effective)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	sensors := baseSensors(effective)
+	kernelNodes, err := r.handleNodeBackends(ctx, nodesensor, sensors, logger)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	sensors, err = renderedSensors(sensors, kernelNodes)
 	if err != nil {
+		logger.Error(err, "Failed to render the sensor DaemonSets")
+		err = r.conditionsUpdate(falconv1alpha1.ConditionFailed,
+			metav1.ConditionFalse,
+			falconv1alpha1.ReasonReqNotMet,
+			err.Error(),
+			ctx, req.NamespacedName, nodesensor, logger)
+		return ctrl.Result{}, err
+	}
+	mainSensor := sensors[0]
+
+	if err := r.handleNodeTags(ctx, nodesensor, sensors, logger); err != nil {
+		logger.Error(err, "Failed to render the sensor grouping tags of the nodes")
+		err = r.conditionsUpdate(falconv1alpha1.ConditionFailed,
+			metav1.ConditionFalse,
+			falconv1alpha1.ReasonReqNotMet,
+			err.Error(),
+			ctx, req.NamespacedName, nodesensor, logger)
 		return ctrl.Result{}, err
 	}
 
-	sensorConf, updated, err := r.handleConfigMaps(ctx, config, nodesensor, logger)
+	sensorConf, updated, err := r.handleConfigMaps(ctx, config.WithNodeSensor(mainSensor), nodesensor, logger)
 	if err != nil {
 		err = r.conditionsUpdate(falconv1alpha1.ConditionFailed,
 			metav1.ConditionFalse,
@@ -271,12 +338,28 @@ func (r *FalconNodeSensorReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, err
 	}
 
+	// Check if the FalconNodeSensor instance is marked to be deleted, which is
+	// indicated by the deletion timestamp being set.
+	if nodesensor.GetDeletionTimestamp() != nil {
+		return r.handleDeletion(ctx, image, nodesensor, logger)
+	}
+
 	// Check if the daemonset already exists, if not create a new one
 	daemonset := &appsv1.DaemonSet{}
+	var requeueAfter time.Duration
 
 	err = common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: nodesensor.Name, Namespace: nodesensor.Spec.InstallNamespace}, daemonset)
 	if err != nil && errors.IsNotFound(err) {
-		ds := assets.Daemonset(nodesensor.Name, image, serviceAccount, nodesensor)
+		ds := assets.Daemonset(nodesensor.Name, image, serviceAccount, mainSensor)
+		if err := assets.ApplyPodTemplateOverride(&ds.Spec.Template, nodesensor.Spec.Node.PodTemplateOverride); err != nil {
+			logger.Error(err, "Failed to apply the DaemonSet pod template override")
+			return ctrl.Result{}, err
+		}
+		assets.ApplyResourceMetadata(ds, nodesensor.Spec.ResourceMetadata)
+
+		if err := setConfigHash(ds, sensorConf); err != nil {
+			return ctrl.Result{}, err
+		}
 
 		err := controllerutil.SetControllerReference(nodesensor, ds, r.Scheme)
 		if err != nil {
@@ -323,45 +406,59 @@ func (r *FalconNodeSensorReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		logger.Error(err, "error getting DaemonSet")
 		return ctrl.Result{}, err
 	} else {
-		// Copy Daemonset for updates
-		dsUpdate := daemonset.DeepCopy()
-		dsTarget := assets.Daemonset(dsUpdate.Name, image, serviceAccount, nodesensor)
-
-		// Objects to check for updates to re-spin pods
-		imgUpdate := updateDaemonSetImages(dsUpdate, image, logger)
-		affUpdate := updateDaemonSetAffinity(dsUpdate, nodesensor, logger)
-		containerVolUpdate := updateDaemonSetContainerVolumes(dsUpdate, dsTarget, logger)
-		volumeUpdates := updateDaemonSetVolumes(dsUpdate, dsTarget, logger)
-		resources := updateDaemonSetResources(dsUpdate, dsTarget, logger)
-		initResources := updateDaemonSetInitContainerResources(dsUpdate, dsTarget, logger)
-		pc := updateDaemonSetPriorityClass(dsUpdate, dsTarget, logger)
-		capabilities := updateDaemonSetCapabilities(dsUpdate, dsTarget, logger)
-		initArgs := updateDaemonSetInitArgs(dsUpdate, dsTarget, logger)
-		proxyUpdates := updateDaemonSetContainerProxy(dsUpdate, logger)
-		tolsUpdate, err := r.updateDaemonSetTolerations(ctx, dsUpdate, nodesensor, logger)
+		dsTarget := assets.Daemonset(daemonset.Name, image, serviceAccount, mainSensor)
+		if err := assets.ApplyPodTemplateOverride(&dsTarget.Spec.Template, nodesensor.Spec.Node.PodTemplateOverride); err != nil {
+			logger.Error(err, "Failed to apply the DaemonSet pod template override")
+			return ctrl.Result{}, err
+		}
+		assets.ApplyResourceMetadata(dsTarget, nodesensor.Spec.ResourceMetadata)
+
+		if err := setConfigHash(dsTarget, sensorConf); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if len(proxy.ReadProxyVarsFromEnv()) > 0 {
+			for i, container := range dsTarget.Spec.Template.Spec.Containers {
+				dsTarget.Spec.Template.Spec.Containers[i].Env = append(container.Env, proxy.ReadProxyVarsFromEnv()...)
+			}
+		}
+
+		// Nodes running the sensor before the update, some of which may no longer match the scheduling constraints of the DaemonSets
+		existingSensors, err := r.additionalSensorDaemonSets(ctx, nodesensor)
 		if err != nil {
 			return ctrl.Result{}, err
 		}
 
-		// Update the daemonset and re-spin pods with changes
-		if imgUpdate || tolsUpdate || affUpdate || containerVolUpdate || volumeUpdates || resources || pc || capabilities || initArgs || initResources || proxyUpdates || updated {
-			err = r.Update(ctx, dsUpdate)
+		installed := []string{}
+		for _, ds := range append([]*appsv1.DaemonSet{daemonset}, existingSensors...) {
+			nodes, err := r.nodesWithSensor(ctx, ds)
 			if err != nil {
-				err = r.conditionsUpdate(falconv1alpha1.ConditionDaemonSetReady,
-					metav1.ConditionTrue,
-					falconv1alpha1.ReasonUpdateFailed,
-					"FalconNodeSensor DaemonSet update has failed",
-					ctx, req.NamespacedName, nodesensor, logger)
-				logger.Error(err, "Failed to update DaemonSet", "DaemonSet.Namespace", dsUpdate.Namespace, "DaemonSet.Name", dsUpdate.Name)
 				return ctrl.Result{}, err
 			}
+			installed = append(installed, nodes...)
+		}
 
-			err := k8s_utils.RestartDaemonSet(ctx, r.Client, dsUpdate)
-			if err != nil {
-				logger.Error(err, "Failed to restart pods after DaemonSet configuration changed.")
+		generation := daemonset.Generation
+		result, err := k8sutils.ServerSideApply(r.Client, ctx, logger, nodesensor, daemonset, dsTarget, nodesensor.Spec.FieldOwnership)
+		if err != nil {
+			logger.Error(err, "Failed to update DaemonSet", "DaemonSet.Namespace", dsTarget.Namespace, "DaemonSet.Name", dsTarget.Name)
+			if err := r.conditionsUpdate(falconv1alpha1.ConditionDaemonSetReady,
+				metav1.ConditionTrue,
+				falconv1alpha1.ReasonUpdateFailed,
+				"FalconNodeSensor DaemonSet update has failed",
+				ctx, req.NamespacedName, nodesensor, logger); err != nil {
 				return ctrl.Result{}, err
 			}
+			return ctrl.Result{}, err
+		}
+
+		if err := r.driftConditionUpdate(ctx, req.NamespacedName, nodesensor, result, logger); err != nil {
+			return ctrl.Result{}, err
+		}
 
+		// The DaemonSet controller rolls the pods out according to the update strategy when the pod template changed,
+		// including the hash of the sensor configuration. Labels and annotations of the DaemonSet itself do not change its generation.
+		if dsTarget.Generation != generation {
 			err = r.conditionsUpdate(falconv1alpha1.ConditionDaemonSetReady,
 				metav1.ConditionTrue,
 				falconv1alpha1.ReasonUpdateSucceeded,
@@ -370,7 +467,56 @@ func (r *FalconNodeSensorReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			if err != nil {
 				return ctrl.Result{}, err
 			}
-			logger.Info("FalconNodeSensor DaemonSet configuration changed. Pods have been restarted.")
+			logger.Info("FalconNodeSensor DaemonSet configuration changed. Pods are being rolled out.", "UpdateStrategy", dsTarget.Spec.UpdateStrategy.Type)
+		}
+
+		additional, err := r.handleAdditionalSensors(ctx, config, nodesensor, sensors[1:], image, serviceAccount, logger)
+		if err != nil {
+			if err := r.conditionsUpdate(falconv1alpha1.ConditionDaemonSetReady,
+				metav1.ConditionTrue,
+				falconv1alpha1.ReasonUpdateFailed,
+				"FalconNodeSensor node pool or kernel backend DaemonSet update has failed",
+				ctx, req.NamespacedName, nodesensor, logger); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, err
+		}
+
+		daemonsets := append([]*appsv1.DaemonSet{dsTarget}, additional...)
+		if err := r.rolloutStatusUpdate(ctx, req.NamespacedName, nodesensor, daemonsets, logger); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		cleaning, err := r.handleNodeScopeCleanup(ctx, nodesensor, daemonsets, installed, image, serviceAccount, logger)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if cleaning {
+			requeueAfter = cleanupRequeueInterval
+		}
+
+		restarting, err := r.restartRetaggedSensorPods(ctx, daemonsets, logger)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if restarting && (requeueAfter == 0 || tagsRestartRequeueInterval < requeueAfter) {
+			requeueAfter = tagsRestartRequeueInterval
+		}
+
+		nextEnrollmentCheck, err := r.handleNodeEnrollment(ctx, config, nodesensor, daemonsets, logger)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if requeueAfter == 0 || (nextEnrollmentCheck > 0 && nextEnrollmentCheck < requeueAfter) {
+			requeueAfter = nextEnrollmentCheck
+		}
+
+		nextHostCleanup, err := r.handleHostCleanup(ctx, config, nodesensor, logger)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if requeueAfter == 0 || (nextHostCleanup > 0 && nextHostCleanup < requeueAfter) {
+			requeueAfter = nextHostCleanup
 		}
 	}
 
@@ -400,41 +546,11 @@ func (r *FalconNodeSensorReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{Requeue: true}, err
 	}
 
-	// Check if the FalconNodeSensor instance is marked to be deleted, which is
-	// indicated by the deletion timestamp being set.
-	isDSMarkedToBeDeleted := nodesensor.GetDeletionTimestamp() != nil
-	if isDSMarkedToBeDeleted {
-		if controllerutil.ContainsFinalizer(nodesensor, common.FalconFinalizer) {
-			logger.Info("Successfully finalized daemonset")
-			// Allows the cleanup to be disabled by disableCleanup option
-			if !*nodesensor.Spec.Node.NodeCleanup {
-				// Run finalization logic for common.FalconFinalizer. If the
-				// finalization logic fails, don't remove the finalizer so
-				// that we can retry during the next reconciliation.
-				if err := r.finalizeDaemonset(ctx, image, serviceAccount, nodesensor, logger); err != nil {
-					return ctrl.Result{}, err
-				}
-			} else {
-				logger.Info("Skipping cleanup because it is disabled", "disableCleanup", *nodesensor.Spec.Node.NodeCleanup)
-			}
-
-			// Remove common.FalconFinalizer. Once all finalizers have been
-			// removed, the object will be deleted.
-			controllerutil.RemoveFinalizer(nodesensor, common.FalconFinalizer)
-			err := r.Update(ctx, nodesensor)
-			if err != nil {
-				return ctrl.Result{}, err
-			}
-			log.Info("Removing finalizer")
-
-		}
-		return ctrl.Result{}, nil
-	}
-
 	// Add finalizer for this CR
 	if !controllerutil.ContainsFinalizer(nodesensor, common.FalconFinalizer) {
+		patch := client.MergeFromWithOptions(nodesensor.DeepCopy(), client.MergeFromWithOptimisticLock{})
 		controllerutil.AddFinalizer(nodesensor, common.FalconFinalizer)
-		err = r.Update(ctx, nodesensor)
+		err = r.Patch(ctx, nodesensor, patch)
 		if err != nil {
 			logger.Error(err, "Unable to update finalizer")
 			return ctrl.Result{}, err
@@ -443,23 +559,26 @@ func (r *FalconNodeSensorReconciler) Reconcile(ctx context.Context, req ctrl.Req
 
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 // handleNamespace creates and updates the namespace
 func (r *FalconNodeSensorReconciler) handleNamespace(ctx context.Context, nodesensor *falconv1alpha1.FalconNodeSensor, logger logr.Logger) (bool, error) {
 	ns := corev1.Namespace{}
+	desiredNs := corev1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Namespace",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: nodesensor.Spec.InstallNamespace,
+		},
+	}
+	assets.ApplyResourceMetadata(&desiredNs, nodesensor.Spec.ResourceMetadata)
+
 	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: nodesensor.Spec.InstallNamespace}, &ns)
 	if err != nil && errors.IsNotFound(err) {
-		ns = corev1.Namespace{
-			TypeMeta: metav1.TypeMeta{
-				APIVersion: corev1.SchemeGroupVersion.String(),
-				Kind:       "Namespace",
-			},
-			ObjectMeta: metav1.ObjectMeta{
-				Name: nodesensor.Spec.InstallNamespace,
-			},
-		}
+		ns = desiredNs
 
 		err = ctrl.SetControllerReference(nodesensor, &ns, r.Scheme)
 		if err != nil {
@@ -478,6 +597,13 @@ func (r *FalconNodeSensorReconciler) handleNamespace(ctx context.Context, nodese
 		return false, err
 	}
 
+	if assets.SyncResourceMetadata(&ns, &desiredNs, nodesensor.Spec.ResourceMetadata) {
+		if err := r.Update(ctx, &ns); err != nil {
+			logger.Error(err, "Failed to update FalconNodeSensor Namespace metadata", "Namespace.Name", nodesensor.Spec.InstallNamespace)
+			return false, err
+		}
+	}
+
 	return false, nil
 }
 
@@ -504,6 +630,7 @@ func (r *FalconNodeSensorReconciler) handlePriorityClass(ctx context.Context, no
 	}
 
 	pc := assets.PriorityClass(pcName, nodesensor.Spec.Node.PriorityClass.Value)
+	assets.ApplyResourceMetadata(pc, nodesensor.Spec.ResourceMetadata)
 
 	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: pcName, Namespace: nodesensor.Spec.InstallNamespace}, existingPC)
 	if err != nil && errors.IsNotFound(err) {
@@ -533,6 +660,10 @@ func (r *FalconNodeSensorReconciler) handlePriorityClass(ctx context.Context, no
 		update = true
 	}
 
+	if !update && assets.SyncResourceMetadata(existingPC, pc, nodesensor.Spec.ResourceMetadata) {
+		return r.Update(ctx, existingPC)
+	}
+
 	if update {
 		err = r.Delete(ctx, existingPC)
 		if err != nil {
@@ -561,6 +692,7 @@ func (r *FalconNodeSensorReconciler) handleConfigMaps(ctx context.Context, confi
 
 	confCm := &corev1.ConfigMap{}
 	configmap := assets.SensorConfigMap(cmName, nodesensor.Spec.InstallNamespace, common.FalconKernelSensor, config.SensorEnvVars())
+	assets.ApplyResourceMetadata(configmap, nodesensor.Spec.ResourceMetadata)
 
 	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: cmName, Namespace: nodesensor.Spec.InstallNamespace}, confCm)
 	if err != nil && errors.IsNotFound(err) {
@@ -593,17 +725,20 @@ func (r *FalconNodeSensorReconciler) handleConfigMaps(ctx context.Context, confi
 		return nil, updated, err
 	}
 
-	if !reflect.DeepEqual(confCm.Data, configmap.Data) {
-		err = r.Update(ctx, configmap)
-		if err != nil {
-			logger.Error(err, "Failed to update Configmap", "Configmap.Namespace", nodesensor.Spec.InstallNamespace, "Configmap.Name", cmName)
-			return nil, updated, err
-		}
+	existingData := confCm.Data
+	result, err := k8sutils.ServerSideApply(r.Client, ctx, logger, nodesensor, confCm, configmap, nodesensor.Spec.FieldOwnership)
+	if err != nil {
+		logger.Error(err, "Failed to update Configmap", "Configmap.Namespace", nodesensor.Spec.InstallNamespace, "Configmap.Name", cmName)
+		return nil, updated, err
+	}
 
-		updated = true
+	if err := r.driftConditionUpdate(ctx, types.NamespacedName{Name: nodesensor.Name}, nodesensor, result, logger); err != nil {
+		return nil, updated, err
 	}
 
-	return confCm, updated, nil
+	// Metadata changes do not require the sensor pods to be restarted
+	updated = !reflect.DeepEqual(existingData, configmap.Data)
+	return configmap, updated, nil
 }
 
 // handleCrowdStrikeSecrets creates and updates the image pull secrets for the nodesensor
@@ -614,7 +749,16 @@ func (r *FalconNodeSensorReconciler) handleCrowdStrikeSecrets(ctx context.Contex
 	secret := corev1.Secret{}
 
 	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: common.FalconPullSecretName, Namespace: nodesensor.Spec.InstallNamespace}, &secret)
-	if err == nil || !errors.IsNotFound(err) {
+	if err == nil {
+		// The pull secret data is kept up to date by the pull secret refresher, only the metadata is synced here
+		desired := assets.Secret(common.FalconPullSecretName, nodesensor.Spec.InstallNamespace, common.FalconKernelSensor, nil, corev1.SecretTypeDockerConfigJson)
+		assets.ApplyResourceMetadata(desired, nodesensor.Spec.ResourceMetadata)
+		if assets.SyncResourceMetadata(&secret, desired, nodesensor.Spec.ResourceMetadata) {
+			return r.Update(ctx, &secret)
+		}
+
+		return nil
+	} else if !errors.IsNotFound(err) {
 		return err
 	}
 
@@ -625,6 +769,7 @@ func (r *FalconNodeSensorReconciler) handleCrowdStrikeSecrets(ctx context.Contex
 
 	secretData := map[string][]byte{corev1.DockerConfigJsonKey: common.CleanDecodedBase64(pulltoken)}
 	secret = *assets.Secret(common.FalconPullSecretName, nodesensor.Spec.InstallNamespace, common.FalconKernelSensor, secretData, corev1.SecretTypeDockerConfigJson)
+	assets.ApplyResourceMetadata(&secret, nodesensor.Spec.ResourceMetadata)
 	err = ctrl.SetControllerReference(nodesensor, &secret, r.Scheme)
 	if err != nil {
 		logger.Error(err, "Unable to assign Controller Reference to the Pull Secret")
@@ -637,31 +782,31 @@ func (r *FalconNodeSensorReconciler) handleCrowdStrikeSecrets(ctx context.Contex
 		}
 	} else {
 		logger.Info("Created a new Pull Secret", "Secret.Namespace", nodesensor.Spec.InstallNamespace, "Secret.Name", common.FalconPullSecretName)
+		return r.registryPullSecrets().UpdateRefreshTime(ctx, r.Client, nodesensor)
 	}
 	return nil
 }
 
-func updateDaemonSetContainerProxy(ds *appsv1.DaemonSet, logger logr.Logger) bool {
-	updated := false
-	if len(proxy.ReadProxyVarsFromEnv()) > 0 {
-		for i, container := range ds.Spec.Template.Spec.Containers {
-			newContainerEnv := common.AppendUniqueEnvVars(container.Env, proxy.ReadProxyVarsFromEnv())
-			updatedContainerEnv := common.UpdateEnvVars(container.Env, proxy.ReadProxyVarsFromEnv())
-			if !equality.Semantic.DeepEqual(ds.Spec.Template.Spec.Containers[i].Env, newContainerEnv) {
-				ds.Spec.Template.Spec.Containers[i].Env = newContainerEnv
-				updated = true
-			}
-			if !equality.Semantic.DeepEqual(ds.Spec.Template.Spec.Containers[i].Env, updatedContainerEnv) {
-				ds.Spec.Template.Spec.Containers[i].Env = updatedContainerEnv
-				updated = true
+// registryPullSecrets gives the pull secret refresher access to the registry pull secrets of the FalconNodeSensor
+func (r *FalconNodeSensorReconciler) registryPullSecrets() pullsecret.Accessors[*falconv1alpha1.FalconNodeSensor] {
+	return pullsecret.Accessors[*falconv1alpha1.FalconNodeSensor]{
+		Kind: "FalconNodeSensor",
+		New:  func() *falconv1alpha1.FalconNodeSensor { return &falconv1alpha1.FalconNodeSensor{} },
+		ApiConfig: func(ctx context.Context, nodesensor *falconv1alpha1.FalconNodeSensor) (*falcon.ApiConfig, error) {
+			nodesensor, err := k8sutils.ResolveFalconConfig(ctx, r, nodesensor)
+			if err != nil {
+				return nil, err
 			}
-			if updated {
-				logger.Info("Updating FalconNodeSensor DaemonSet Proxy Settings")
+
+			if nodesensor.Spec.FalconAPI == nil && !nodesensor.Spec.FalconSecret.Enabled {
+				return nil, nil
 			}
-		}
+			return nodesensor.Spec.FalconAPI.ApiConfigWithSecret(ctx, r.Reader, nodesensor.Spec.FalconSecret)
+		},
+		RefreshTime: func(nodesensor *falconv1alpha1.FalconNodeSensor) **metav1.Time {
+			return &nodesensor.Status.RegistryTokenRefreshTime
+		},
 	}
-
-	return updated
 }
 
 // If an update is needed, this will update the tolerations from the given DaemonSet
@@ -673,9 +818,10 @@ func (r *FalconNodeSensorReconciler) updateDaemonSetTolerations(ctx context.Cont
 		logger.Info("Updating FalconNodeSensor DaemonSet Tolerations")
 		mergedTolerations := k8s_utils.MergeTolerations(*tolerations, *origTolerations)
 		*tolerations = mergedTolerations
+		patch := client.MergeFrom(nodesensor.DeepCopy())
 		nodesensor.Spec.Node.Tolerations = &mergedTolerations
 
-		if err := r.Update(ctx, nodesensor); err != nil {
+		if err := r.Patch(ctx, nodesensor, patch); err != nil {
 			logger.Error(err, "Failed to update FalconNodeSensor Tolerations")
 			return false, err
 		}
@@ -683,135 +829,6 @@ func (r *FalconNodeSensorReconciler) updateDaemonSetTolerations(ctx context.Cont
 	return tolerationsUpdate, nil
 }
 
-// If an update is needed, this will update the affinity from the given DaemonSet
-func updateDaemonSetAffinity(ds *appsv1.DaemonSet, nodesensor *falconv1alpha1.FalconNodeSensor, logger logr.Logger) bool {
-	nodeAffinity := ds.Spec.Template.Spec.Affinity
-	origNodeAffinity := corev1.Affinity{NodeAffinity: &nodesensor.Spec.Node.NodeAffinity}
-	affinityUpdate := !equality.Semantic.DeepEqual(nodeAffinity.NodeAffinity, origNodeAffinity.NodeAffinity)
-	if affinityUpdate {
-		logger.Info("Updating FalconNodeSensor DaemonSet NodeAffinity")
-		*nodeAffinity = origNodeAffinity
-	}
-	return affinityUpdate
-}
-
-// If an update is needed, this will update the containervolumes from the given DaemonSet
-func updateDaemonSetContainerVolumes(ds, origDS *appsv1.DaemonSet, logger logr.Logger) bool {
-	containerVolumeMounts := &ds.Spec.Template.Spec.Containers[0].VolumeMounts
-	containerVolumeMountsUpdates := !equality.Semantic.DeepEqual(*containerVolumeMounts, origDS.Spec.Template.Spec.Containers[0].VolumeMounts)
-	if containerVolumeMountsUpdates {
-		logger.Info("Updating FalconNodeSensor DaemonSet Container volumeMounts")
-		*containerVolumeMounts = origDS.Spec.Template.Spec.Containers[0].VolumeMounts
-	}
-
-	containerVolumeMounts = &ds.Spec.Template.Spec.InitContainers[0].VolumeMounts
-	containerVolumeMountsUpdates = !equality.Semantic.DeepEqual(*containerVolumeMounts, origDS.Spec.Template.Spec.InitContainers[0].VolumeMounts)
-	if containerVolumeMountsUpdates {
-		logger.Info("Updating FalconNodeSensor DaemonSet InitContainer volumeMounts")
-		*containerVolumeMounts = origDS.Spec.Template.Spec.InitContainers[0].VolumeMounts
-	}
-
-	return containerVolumeMountsUpdates
-}
-
-// If an update is needed, this will update the volumes from the given DaemonSet
-func updateDaemonSetVolumes(ds, origDS *appsv1.DaemonSet, logger logr.Logger) bool {
-	volumeMounts := &ds.Spec.Template.Spec.Volumes
-	volumeMountsUpdates := !equality.Semantic.DeepEqual(*volumeMounts, origDS.Spec.Template.Spec.Volumes)
-	if volumeMountsUpdates {
-		logger.Info("Updating FalconNodeSensor DaemonSet volumes")
-		*volumeMounts = origDS.Spec.Template.Spec.Volumes
-	}
-
-	return volumeMountsUpdates
-}
-
-// If an update is needed, this will update the InitContainer image reference from the given DaemonSet
-func updateDaemonSetImages(ds *appsv1.DaemonSet, origImg string, logger logr.Logger) bool {
-	initImage := &ds.Spec.Template.Spec.InitContainers[0].Image
-	imgUpdate := *initImage != origImg
-	if imgUpdate {
-		logger.Info("Updating FalconNodeSensor DaemonSet InitContainer image", "Original Image", origImg, "Current Image", initImage)
-		*initImage = origImg
-	}
-
-	image := &ds.Spec.Template.Spec.Containers[0].Image
-	imgUpdate = *image != origImg
-	if imgUpdate {
-		logger.Info("Updating FalconNodeSensor DaemonSet image", "Original Image", origImg, "Current Image", image)
-		*image = origImg
-	}
-
-	return imgUpdate
-}
-
-// If an update is needed, this will update the resources from the given DaemonSet
-func updateDaemonSetResources(ds, origDS *appsv1.DaemonSet, logger logr.Logger) bool {
-	resources := &ds.Spec.Template.Spec.Containers[0].Resources
-	resourcesUpdates := !equality.Semantic.DeepEqual(*resources, origDS.Spec.Template.Spec.Containers[0].Resources)
-	if resourcesUpdates {
-		logger.Info("Updating FalconNodeSensor DaemonSet resources")
-		*resources = origDS.Spec.Template.Spec.Containers[0].Resources
-
-	}
-
-	return resourcesUpdates
-}
-
-func updateDaemonSetInitContainerResources(ds, origDS *appsv1.DaemonSet, logger logr.Logger) bool {
-	resources := &ds.Spec.Template.Spec.InitContainers[0].Resources
-	resourcesUpdates := !equality.Semantic.DeepEqual(*resources, origDS.Spec.Template.Spec.InitContainers[0].Resources)
-	if resourcesUpdates {
-		logger.Info("Updating FalconNodeSensor DaemonSet InitContainer resources")
-		*resources = origDS.Spec.Template.Spec.InitContainers[0].Resources
-	}
-
-	return resourcesUpdates
-}
-
-// If an update is needed, this will update the priority class from the given DaemonSet
-func updateDaemonSetPriorityClass(ds, origDS *appsv1.DaemonSet, logger logr.Logger) bool {
-	priorityClass := &ds.Spec.Template.Spec.PriorityClassName
-	priorityClassUpdates := *priorityClass != origDS.Spec.Template.Spec.PriorityClassName
-	if priorityClassUpdates {
-		logger.Info("Updating FalconNodeSensor DaemonSet priority class")
-		*priorityClass = origDS.Spec.Template.Spec.PriorityClassName
-	}
-
-	return priorityClassUpdates
-}
-
-// If an update is needed, this will update the capabilities from the given DaemonSet
-func updateDaemonSetCapabilities(ds, origDS *appsv1.DaemonSet, logger logr.Logger) bool {
-	capabilities := &ds.Spec.Template.Spec.Containers[0].SecurityContext.Capabilities
-	capabilitiesUpdates := !equality.Semantic.DeepEqual(*capabilities, origDS.Spec.Template.Spec.Containers[0].SecurityContext.Capabilities)
-	if capabilitiesUpdates {
-		logger.Info("Updating FalconNodeSensor DaemonSet Container capabilities")
-		*capabilities = origDS.Spec.Template.Spec.Containers[0].SecurityContext.Capabilities
-	}
-
-	capabilities = &ds.Spec.Template.Spec.InitContainers[0].SecurityContext.Capabilities
-	capabilitiesUpdates = !equality.Semantic.DeepEqual(*capabilities, origDS.Spec.Template.Spec.InitContainers[0].SecurityContext.Capabilities)
-	if capabilitiesUpdates {
-		logger.Info("Updating FalconNodeSensor DaemonSet InitContainer capabilities")
-		*capabilities = origDS.Spec.Template.Spec.InitContainers[0].SecurityContext.Capabilities
-	}
-
-	return capabilitiesUpdates
-}
-
-// If an update is needed, this will update the init args from the given DaemonSet
-func updateDaemonSetInitArgs(ds, origDS *appsv1.DaemonSet, logger logr.Logger) bool {
-	initArgs := &ds.Spec.Template.Spec.InitContainers[0].Args
-	initArgsUpdates := !equality.Semantic.DeepEqual(*initArgs, origDS.Spec.Template.Spec.InitContainers[0].Args)
-	if initArgsUpdates {
-		logger.Info("Updating FalconNodeSensor DaemonSet init args")
-		*initArgs = origDS.Spec.Template.Spec.InitContainers[0].Args
-	}
-
-	return initArgsUpdates
-}
-
 // handlePermissions creates and updates the service account, role and role binding
 func (r *FalconNodeSensorReconciler) handlePermissions(ctx context.Context, nodesensor *falconv1alpha1.FalconNodeSensor, logger logr.Logger) (bool, error) {
 	created, err := r.handleServiceAccount(ctx, nodesensor, logger)
@@ -825,6 +842,13 @@ func (r *FalconNodeSensorReconciler) handlePermissions(ctx context.Context, node
 // handleRoleBinding creates and updates RoleBinding
 func (r *FalconNodeSensorReconciler) handleClusterRoleBinding(ctx context.Context, nodesensor *falconv1alpha1.FalconNodeSensor, logger logr.Logger) (bool, error) {
 	binding := rbacv1.ClusterRoleBinding{}
+	desiredBinding := rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   common.NodeClusterRoleBindingName,
+			Labels: common.CRLabels("clusterrolebinding", common.NodeClusterRoleBindingName, common.FalconKernelSensor),
+		},
+	}
+	assets.ApplyResourceMetadata(&desiredBinding, nodesensor.Spec.ResourceMetadata)
 
 	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: common.NodeClusterRoleBindingName}, &binding)
 	if err != nil && errors.IsNotFound(err) {
@@ -833,10 +857,7 @@ func (r *FalconNodeSensorReconciler) handleClusterRoleBinding(ctx context.Contex
 				APIVersion: rbacv1.SchemeGroupVersion.String(),
 				Kind:       "ClusterRoleBinding",
 			},
-			ObjectMeta: metav1.ObjectMeta{
-				Name:   common.NodeClusterRoleBindingName,
-				Labels: common.CRLabels("clusterrolebinding", common.NodeClusterRoleBindingName, common.FalconKernelSensor),
-			},
+			ObjectMeta: desiredBinding.ObjectMeta,
 			RoleRef: rbacv1.RoleRef{
 				APIGroup: "rbac.authorization.k8s.io",
 				Kind:     "ClusterRole",
@@ -869,26 +890,35 @@ func (r *FalconNodeSensorReconciler) handleClusterRoleBinding(ctx context.Contex
 		return false, err
 	}
 
+	if assets.SyncResourceMetadata(&binding, &desiredBinding, nodesensor.Spec.ResourceMetadata) {
+		if err := r.Update(ctx, &binding); err != nil {
+			logger.Error(err, "Failed to update ClusterRoleBinding metadata", "ClusteRoleBinding.Name", common.NodeClusterRoleBindingName)
+			return false, err
+		}
+	}
+
 	return false, nil
 }
 
 // handleServiceAccount creates and updates the service account and grants necessary permissions to it
 func (r *FalconNodeSensorReconciler) handleServiceAccount(ctx context.Context, nodesensor *falconv1alpha1.FalconNodeSensor, logger logr.Logger) (bool, error) {
 	sa := corev1.ServiceAccount{}
+	desiredSA := corev1.ServiceAccount{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "ServiceAccount",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: nodesensor.Spec.InstallNamespace,
+			Name:      common.NodeServiceAccountName,
+			Labels:    common.CRLabels("serviceaccount", common.NodeServiceAccountName, common.FalconKernelSensor),
+		},
+	}
+	assets.ApplyResourceMetadata(&desiredSA, nodesensor.Spec.ResourceMetadata)
 
 	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: common.NodeServiceAccountName, Namespace: nodesensor.Spec.InstallNamespace}, &sa)
 	if err != nil && errors.IsNotFound(err) {
-		sa = corev1.ServiceAccount{
-			TypeMeta: metav1.TypeMeta{
-				APIVersion: corev1.SchemeGroupVersion.String(),
-				Kind:       "ServiceAccount",
-			},
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace: nodesensor.Spec.InstallNamespace,
-				Name:      common.NodeServiceAccountName,
-				Labels:    common.CRLabels("serviceaccount", common.NodeServiceAccountName, common.FalconKernelSensor),
-			},
-		}
+		sa = desiredSA
 
 		err = ctrl.SetControllerReference(nodesensor, &sa, r.Scheme)
 		if err != nil {
@@ -908,6 +938,13 @@ func (r *FalconNodeSensorReconciler) handleServiceAccount(ctx context.Context, n
 		return false, err
 	}
 
+	if assets.SyncResourceMetadata(&sa, &desiredSA, nodesensor.Spec.ResourceMetadata) {
+		if err := r.Update(ctx, &sa); err != nil {
+			logger.Error(err, "Failed to update ServiceAccount metadata", "Namespace.Name", nodesensor.Spec.InstallNamespace, "ServiceAccount.Name", common.NodeServiceAccountName)
+			return false, err
+		}
+	}
+
 	return false, nil
 }
 
@@ -970,25 +1007,63 @@ func (r *FalconNodeSensorReconciler) conditionsUpdate(condType string, status me
 	return nil
 }
 
-// finalizeDaemonset deletes the Daemonset running the Falcon Sensor and then runs a Daemonset to cleanup the /opt/CrowdStrike directory
-func (r *FalconNodeSensorReconciler) finalizeDaemonset(ctx context.Context, image string, serviceAccount string, nodesensor *falconv1alpha1.FalconNodeSensor, logger logr.Logger) error {
-	dsCleanupName := nodesensor.Name + "-cleanup"
-	daemonset := &appsv1.DaemonSet{}
-	pods := corev1.PodList{}
-	dsList := &appsv1.DaemonSetList{}
-	var nodeCount int32 = 0
+// driftConditionUpdate records the changes made by other field managers to an applied resource in the DriftDetected condition
+func (r *FalconNodeSensorReconciler) driftConditionUpdate(ctx context.Context, nsType types.NamespacedName, nodesensor *falconv1alpha1.FalconNodeSensor, result k8sutils.ApplyResult, logger logr.Logger) error {
+	condition := k8sutils.DriftCondition(nodesensor, nodesensor.Status.Conditions, result, nodesensor.Spec.FieldOwnership)
+	if condition == nil {
+		return nil
+	}
+
+	return r.conditionsUpdate(condition.Type, condition.Status, condition.Reason, condition.Message, ctx, nsType, nodesensor, logger)
+}
 
-	// Get a list of DS and return the DS within the correct NS
-	listOptions := &client.ListOptions{
-		LabelSelector: labels.SelectorFromSet(labels.Set{common.FalconComponentKey: common.FalconKernelSensor}),
-		Namespace:     nodesensor.Spec.InstallNamespace,
+// handleDeletion finalizes a FalconNodeSensor marked to be deleted and removes its finalizer once done. The image is only used to
+// clean up the nodes, so it may be empty when the finalization is forced or the cleanup disabled.
+func (r *FalconNodeSensorReconciler) handleDeletion(ctx context.Context, image string, nodesensor *falconv1alpha1.FalconNodeSensor, logger logr.Logger) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(nodesensor, common.FalconFinalizer) {
+		return ctrl.Result{}, nil
 	}
 
-	if err := r.List(ctx, dsList, listOptions); err != nil {
-		if err = r.Reader.List(ctx, dsList, listOptions); err != nil {
-			return err
+	// Allows the cleanup to be disabled by disableCleanup option
+	if !*nodesensor.Spec.Node.NodeCleanup {
+		// Run finalization logic for common.FalconFinalizer. If the
+		// finalization logic fails or the nodes are still being cleaned up,
+		// don't remove the finalizer so that we can retry during the next reconciliation.
+		done, err := r.finalizeDaemonset(ctx, image, common.NodeServiceAccountName, nodesensor, logger)
+		if err != nil {
+			return ctrl.Result{}, err
 		}
+		if !done {
+			return ctrl.Result{RequeueAfter: cleanupRequeueInterval}, nil
+		}
+	} else {
+		logger.Info("Skipping cleanup because it is disabled", "disableCleanup", *nodesensor.Spec.Node.NodeCleanup)
+	}
+
+	// Remove common.FalconFinalizer. Once all finalizers have been
+	// removed, the object will be deleted.
+	patch := client.MergeFromWithOptions(nodesensor.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	controllerutil.RemoveFinalizer(nodesensor, common.FalconFinalizer)
+	if err := r.Patch(ctx, nodesensor, patch); err != nil {
+		return ctrl.Result{}, err
 	}
+	logger.Info("Removing finalizer")
+
+	return ctrl.Result{}, nil
+}
+
+// forceFinalize reports whether the finalization of the FalconNodeSensor is forced, skipping the cleanup of the remaining nodes
+func forceFinalize(nodesensor *falconv1alpha1.FalconNodeSensor) bool {
+	return nodesensor.Annotations[common.FalconForceFinalizeKey] == "true"
+}
+
+// finalizeDaemonset deletes the Daemonset running the Falcon Sensor and then runs a Daemonset to cleanup the /opt/CrowdStrike directory.
+// The cleanup is driven by requeues rather than by waiting for the cleanup pods: it returns true once the nodes have been cleaned up,
+// the cleanup timed out or the finalization has been forced with the force-finalize annotation.
+func (r *FalconNodeSensorReconciler) finalizeDaemonset(ctx context.Context, image string, serviceAccount string, nodesensor *falconv1alpha1.FalconNodeSensor, logger logr.Logger) (bool, error) {
+	dsCleanupName := nodesensor.Name + "-cleanup"
+	nsType := types.NamespacedName{Name: nodesensor.Name}
+	daemonset := &appsv1.DaemonSet{}
 
 	// Delete the Daemonset containing the sensor
 	if err := r.Delete(ctx,
@@ -998,104 +1073,103 @@ func (r *FalconNodeSensorReconciler) finalizeDaemonset(ctx context.Context, imag
 			},
 		}); err != nil && !errors.IsNotFound(err) {
 		logger.Error(err, "Failed to cleanup Falcon sensor DaemonSet pods")
-		return err
+		return false, err
+	}
+
+	// Delete the Daemonsets of the node pools and of the kernel backend, the cleanup DS runs on their nodes as well
+	if err := r.deleteAdditionalSensors(ctx, nodesensor, nil, logger); err != nil {
+		return false, err
 	}
 
 	// Check if the cleanup DS is created. If not, create it.
 	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: dsCleanupName, Namespace: nodesensor.Spec.InstallNamespace}, daemonset)
 	if err != nil && errors.IsNotFound(err) {
+		if nodesensor.Status.Cleanup != nil {
+			logger.Info("Clean-up daemonset has been removed")
+			return true, nil
+		}
+
+		if forceFinalize(nodesensor) {
+			logger.Info("Finalization has been forced. Skipping the cleanup of the nodes.", "Annotation", common.FalconForceFinalizeKey)
+			return true, nil
+		}
+
 		// Define a new DS for cleanup
 		ds := assets.RemoveNodeDirDaemonset(dsCleanupName, image, serviceAccount, nodesensor)
+		assets.ApplyResourceMetadata(ds, nodesensor.Spec.ResourceMetadata)
 
 		// Create the cleanup DS
 		err = r.Create(ctx, ds)
 		if err != nil {
 			logger.Error(err, "Failed to delete node directory with cleanup DaemonSet", "Path", common.FalconHostInstallDir)
-			return err
+			return false, err
 		}
 
-		var lastCompletedCount int32
-		var lastNodeCount int32
-		var crashloopingPodNodes []string
-		// Start inifite loop to check that all pods have either completed or are running in the DS
-		for {
-			// List all pods with the "cleanup" label in the appropriate NS
-			cleanupListOptions := &client.ListOptions{
-				LabelSelector: labels.SelectorFromSet(labels.Set{common.FalconInstanceNameKey: "cleanup"}),
-				Namespace:     nodesensor.Spec.InstallNamespace,
-			}
-			if err := r.List(ctx, &pods, cleanupListOptions); err != nil {
-				if err = r.Reader.List(ctx, &pods, cleanupListOptions); err != nil {
-					return err
-				}
-			}
+		logger.Info("Created the cleanup DaemonSet", "Path", common.FalconHostInstallDir, "Timeout", cleanupTimeout(nodesensor))
+		return false, r.cleanupStatusUpdate(ctx, nsType, nodesensor, &falconv1alpha1.FalconNodeCleanupStatus{StartTime: metav1.Now()}, logger)
+	} else if err != nil {
+		logger.Error(err, "error getting the cleanup DaemonSet")
+		return false, err
+	}
 
-			// Reset completedCount each loop, to ensure we don't count the same node(s) multiple times
-			var completedCount int32 = 0
-			// Reset the nodeCount to the desired number of pods to be scheduled for cleanup each loop, in case the cluster has scaled down
-			for _, dSet := range dsList.Items {
-				nodeCount = dSet.Status.DesiredNumberScheduled
-				if lastNodeCount != nodeCount {
-					logger.Info("Setting DaemonSet node count", "Number of nodes", nodeCount)
-				}
-				lastNodeCount = nodeCount
-			}
+	cleanup := nodesensor.Status.Cleanup.DeepCopy()
+	if cleanup == nil {
+		cleanup = &falconv1alpha1.FalconNodeCleanupStatus{StartTime: metav1.Now()}
+	}
 
-			// When the pods have a status of completed or running, increment the count.
-			// The reason running is an acceptable value is because the pods should be running the sleep command and have already cleaned up /opt/CrowdStrike
-			for _, pod := range pods.Items {
-				switch pod.Status.Phase {
-				case "Running", "Succeeded":
-					completedCount++
-				case "Pending":
-					if k8sutils.IsInitPodCrashLooping(&pod) {
-						if !slices.Contains(crashloopingPodNodes, pod.Spec.NodeName) {
-							logger.Info(fmt.Sprintf("/opt/CrowdStrike may have not been removed on node %s due to the cleanup pod crashlooping. See the troubleshooting section of the node sensor documentation for more information.", pod.Spec.NodeName))
-							_ = append(crashloopingPodNodes, pod.Spec.NodeName)
-						}
-						completedCount++
-					}
-				}
+	if cleanup.CompletionTime == nil {
+		pods := corev1.PodList{}
+		cleanupListOptions := &client.ListOptions{
+			LabelSelector: labels.SelectorFromSet(daemonset.Spec.Selector.MatchLabels),
+			Namespace:     nodesensor.Spec.InstallNamespace,
+		}
+		if err := r.List(ctx, &pods, cleanupListOptions); err != nil {
+			if err = r.Reader.List(ctx, &pods, cleanupListOptions); err != nil {
+				return false, err
 			}
+		}
 
-			// Break out of the infinite loop for cleanup when the completed or running DS count reaches the desired node count
-			if completedCount == nodeCount {
-				logger.Info("Clean up pods should be done. Continuing deleting.")
-				break
-			} else if completedCount < nodeCount && completedCount > 0 {
-				if completedCount != lastCompletedCount {
-					logger.Info("Waiting for cleanup pods to complete. Retrying....", "Number of pods still processing task", completedCount)
-				}
-				lastCompletedCount = completedCount
-			}
+		cleanup.Nodes = cleanupResults(pods.Items)
+		switch {
+		case cleanupDone(daemonset, cleanup.Nodes):
+			logger.Info("Clean up pods should be done. Continuing deleting.")
+		case forceFinalize(nodesensor):
+			logger.Info("Finalization has been forced. Skipping the cleanup of the remaining nodes.", "Annotation", common.FalconForceFinalizeKey)
+			timeOutPendingNodes(cleanup.Nodes)
+		case time.Since(cleanup.StartTime.Time) > cleanupTimeout(nodesensor):
+			logger.Info("Timed out waiting for cleanup pods to complete. Skipping the cleanup of the remaining nodes.", "Timeout", cleanupTimeout(nodesensor))
+			timeOutPendingNodes(cleanup.Nodes)
+		default:
+			logger.Info("Waiting for cleanup pods to complete. Retrying....", "Number of nodes", daemonset.Status.DesiredNumberScheduled, "Number of nodes still processing task", countCleanupState(cleanup.Nodes, falconv1alpha1.FalconNodeCleanupPending))
+			return false, r.cleanupStatusUpdate(ctx, nsType, nodesensor, cleanup, logger)
+		}
 
-			err = common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: dsCleanupName, Namespace: nodesensor.Spec.InstallNamespace}, daemonset)
-			if err != nil && errors.IsNotFound(err) {
-				logger.Info("Clean-up daemonset has been removed")
-				break
+		for _, result := range cleanup.Nodes {
+			if result.State != falconv1alpha1.FalconNodeCleanupCompleted {
+				logger.Info(fmt.Sprintf("/opt/CrowdStrike may have not been removed on node %s. See the troubleshooting section of the node sensor documentation for more information.", result.Node), "State", result.State)
 			}
 		}
 
-		// The cleanup DS should be completed so delete the cleanup DS
-		if err := r.Delete(ctx,
-			&appsv1.DaemonSet{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: dsCleanupName, Namespace: nodesensor.Spec.InstallNamespace,
-				},
-			}); err != nil && !errors.IsNotFound(err) {
-			logger.Error(err, "Failed to cleanup Falcon sensor DaemonSet pods")
-			return err
+		now := metav1.Now()
+		cleanup.CompletionTime = &now
+		if err := r.cleanupStatusUpdate(ctx, nsType, nodesensor, cleanup, logger); err != nil {
+			return false, err
 		}
+	}
 
-		// If we have gotten here, the cleanup should be successful
-		logger.Info("Successfully deleted node directory", "Path", common.FalconDataDir)
-	} else if err != nil {
-		logger.Error(err, "error getting the cleanup DaemonSet")
-		return err
+	// The cleanup DS should be completed so delete the cleanup DS
+	if err := r.Delete(ctx,
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: dsCleanupName, Namespace: nodesensor.Spec.InstallNamespace,
+			},
+		}); err != nil && !errors.IsNotFound(err) {
+		logger.Error(err, "Failed to cleanup Falcon sensor DaemonSet pods")
+		return false, err
 	}
 
-	logger.Info("Successfully finalized daemonset")
-	return nil
+	logger.Info("Successfully finalized daemonset", "Path", common.FalconDataDir, "Completed nodes", countCleanupState(cleanup.Nodes, falconv1alpha1.FalconNodeCleanupCompleted))
+	return true, nil
 }
 
 func (r *FalconNodeSensorReconciler) reconcileObjectWithName(ctx context.Context, name types.NamespacedName) error {
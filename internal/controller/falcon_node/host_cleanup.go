@@ -0,0 +1,356 @@
+package falcon
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/crowdstrike/falcon-operator/pkg/common"
+	"github.com/crowdstrike/falcon-operator/pkg/node"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// defaultHostCleanupGracePeriod is the time waited after the deletion of a node before its hosts are cleaned up
+	defaultHostCleanupGracePeriod = time.Hour
+
+	// hostCleanupRetryInterval is the interval between two attempts to clean up the hosts of a deleted node
+	hostCleanupRetryInterval = 10 * time.Minute
+
+	// hostCleanupMaxAttempts is the number of failed attempts after which a deleted node is dropped
+	hostCleanupMaxAttempts = 6
+)
+
+// deletedNodeTracker holds the nodes whose deletion was observed, per FalconNodeSensor, until they are recorded in its status
+type deletedNodeTracker struct {
+	mu    sync.Mutex
+	nodes map[string][]falconv1alpha1.FalconNodeDeletedNode
+}
+
+// add records the deletion of a node for a FalconNodeSensor
+func (t *deletedNodeTracker) add(nodesensor string, deleted ...falconv1alpha1.FalconNodeDeletedNode) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.nodes == nil {
+		t.nodes = map[string][]falconv1alpha1.FalconNodeDeletedNode{}
+	}
+	t.nodes[nodesensor] = mergeDeletedNodes(t.nodes[nodesensor], deleted)
+}
+
+// take returns and forgets the deleted nodes recorded for a FalconNodeSensor
+func (t *deletedNodeTracker) take(nodesensor string) []falconv1alpha1.FalconNodeDeletedNode {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	deleted := t.nodes[nodesensor]
+	delete(t.nodes, nodesensor)
+	return deleted
+}
+
+// mergeDeletedNodes adds the deleted nodes to the list, replacing the entries of nodes deleted again
+func mergeDeletedNodes(list []falconv1alpha1.FalconNodeDeletedNode, deleted []falconv1alpha1.FalconNodeDeletedNode) []falconv1alpha1.FalconNodeDeletedNode {
+	merged := append([]falconv1alpha1.FalconNodeDeletedNode{}, list...)
+	for _, d := range deleted {
+		replaced := false
+		for i := range merged {
+			if merged[i].Node == d.Node {
+				merged[i] = d
+				replaced = true
+			}
+		}
+		if !replaced {
+			merged = append(merged, d)
+		}
+	}
+
+	return merged
+}
+
+// nodeDeletionHandler records the deletion of linux nodes for the FalconNodeSensors cleaning up the hosts of deleted nodes.
+// All linux nodes are recorded since a node being drained by an autoscaler is no longer in the scope of the sensor DaemonSet;
+// nodes without a host in the Falcon platform are simply dropped once their grace period has passed.
+func (r *FalconNodeSensorReconciler) nodeDeletionHandler() handler.EventHandler {
+	return handler.Funcs{
+		DeleteFunc: func(ctx context.Context, e event.TypedDeleteEvent[client.Object], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			n, ok := e.Object.(*corev1.Node)
+			if !ok || !labels.SelectorFromSet(common.NodeSelector).Matches(labels.Set(n.Labels)) {
+				return
+			}
+
+			nodesensors := &falconv1alpha1.FalconNodeSensorList{}
+			if err := r.List(ctx, nodesensors); err != nil {
+				log.FromContext(ctx).Error(err, "unable to list FalconNodeSensors for node deletion")
+				return
+			}
+
+			deleted := falconv1alpha1.FalconNodeDeletedNode{
+				Node:         n.Name,
+				Hostnames:    nodeHostnames(n),
+				DeletionTime: metav1.Now(),
+			}
+			for _, nodesensor := range nodesensors.Items {
+				if !nodesensor.Spec.Node.HostCleanup.Enabled {
+					continue
+				}
+
+				r.deletedNodes.add(nodesensor.Name, deleted)
+				q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Name: nodesensor.Name}})
+			}
+		},
+	}
+}
+
+// handleHostCleanup hides or tags the hosts of the nodes deleted for longer than the grace period in the Falcon platform, and records
+// the outcome in Events of the FalconNodeSensor. Deleted nodes are kept in the status until their hosts are handled, so that they
+// survive restarts of the operator, and dropped after hostCleanupMaxAttempts failed attempts. It returns the time until the next deleted
+// node is due.
+func (r *FalconNodeSensorReconciler) handleHostCleanup(ctx context.Context, config *node.ConfigCache, nodesensor *falconv1alpha1.FalconNodeSensor, logger logr.Logger) (time.Duration, error) {
+	cleanup := nodesensor.Spec.Node.HostCleanup
+	tracked := r.deletedNodes.take(nodesensor.Name)
+	if !cleanup.Enabled {
+		return 0, r.deletedNodesStatusUpdate(ctx, nodesensor, nil, logger)
+	}
+
+	// The hosts cannot be queried without API credentials, so deleted nodes are not tracked rather than retried forever
+	if !config.FalconAPIConfigured() {
+		if err := r.deletedNodesStatusUpdate(ctx, nodesensor, nil, logger); err != nil {
+			return 0, err
+		}
+
+		return 0, r.conditionsUpdate(falconv1alpha1.ConditionHostCleanupReady,
+			metav1.ConditionFalse,
+			falconv1alpha1.ReasonAPINotConfigured,
+			"The hosts of deleted nodes cannot be cleaned up without CrowdStrike API credentials. Configure falcon_api or falconSecret",
+			ctx, types.NamespacedName{Name: nodesensor.Name}, nodesensor, logger)
+	}
+
+	if err := r.conditionsUpdate(falconv1alpha1.ConditionHostCleanupReady,
+		metav1.ConditionTrue,
+		falconv1alpha1.ReasonSucceeded,
+		"The hosts of deleted nodes are cleaned up in the Falcon platform",
+		ctx, types.NamespacedName{Name: nodesensor.Name}, nodesensor, logger); err != nil {
+		return 0, err
+	}
+
+	grace := defaultHostCleanupGracePeriod
+	if cleanup.GracePeriod != nil {
+		grace = cleanup.GracePeriod.Duration
+	}
+
+	now := time.Now()
+	var next time.Duration
+	requeue := func(d time.Duration) {
+		if next == 0 || d < next {
+			next = d
+		}
+	}
+
+	remaining := []falconv1alpha1.FalconNodeDeletedNode{}
+	dueNodes := []falconv1alpha1.FalconNodeDeletedNode{}
+	for _, deleted := range mergeDeletedNodes(nodesensor.Status.DeletedNodes, tracked) {
+		due := deleted.DeletionTime.Add(grace)
+		if deleted.LastAttemptTime != nil && deleted.LastAttemptTime.Add(hostCleanupRetryInterval).After(due) {
+			due = deleted.LastAttemptTime.Add(hostCleanupRetryInterval)
+		}
+		if now.Before(due) {
+			remaining = append(remaining, deleted)
+			requeue(due.Sub(now))
+			continue
+		}
+
+		dueNodes = append(dueNodes, deleted)
+	}
+
+	failed := r.cleanupDeletedNodes(ctx, config, nodesensor, dueNodes, grace)
+	for _, deleted := range dueNodes {
+		err, ok := failed[deleted.Node]
+		if !ok {
+			continue
+		}
+
+		logger.Error(err, "Failed to clean up the hosts of a deleted node", "Node.Name", deleted.Node)
+		r.event(nodesensor, corev1.EventTypeWarning, "HostCleanupFailed", fmt.Sprintf("Failed to clean up the hosts of deleted node %s: %v", deleted.Node, err))
+
+		deleted.Attempts++
+		if deleted.Attempts >= hostCleanupMaxAttempts {
+			r.event(nodesensor, corev1.EventTypeWarning, "HostCleanupAbandoned", fmt.Sprintf("Gave up cleaning up the hosts of deleted node %s after %d failed attempts", deleted.Node, deleted.Attempts))
+			continue
+		}
+
+		attempt := metav1.NewTime(now)
+		deleted.LastAttemptTime = &attempt
+		deleted.Message = err.Error()
+		remaining = append(remaining, deleted)
+		requeue(hostCleanupRetryInterval)
+	}
+
+	if err := r.deletedNodesStatusUpdate(ctx, nodesensor, remaining, logger); err != nil {
+		// The nodes deleted since the last reconciliation are recorded again so that they are not lost
+		r.deletedNodes.add(nodesensor.Name, tracked...)
+		return 0, err
+	}
+
+	return next, nil
+}
+
+// cleanupDeletedNodes hides or tags the hosts of the deleted nodes which have not been seen after the end of their grace period. The hosts
+// of all the nodes are queried at once. It returns the errors of the nodes whose hosts could not be cleaned up, keyed by node name.
+func (r *FalconNodeSensorReconciler) cleanupDeletedNodes(ctx context.Context, config *node.ConfigCache, nodesensor *falconv1alpha1.FalconNodeSensor, due []falconv1alpha1.FalconNodeDeletedNode, grace time.Duration) map[string]error {
+	failed := map[string]error{}
+	pending := []falconv1alpha1.FalconNodeDeletedNode{}
+	hostnames := []string{}
+	for _, deleted := range due {
+		err := r.Get(ctx, types.NamespacedName{Name: deleted.Node}, &corev1.Node{})
+		switch {
+		case err == nil:
+			r.event(nodesensor, corev1.EventTypeNormal, "HostCleanupSkipped", fmt.Sprintf("Node %s was recreated, its hosts are left alone", deleted.Node))
+		case errors.IsNotFound(err):
+			pending = append(pending, deleted)
+			for _, hostname := range deleted.Hostnames {
+				if !slices.Contains(hostnames, hostname) {
+					hostnames = append(hostnames, hostname)
+				}
+			}
+		default:
+			failed[deleted.Node] = err
+		}
+	}
+
+	if len(pending) == 0 {
+		return failed
+	}
+
+	devices, err := config.GetHosts(ctx, hostnames)
+	if err != nil {
+		for _, deleted := range pending {
+			failed[deleted.Node] = err
+		}
+		return failed
+	}
+
+	for _, deleted := range pending {
+		if err := r.cleanupDeletedNode(ctx, config, nodesensor, deleted, hostsWithHostnames(devices, deleted.Hostnames), deleted.DeletionTime.Add(grace)); err != nil {
+			failed[deleted.Node] = err
+		}
+	}
+
+	return failed
+}
+
+// cleanupDeletedNode hides or tags the hosts of a deleted node which have not been seen after the end of its grace period
+func (r *FalconNodeSensorReconciler) cleanupDeletedNode(ctx context.Context, config *node.ConfigCache, nodesensor *falconv1alpha1.FalconNodeSensor, deleted falconv1alpha1.FalconNodeDeletedNode, devices []*models.DeviceapiDeviceSwagger, graceEnd time.Time) error {
+	aids := deletedNodeHosts(devices, config.CID(), graceEnd)
+	if len(aids) == 0 {
+		r.event(nodesensor, corev1.EventTypeNormal, "HostCleanupSkipped", fmt.Sprintf("No host of deleted node %s was found to clean up", deleted.Node))
+		return nil
+	}
+
+	cleanup := nodesensor.Spec.Node.HostCleanup
+	switch {
+	case cleanup.DryRun:
+		r.event(nodesensor, corev1.EventTypeNormal, "HostCleanupDryRun", fmt.Sprintf("Hosts of deleted node %s would be cleaned up with action %s: %s", deleted.Node, cleanup.Action, strings.Join(aids, ", ")))
+	case cleanup.Action == "tag":
+		if err := config.TagHosts(ctx, aids, []string{cleanup.Tag}); err != nil {
+			return err
+		}
+		r.event(nodesensor, corev1.EventTypeNormal, "HostsTagged", fmt.Sprintf("Hosts of deleted node %s were tagged %s: %s", deleted.Node, cleanup.Tag, strings.Join(aids, ", ")))
+	default:
+		if err := config.HideHosts(ctx, aids); err != nil {
+			return err
+		}
+		r.event(nodesensor, corev1.EventTypeNormal, "HostsHidden", fmt.Sprintf("Hosts of deleted node %s were hidden: %s", deleted.Node, strings.Join(aids, ", ")))
+	}
+
+	return nil
+}
+
+// hostsWithHostnames returns the hosts reporting one of the hostnames
+func hostsWithHostnames(devices []*models.DeviceapiDeviceSwagger, hostnames []string) []*models.DeviceapiDeviceSwagger {
+	return slices.DeleteFunc(slices.Clone(devices), func(device *models.DeviceapiDeviceSwagger) bool {
+		return device == nil || !slices.Contains(hostnames, strings.ToLower(device.Hostname))
+	})
+}
+
+// deletedNodeHosts returns the AIDs of the hosts of the CID not seen after the end of the grace period of a deleted node.
+// Hosts seen later belong to a live machine reusing the hostname.
+func deletedNodeHosts(devices []*models.DeviceapiDeviceSwagger, cid string, graceEnd time.Time) []string {
+	cid = hostCID(cid)
+
+	aids := []string{}
+	for _, device := range devices {
+		if device == nil || device.DeviceID == nil {
+			continue
+		}
+		if cid != "" && device.Cid != nil && strings.ToLower(*device.Cid) != cid {
+			continue
+		}
+
+		lastSeen, err := time.Parse(time.RFC3339, device.LastSeen)
+		if err != nil || lastSeen.After(graceEnd) {
+			continue
+		}
+
+		aids = append(aids, *device.DeviceID)
+	}
+
+	return aids
+}
+
+// deletedNodesStatusUpdate records the deleted nodes waiting for the cleanup of their hosts in the status of the FalconNodeSensor. The
+// HostCleanupReady condition is removed along with them once the cleanup is disabled.
+func (r *FalconNodeSensorReconciler) deletedNodesStatusUpdate(ctx context.Context, nodesensor *falconv1alpha1.FalconNodeSensor, deleted []falconv1alpha1.FalconNodeDeletedNode, logger logr.Logger) error {
+	if len(deleted) == 0 {
+		deleted = nil
+	}
+	removeCondition := !nodesensor.Spec.Node.HostCleanup.Enabled && meta.FindStatusCondition(nodesensor.Status.Conditions, falconv1alpha1.ConditionHostCleanupReady) != nil
+	if equality.Semantic.DeepEqual(nodesensor.Status.DeletedNodes, deleted) && !removeCondition {
+		return nil
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := r.Get(ctx, types.NamespacedName{Name: nodesensor.Name}, nodesensor)
+		if err != nil {
+			return err
+		}
+
+		nodesensor.Status.DeletedNodes = deleted
+		if removeCondition {
+			meta.RemoveStatusCondition(&nodesensor.Status.Conditions, falconv1alpha1.ConditionHostCleanupReady)
+		}
+		return r.Status().Update(ctx, nodesensor)
+	})
+	if err != nil {
+		logger.Error(err, "Failed to update FalconNodeSensor status for nodesensor.Status.DeletedNodes")
+		return err
+	}
+
+	return nil
+}
+
+func (r *FalconNodeSensorReconciler) event(nodesensor *falconv1alpha1.FalconNodeSensor, eventType string, reason string, message string) {
+	if r.Recorder == nil {
+		return
+	}
+
+	r.Recorder.Event(nodesensor, eventType, reason, message)
+}
@@ -0,0 +1,225 @@
+package falcon
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/crowdstrike/falcon-operator/pkg/node"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestDeletedNodeHosts(t *testing.T) {
+	graceEnd := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	device := func(aid, cid string, lastSeen time.Time) *models.DeviceapiDeviceSwagger {
+		return &models.DeviceapiDeviceSwagger{DeviceID: &aid, Cid: &cid, Hostname: "node-a", LastSeen: lastSeen.Format(time.RFC3339)}
+	}
+
+	devices := []*models.DeviceapiDeviceSwagger{
+		device("aid-old", "0123456789abcdef", graceEnd.Add(-48*time.Hour)),
+		device("aid-deleted", "0123456789abcdef", graceEnd.Add(-time.Hour)),
+		device("aid-live", "0123456789abcdef", graceEnd.Add(time.Minute)),
+		device("aid-other-cid", "fedcba9876543210", graceEnd.Add(-time.Hour)),
+		{Hostname: "node-a"},
+	}
+
+	want := []string{"aid-old", "aid-deleted"}
+	if diff := cmp.Diff(want, deletedNodeHosts(devices, "0123456789ABCDEF-12", graceEnd)); diff != "" {
+		t.Errorf("deletedNodeHosts() mismatch (-want +got): %s", diff)
+	}
+}
+
+func TestDeletedNodeTracker(t *testing.T) {
+	first := metav1.NewTime(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	second := metav1.NewTime(first.Add(time.Hour))
+
+	tracker := deletedNodeTracker{}
+	tracker.add("falcon-node-sensor", falconv1alpha1.FalconNodeDeletedNode{Node: "node-a", DeletionTime: first})
+	tracker.add("falcon-node-sensor", falconv1alpha1.FalconNodeDeletedNode{Node: "node-b", DeletionTime: first})
+	tracker.add("falcon-node-sensor", falconv1alpha1.FalconNodeDeletedNode{Node: "node-a", DeletionTime: second})
+	tracker.add("other-sensor", falconv1alpha1.FalconNodeDeletedNode{Node: "node-a", DeletionTime: first})
+
+	want := []falconv1alpha1.FalconNodeDeletedNode{
+		{Node: "node-a", DeletionTime: second},
+		{Node: "node-b", DeletionTime: first},
+	}
+	if diff := cmp.Diff(want, tracker.take("falcon-node-sensor")); diff != "" {
+		t.Errorf("take() mismatch (-want +got): %s", diff)
+	}
+
+	if got := tracker.take("falcon-node-sensor"); len(got) != 0 {
+		t.Errorf("take() = %v after the deleted nodes were taken, want none", got)
+	}
+
+	if got := tracker.take("other-sensor"); len(got) != 1 {
+		t.Errorf("take() = %v for another FalconNodeSensor, want one deleted node", got)
+	}
+}
+
+func TestHostsWithHostnames(t *testing.T) {
+	devices := []*models.DeviceapiDeviceSwagger{
+		{Hostname: "node-a"},
+		{Hostname: "NODE-B"},
+		{Hostname: "node-c"},
+		nil,
+	}
+
+	got := hostsWithHostnames(devices, []string{"node-a", "node-b"})
+	if diff := cmp.Diff([]*models.DeviceapiDeviceSwagger{devices[0], devices[1]}, got); diff != "" {
+		t.Errorf("hostsWithHostnames() mismatch (-want +got): %s", diff)
+	}
+}
+
+func TestCleanupDeletedNodes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	nodesensor := &falconv1alpha1.FalconNodeSensor{ObjectMeta: metav1.ObjectMeta{Name: "falcon-node-sensor"}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-recreated"}}).Build()
+	r := &FalconNodeSensorReconciler{Client: c, Reader: c, Scheme: scheme}
+
+	deletionTime := metav1.NewTime(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	due := []falconv1alpha1.FalconNodeDeletedNode{
+		{Node: "node-recreated", Hostnames: []string{"node-recreated"}, DeletionTime: deletionTime},
+		{Node: "node-a", Hostnames: []string{"node-a"}, DeletionTime: deletionTime},
+		{Node: "node-b", Hostnames: []string{"node-b"}, DeletionTime: deletionTime},
+	}
+
+	// The single hosts query fails without API credentials, which fails the cleanup of every deleted node still missing
+	config := node.ConfigCacheTest("0123456789ABCDEF-12", "", nodesensor, nil)
+	failed := r.cleanupDeletedNodes(context.Background(), config, nodesensor, due, time.Hour)
+
+	want := map[string]error{"node-a": node.ErrFalconAPINotConfigured, "node-b": node.ErrFalconAPINotConfigured}
+	if diff := cmp.Diff(want, failed, cmpopts.EquateErrors()); diff != "" {
+		t.Errorf("cleanupDeletedNodes() mismatch (-want +got): %s", diff)
+	}
+}
+
+func TestHandleHostCleanup_WithoutFalconAPI(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := falconv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	nodesensor := &falconv1alpha1.FalconNodeSensor{
+		ObjectMeta: metav1.ObjectMeta{Name: "falcon-node-sensor"},
+		Spec:       falconv1alpha1.FalconNodeSensorSpec{Node: falconv1alpha1.FalconNodeSensorConfig{HostCleanup: falconv1alpha1.FalconNodeHostCleanup{Enabled: true}}},
+		Status: falconv1alpha1.FalconNodeSensorStatus{
+			DeletedNodes: []falconv1alpha1.FalconNodeDeletedNode{{Node: "node-a", Hostnames: []string{"node-a"}, DeletionTime: metav1.Now()}},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(nodesensor).WithStatusSubresource(nodesensor).Build()
+	r := &FalconNodeSensorReconciler{Client: c, Reader: c, Scheme: scheme}
+	r.deletedNodes.add(nodesensor.Name, falconv1alpha1.FalconNodeDeletedNode{Node: "node-b", Hostnames: []string{"node-b"}, DeletionTime: metav1.Now()})
+
+	config := node.ConfigCacheTest("0123456789ABCDEF-12", "", nodesensor, nil)
+	next, err := r.handleHostCleanup(context.Background(), config, nodesensor, logr.Discard())
+	if err != nil {
+		t.Fatalf("handleHostCleanup() error = %v", err)
+	}
+	if next != 0 {
+		t.Errorf("handleHostCleanup() = %v, want no requeue without API credentials", next)
+	}
+	if nodesensor.Status.DeletedNodes != nil {
+		t.Errorf("handleHostCleanup() kept %v in the status without API credentials", nodesensor.Status.DeletedNodes)
+	}
+	if !meta.IsStatusConditionFalse(nodesensor.Status.Conditions, falconv1alpha1.ConditionHostCleanupReady) {
+		t.Errorf("handleHostCleanup() did not set the %s condition to False", falconv1alpha1.ConditionHostCleanupReady)
+	}
+
+	// The condition is removed once the cleanup is disabled
+	nodesensor.Spec.Node.HostCleanup.Enabled = false
+	if err := c.Update(context.Background(), nodesensor); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.handleHostCleanup(context.Background(), config, nodesensor, logr.Discard()); err != nil {
+		t.Fatalf("handleHostCleanup() error = %v", err)
+	}
+	if meta.FindStatusCondition(nodesensor.Status.Conditions, falconv1alpha1.ConditionHostCleanupReady) != nil {
+		t.Errorf("handleHostCleanup() kept the %s condition with the cleanup disabled", falconv1alpha1.ConditionHostCleanupReady)
+	}
+}
+
+func TestHandleHostCleanup_DropsAfterMaxAttempts(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := falconv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	deletionTime := metav1.NewTime(time.Now().Add(-24 * time.Hour))
+	lastAttempt := metav1.NewTime(time.Now().Add(-time.Hour))
+	nodesensor := &falconv1alpha1.FalconNodeSensor{
+		ObjectMeta: metav1.ObjectMeta{Name: "falcon-node-sensor"},
+		Spec:       falconv1alpha1.FalconNodeSensorSpec{Node: falconv1alpha1.FalconNodeSensorConfig{HostCleanup: falconv1alpha1.FalconNodeHostCleanup{Enabled: true}}},
+		Status: falconv1alpha1.FalconNodeSensorStatus{
+			DeletedNodes: []falconv1alpha1.FalconNodeDeletedNode{
+				{Node: "node-a", Hostnames: []string{"node-a"}, DeletionTime: deletionTime, LastAttemptTime: &lastAttempt, Attempts: hostCleanupMaxAttempts - 1},
+				{Node: "node-b", Hostnames: []string{"node-b"}, DeletionTime: deletionTime},
+			},
+		},
+	}
+
+	// The nodes cannot be read, which fails every attempt before the Hosts API is queried
+	getErr := errors.New("nodes unavailable")
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(nodesensor).WithStatusSubresource(nodesensor).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if _, ok := obj.(*corev1.Node); ok {
+					return getErr
+				}
+				return c.Get(ctx, key, obj, opts...)
+			},
+		}).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &FalconNodeSensorReconciler{Client: c, Reader: c, Scheme: scheme, Recorder: recorder}
+
+	config := node.ConfigCacheTest("0123456789ABCDEF-12", "", nodesensor, &falcon.ApiConfig{})
+	next, err := r.handleHostCleanup(context.Background(), config, nodesensor, logr.Discard())
+	if err != nil {
+		t.Fatalf("handleHostCleanup() error = %v", err)
+	}
+	if next != hostCleanupRetryInterval {
+		t.Errorf("handleHostCleanup() = %v, want %v", next, hostCleanupRetryInterval)
+	}
+
+	if len(nodesensor.Status.DeletedNodes) != 1 || nodesensor.Status.DeletedNodes[0].Node != "node-b" || nodesensor.Status.DeletedNodes[0].Attempts != 1 {
+		t.Errorf("handleHostCleanup() status = %v, want node-b after its first failed attempt", nodesensor.Status.DeletedNodes)
+	}
+	if !meta.IsStatusConditionTrue(nodesensor.Status.Conditions, falconv1alpha1.ConditionHostCleanupReady) {
+		t.Errorf("handleHostCleanup() did not set the %s condition to True", falconv1alpha1.ConditionHostCleanupReady)
+	}
+
+	abandoned := 0
+	for len(recorder.Events) > 0 {
+		if event := <-recorder.Events; strings.Contains(event, "HostCleanupAbandoned") {
+			abandoned++
+		}
+	}
+	if abandoned != 1 {
+		t.Errorf("handleHostCleanup() recorded %d HostCleanupAbandoned events, want 1", abandoned)
+	}
+}
@@ -0,0 +1,367 @@
+package falcon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/crowdstrike/falcon-operator/internal/controller/assets"
+	k8sutils "github.com/crowdstrike/falcon-operator/internal/controller/common"
+	"github.com/crowdstrike/falcon-operator/pkg/common"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// nodeConditionTaintPrefix is the prefix of the taints reflecting node conditions, such as not-ready or unschedulable,
+// which the DaemonSet controller tolerates for every DaemonSet pod
+const nodeConditionTaintPrefix = "node.kubernetes.io/"
+
+// nodesWithSensor returns the nodes running a pod of the sensor DaemonSet, including pods being terminated
+func (r *FalconNodeSensorReconciler) nodesWithSensor(ctx context.Context, ds *appsv1.DaemonSet) ([]string, error) {
+	pods := corev1.PodList{}
+	listOptions := &client.ListOptions{
+		LabelSelector: labels.SelectorFromSet(ds.Spec.Selector.MatchLabels),
+		Namespace:     ds.Namespace,
+	}
+	if err := r.List(ctx, &pods, listOptions); err != nil {
+		if err = r.Reader.List(ctx, &pods, listOptions); err != nil {
+			return nil, err
+		}
+	}
+
+	nodes := []string{}
+	for i := range pods.Items {
+		if node := podNodeName(&pods.Items[i]); node != "" {
+			nodes = append(nodes, node)
+		}
+	}
+
+	return nodes, nil
+}
+
+// sensorsOnNode returns the names of the FalconNodeSensors with a sensor pod on the node, including pods being terminated
+func (r *FalconNodeSensorReconciler) sensorsOnNode(ctx context.Context, nodeName string) ([]string, error) {
+	pods := corev1.PodList{}
+	if err := r.Reader.List(ctx, &pods,
+		client.MatchingLabels{common.FalconComponentKey: common.FalconKernelSensor, common.FalconInstanceNameKey: "daemonset"},
+		client.MatchingFields{"spec.nodeName": nodeName}); err != nil {
+		return nil, err
+	}
+
+	sensors := []string{}
+	for _, pod := range pods.Items {
+		if !slices.Contains(sensors, pod.Labels[common.FalconInstanceKey]) {
+			sensors = append(sensors, pod.Labels[common.FalconInstanceKey])
+		}
+	}
+
+	return sensors, nil
+}
+
+// ownSensor reports whether the sensor DaemonSet belongs to the FalconNodeSensor, either as its main DaemonSet, its kernel backend DaemonSet
+// or the DaemonSet of one of its node pools
+func ownSensor(nodesensor *falconv1alpha1.FalconNodeSensor, sensor string) bool {
+	return sensor == nodesensor.Name || sensor == assets.KernelSensorName(nodesensor.Name) || strings.HasPrefix(sensor, assets.NodePoolName(nodesensor, ""))
+}
+
+// handleNodeScopeCleanup cleans up /opt/CrowdStrike on the nodes that ran the sensor and no longer match the scheduling constraints of any of the
+// DaemonSets, for example after a change of the node affinity or the tolerations. Nodes moving between node pools keep their files.
+// It returns true while cleanups are in progress.
+func (r *FalconNodeSensorReconciler) handleNodeScopeCleanup(ctx context.Context, nodesensor *falconv1alpha1.FalconNodeSensor, daemonsets []*appsv1.DaemonSet, installed []string, image string, serviceAccount string, logger logr.Logger) (bool, error) {
+	states := map[string]falconv1alpha1.FalconNodeCleanupState{}
+	for _, result := range nodesensor.Status.ScopeCleanup {
+		states[result.Node] = result.State
+	}
+
+	if len(installed) == 0 && len(states) == 0 {
+		return false, nil
+	}
+
+	// All nodes are cached by the manager, a single list serves the lookups of the installed and tracked nodes
+	nodeList := corev1.NodeList{}
+	if err := r.List(ctx, &nodeList); err != nil {
+		if err = r.Reader.List(ctx, &nodeList); err != nil {
+			return false, err
+		}
+	}
+	nodes := map[string]*corev1.Node{}
+	for i := range nodeList.Items {
+		nodes[nodeList.Items[i].Name] = &nodeList.Items[i]
+	}
+
+	for _, name := range installed {
+		if _, ok := states[name]; ok {
+			continue
+		}
+
+		node, ok := nodes[name]
+		if !ok {
+			continue
+		}
+
+		if !nodeEligibleForAny(daemonsets, node) {
+			logger.Info("Node left the scheduling scope of the FalconNodeSensor DaemonSet. Cleaning up the node.", "Node", name, "Path", common.FalconHostInstallDir)
+			states[name] = falconv1alpha1.FalconNodeCleanupPending
+		}
+	}
+
+	if len(states) == 0 {
+		return false, nil
+	}
+
+	inProgress := false
+	results := []falconv1alpha1.FalconNodeCleanupResult{}
+	for name, state := range states {
+		podName := scopeCleanupPodName(nodesensor, name)
+
+		if node, ok := nodes[name]; !ok || nodeEligibleForAny(daemonsets, node) {
+			// Removed nodes have nothing left to clean up, and the sensor is installed again on nodes back in scope
+			if err := r.deleteScopeCleanupPod(ctx, nodesensor, podName); err != nil {
+				return false, err
+			}
+			continue
+		}
+
+		if state == falconv1alpha1.FalconNodeCleanupPending {
+			sensors, err := r.sensorsOnNode(ctx, name)
+			if err != nil {
+				return false, err
+			}
+
+			// Another FalconNodeSensor now runs on the node and owns its files
+			if slices.ContainsFunc(sensors, func(sensor string) bool { return !ownSensor(nodesensor, sensor) }) {
+				logger.Info("Node is managed by another FalconNodeSensor. Skipping the cleanup of the node.", "Node", name)
+				if err := r.deleteScopeCleanupPod(ctx, nodesensor, podName); err != nil {
+					return false, err
+				}
+				continue
+			}
+
+			state, err = r.scopeCleanupState(ctx, nodesensor, name, podName, len(sensors) > 0, image, serviceAccount, logger)
+			if err != nil {
+				return false, err
+			}
+
+			switch state {
+			case falconv1alpha1.FalconNodeCleanupCompleted:
+				logger.Info("Successfully deleted node directory", "Node", name, "Path", common.FalconHostInstallDir)
+				continue
+			case falconv1alpha1.FalconNodeCleanupPending:
+				inProgress = true
+			default:
+				logger.Info(fmt.Sprintf("/opt/CrowdStrike may have not been removed on node %s. See the troubleshooting section of the node sensor documentation for more information.", name), "State", state)
+			}
+		}
+
+		results = append(results, falconv1alpha1.FalconNodeCleanupResult{Node: name, State: state})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Node < results[j].Node
+	})
+
+	if len(results) == 0 {
+		results = nil
+	}
+
+	return inProgress, r.scopeCleanupStatusUpdate(ctx, nodesensor, results, logger)
+}
+
+// scopeCleanupState creates the cleanup pod of a node once the sensor pod is gone from the node, and returns the state of the cleanup
+func (r *FalconNodeSensorReconciler) scopeCleanupState(ctx context.Context, nodesensor *falconv1alpha1.FalconNodeSensor, nodeName string, podName string, sensorRunning bool, image string, serviceAccount string, logger logr.Logger) (falconv1alpha1.FalconNodeCleanupState, error) {
+	// The sensor pod is removed by the DaemonSet controller, the files are only cleaned up once it stopped
+	if sensorRunning {
+		return falconv1alpha1.FalconNodeCleanupPending, nil
+	}
+
+	pod := &corev1.Pod{}
+	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: podName, Namespace: nodesensor.Spec.InstallNamespace}, pod)
+	if err != nil && errors.IsNotFound(err) {
+		pod = assets.RemoveNodeDirPod(podName, nodeName, image, serviceAccount, nodesensor)
+		assets.ApplyResourceMetadata(pod, nodesensor.Spec.ResourceMetadata)
+		if err := controllerutil.SetControllerReference(nodesensor, pod, r.Scheme); err != nil {
+			logger.Error(err, "Unable to assign Controller Reference to the cleanup Pod")
+		}
+
+		if err := r.Create(ctx, pod); err != nil {
+			logger.Error(err, "Failed to delete node directory with cleanup Pod", "Node", nodeName, "Path", common.FalconHostInstallDir)
+			return falconv1alpha1.FalconNodeCleanupPending, err
+		}
+
+		return falconv1alpha1.FalconNodeCleanupPending, nil
+	} else if err != nil {
+		return falconv1alpha1.FalconNodeCleanupPending, err
+	}
+
+	state := falconv1alpha1.FalconNodeCleanupPending
+	switch pod.Status.Phase {
+	case corev1.PodRunning, corev1.PodSucceeded:
+		state = falconv1alpha1.FalconNodeCleanupCompleted
+	case corev1.PodFailed:
+		state = falconv1alpha1.FalconNodeCleanupFailed
+	case corev1.PodPending:
+		if k8sutils.IsInitPodCrashLooping(pod) {
+			state = falconv1alpha1.FalconNodeCleanupFailed
+		}
+	}
+
+	if state != falconv1alpha1.FalconNodeCleanupPending {
+		if err := r.deleteScopeCleanupPod(ctx, nodesensor, podName); err != nil {
+			return state, err
+		}
+	}
+
+	return state, nil
+}
+
+// deleteScopeCleanupPod deletes the cleanup pod of a node if it exists
+func (r *FalconNodeSensorReconciler) deleteScopeCleanupPod(ctx context.Context, nodesensor *falconv1alpha1.FalconNodeSensor, podName string) error {
+	err := r.Delete(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: podName, Namespace: nodesensor.Spec.InstallNamespace}})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+// scopeCleanupStatusUpdate records the nodes left to clean up in the status of the FalconNodeSensor
+func (r *FalconNodeSensorReconciler) scopeCleanupStatusUpdate(ctx context.Context, nodesensor *falconv1alpha1.FalconNodeSensor, results []falconv1alpha1.FalconNodeCleanupResult, logger logr.Logger) error {
+	if reflect.DeepEqual(nodesensor.Status.ScopeCleanup, results) {
+		return nil
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := r.Get(ctx, types.NamespacedName{Name: nodesensor.Name}, nodesensor)
+		if err != nil {
+			return err
+		}
+
+		nodesensor.Status.ScopeCleanup = results
+		return r.Status().Update(ctx, nodesensor)
+	})
+	if err != nil {
+		logger.Error(err, "Failed to update FalconNodeSensor status for nodesensor.Status.ScopeCleanup")
+		return err
+	}
+
+	return nil
+}
+
+// scopeCleanupPodName returns the name of the cleanup pod of a node. Node names may be as long as pod names, so the node name is hashed.
+func scopeCleanupPodName(nodesensor *falconv1alpha1.FalconNodeSensor, nodeName string) string {
+	sum := sha256.Sum256([]byte(nodeName))
+	return fmt.Sprintf("%s-cleanup-%s", nodesensor.Name, hex.EncodeToString(sum[:])[:10])
+}
+
+// nodeEligible reports whether the DaemonSet controller would run a pod with the given spec on the node,
+// based on its node selector, its required node affinity and its tolerations
+func nodeEligible(spec *corev1.PodSpec, node *corev1.Node) bool {
+	for key, value := range spec.NodeSelector {
+		if node.Labels[key] != value {
+			return false
+		}
+	}
+
+	if spec.Affinity != nil && spec.Affinity.NodeAffinity != nil && spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		matches := false
+		for _, term := range spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+			if nodeMatchesSelectorTerm(term, node) {
+				matches = true
+				break
+			}
+		}
+
+		if !matches {
+			return false
+		}
+	}
+
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule || strings.HasPrefix(taint.Key, nodeConditionTaintPrefix) {
+			continue
+		}
+
+		tolerated := false
+		for j := range spec.Tolerations {
+			if spec.Tolerations[j].ToleratesTaint(taint) {
+				tolerated = true
+				break
+			}
+		}
+
+		if !tolerated {
+			return false
+		}
+	}
+
+	return true
+}
+
+// nodeEligibleForAny reports whether the node is eligible for a pod of any of the DaemonSets
+func nodeEligibleForAny(daemonsets []*appsv1.DaemonSet, node *corev1.Node) bool {
+	return slices.ContainsFunc(daemonsets, func(ds *appsv1.DaemonSet) bool {
+		return nodeEligible(&ds.Spec.Template.Spec, node)
+	})
+}
+
+// nodeMatchesSelectorTerm reports whether the node matches all the requirements of a node selector term. Empty terms match no node.
+func nodeMatchesSelectorTerm(term corev1.NodeSelectorTerm, node *corev1.Node) bool {
+	if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+		return false
+	}
+
+	for _, expression := range term.MatchExpressions {
+		requirement, err := labels.NewRequirement(expression.Key, nodeSelectorOperators[expression.Operator], expression.Values)
+		if err != nil || !requirement.Matches(labels.Set(node.Labels)) {
+			return false
+		}
+	}
+
+	for _, field := range term.MatchFields {
+		if field.Key != "metadata.name" {
+			return false
+		}
+
+		// Node names may be longer than label values, so the field requirements are not converted to label requirements
+		switch field.Operator {
+		case corev1.NodeSelectorOpIn:
+			if !slices.Contains(field.Values, node.Name) {
+				return false
+			}
+		case corev1.NodeSelectorOpNotIn:
+			if slices.Contains(field.Values, node.Name) {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// nodeSelectorOperators maps node selector operators to label selector operators
+var nodeSelectorOperators = map[corev1.NodeSelectorOperator]selection.Operator{
+	corev1.NodeSelectorOpIn:           selection.In,
+	corev1.NodeSelectorOpNotIn:        selection.NotIn,
+	corev1.NodeSelectorOpExists:       selection.Exists,
+	corev1.NodeSelectorOpDoesNotExist: selection.DoesNotExist,
+	corev1.NodeSelectorOpGt:           selection.GreaterThan,
+	corev1.NodeSelectorOpLt:           selection.LessThan,
+}
@@ -0,0 +1,272 @@
+package falcon
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNodeEligible(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node-a",
+			Labels: map[string]string{"kubernetes.io/os": "linux", "pool": "general", "cores": "8"},
+		},
+		Spec: corev1.NodeSpec{
+			Taints: []corev1.Taint{
+				{Key: "node.kubernetes.io/not-ready", Effect: corev1.TaintEffectNoExecute},
+				{Key: "preferred", Effect: corev1.TaintEffectPreferNoSchedule},
+			},
+		},
+	}
+
+	affinity := func(terms ...corev1.NodeSelectorTerm) *corev1.Affinity {
+		return &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: terms},
+			},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		spec  corev1.PodSpec
+		taint *corev1.Taint
+		want  bool
+	}{
+		{
+			name: "no constraints",
+			want: true,
+		},
+		{
+			name: "matching node selector",
+			spec: corev1.PodSpec{NodeSelector: map[string]string{"kubernetes.io/os": "linux"}},
+			want: true,
+		},
+		{
+			name: "node selector not matching",
+			spec: corev1.PodSpec{NodeSelector: map[string]string{"kubernetes.io/os": "windows"}},
+			want: false,
+		},
+		{
+			name: "matching affinity term",
+			spec: corev1.PodSpec{Affinity: affinity(
+				corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"gpu"}}}},
+				corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "cores", Operator: corev1.NodeSelectorOpGt, Values: []string{"4"}}}},
+			)},
+			want: true,
+		},
+		{
+			name: "excluded node pool",
+			spec: corev1.PodSpec{Affinity: affinity(
+				corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "pool", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"general"}}}},
+			)},
+			want: false,
+		},
+		{
+			name: "excluded node name",
+			spec: corev1.PodSpec{Affinity: affinity(
+				corev1.NodeSelectorTerm{MatchFields: []corev1.NodeSelectorRequirement{{Key: "metadata.name", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"node-a"}}}},
+			)},
+			want: false,
+		},
+		{
+			name: "empty affinity term",
+			spec: corev1.PodSpec{Affinity: affinity(corev1.NodeSelectorTerm{})},
+			want: false,
+		},
+		{
+			name:  "untolerated taint",
+			taint: &corev1.Taint{Key: "dedicated", Value: "db", Effect: corev1.TaintEffectNoSchedule},
+			want:  false,
+		},
+		{
+			name:  "tolerated taint",
+			spec:  corev1.PodSpec{Tolerations: []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}}},
+			taint: &corev1.Taint{Key: "dedicated", Value: "db", Effect: corev1.TaintEffectNoSchedule},
+			want:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := node.DeepCopy()
+			if tt.taint != nil {
+				n.Spec.Taints = append(n.Spec.Taints, *tt.taint)
+			}
+
+			if got := nodeEligible(&tt.spec, n); got != tt.want {
+				t.Errorf("nodeEligible() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOwnSensor(t *testing.T) {
+	nodesensor := &falconv1alpha1.FalconNodeSensor{ObjectMeta: metav1.ObjectMeta{Name: "falcon-node-sensor"}}
+
+	for sensor, want := range map[string]bool{
+		"falcon-node-sensor":          true,
+		"falcon-node-sensor-pool-gpu": true,
+		"falcon-node-sensor-kernel":   true,
+		"falcon-node-sensor-gpu":      false,
+		"other-node-sensor":           false,
+	} {
+		if got := ownSensor(nodesensor, sensor); got != want {
+			t.Errorf("ownSensor(%s) = %v, want %v", sensor, got, want)
+		}
+	}
+}
+
+func TestScopeCleanupPodName(t *testing.T) {
+	nodesensor := &falconv1alpha1.FalconNodeSensor{ObjectMeta: metav1.ObjectMeta{Name: "falcon-node-sensor"}}
+
+	name := scopeCleanupPodName(nodesensor, strings.Repeat("node", 60))
+	if !strings.HasPrefix(name, "falcon-node-sensor-cleanup-") || len(name) != len("falcon-node-sensor-cleanup-")+10 {
+		t.Errorf("scopeCleanupPodName() = %s, want falcon-node-sensor-cleanup- followed by a 10 characters hash", name)
+	}
+
+	if scopeCleanupPodName(nodesensor, "node-a") == scopeCleanupPodName(nodesensor, "node-b") {
+		t.Error("scopeCleanupPodName() returned the same name for different nodes")
+	}
+}
+
+func TestHandleNodeScopeCleanup(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := falconv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	nodesensor := &falconv1alpha1.FalconNodeSensor{
+		ObjectMeta: metav1.ObjectMeta{Name: "falcon-node-sensor"},
+		Spec: falconv1alpha1.FalconNodeSensorSpec{
+			InstallNamespace: "falcon-system",
+			Node:             falconv1alpha1.FalconNodeSensorConfig{GKE: falconv1alpha1.AutoPilot{Enabled: ptr.To(false)}},
+		},
+		Status: falconv1alpha1.FalconNodeSensorStatus{
+			ScopeCleanup: []falconv1alpha1.FalconNodeCleanupResult{
+				{Node: "node-c", State: falconv1alpha1.FalconNodeCleanupPending},
+				{Node: "node-d", State: falconv1alpha1.FalconNodeCleanupFailed},
+				{Node: "node-removed", State: falconv1alpha1.FalconNodeCleanupPending},
+			},
+		},
+	}
+	ds := &appsv1.DaemonSet{
+		Spec: appsv1.DaemonSetSpec{
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{NodeSelector: map[string]string{"pool": "general"}}},
+		},
+	}
+	node := func(name, pool string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"pool": pool}}}
+	}
+	cleanupPod := func(nodeName string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: scopeCleanupPodName(nodesensor, nodeName), Namespace: "falcon-system"},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(nodesensor, node("node-a", "general"), node("node-b", "other"), node("node-c", "other"), node("node-d", "general"),
+			sensorPod("sensor-b", "node-b", nil), cleanupPod("node-c", corev1.PodFailed), cleanupPod("node-d", corev1.PodRunning)).
+		WithStatusSubresource(nodesensor, &corev1.Pod{}).
+		WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
+			return []string{obj.(*corev1.Pod).Spec.NodeName}
+		}).
+		Build()
+	r := &FalconNodeSensorReconciler{Client: c, Reader: c, Scheme: scheme}
+
+	cleanupPodExists := func(nodeName string) bool {
+		err := c.Get(ctx, types.NamespacedName{Name: scopeCleanupPodName(nodesensor, nodeName), Namespace: "falcon-system"}, &corev1.Pod{})
+		if err != nil && !errors.IsNotFound(err) {
+			t.Fatal(err)
+		}
+		return err == nil
+	}
+	handle := func(wantInProgress bool, installed ...string) []falconv1alpha1.FalconNodeCleanupResult {
+		t.Helper()
+		inProgress, err := r.handleNodeScopeCleanup(ctx, nodesensor, []*appsv1.DaemonSet{ds}, installed, "image", "sa", logr.Discard())
+		if err != nil {
+			t.Fatalf("handleNodeScopeCleanup() error = %v", err)
+		}
+		if inProgress != wantInProgress {
+			t.Errorf("handleNodeScopeCleanup() = %v, want %v", inProgress, wantInProgress)
+		}
+		return nodesensor.Status.ScopeCleanup
+	}
+
+	// node-b left the scope, the failed cleanup of node-c is reported and the nodes back in scope or removed are no longer tracked
+	got := handle(true, "node-a", "node-b")
+	want := []falconv1alpha1.FalconNodeCleanupResult{
+		{Node: "node-b", State: falconv1alpha1.FalconNodeCleanupPending},
+		{Node: "node-c", State: falconv1alpha1.FalconNodeCleanupFailed},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("handleNodeScopeCleanup() status mismatch (-want +got):\n%s", diff)
+	}
+	if cleanupPodExists("node-b") {
+		t.Error("handleNodeScopeCleanup() created the cleanup pod of node-b while the sensor still runs on the node")
+	}
+	if cleanupPodExists("node-c") || cleanupPodExists("node-d") {
+		t.Error("handleNodeScopeCleanup() did not delete the cleanup pods of the failed cleanup and of the node back in scope")
+	}
+
+	// The sensor pod is gone from node-b, the node is cleaned up
+	if err := c.Delete(ctx, sensorPod("sensor-b", "node-b", nil)); err != nil {
+		t.Fatal(err)
+	}
+	if got := handle(true); !cmp.Equal(got, want) {
+		t.Errorf("handleNodeScopeCleanup() = %v, want %v", got, want)
+	}
+	if !cleanupPodExists("node-b") {
+		t.Fatal("handleNodeScopeCleanup() did not create the cleanup pod of node-b")
+	}
+
+	pod := &corev1.Pod{}
+	if err := c.Get(ctx, types.NamespacedName{Name: scopeCleanupPodName(nodesensor, "node-b"), Namespace: "falcon-system"}, pod); err != nil {
+		t.Fatal(err)
+	}
+	pod.Status.Phase = corev1.PodSucceeded
+	if err := c.Status().Update(ctx, pod); err != nil {
+		t.Fatal(err)
+	}
+
+	want = []falconv1alpha1.FalconNodeCleanupResult{{Node: "node-c", State: falconv1alpha1.FalconNodeCleanupFailed}}
+	if got := handle(false); !cmp.Equal(got, want) {
+		t.Errorf("handleNodeScopeCleanup() = %v, want %v", got, want)
+	}
+	if cleanupPodExists("node-b") {
+		t.Error("handleNodeScopeCleanup() did not delete the cleanup pod of node-b once completed")
+	}
+
+	// node-c is back in scope
+	nodeC := &corev1.Node{}
+	if err := c.Get(ctx, types.NamespacedName{Name: "node-c"}, nodeC); err != nil {
+		t.Fatal(err)
+	}
+	nodeC.Labels["pool"] = "general"
+	if err := c.Update(ctx, nodeC); err != nil {
+		t.Fatal(err)
+	}
+	if got := handle(false); got != nil {
+		t.Errorf("handleNodeScopeCleanup() = %v, want no node left to clean up", got)
+	}
+}
@@ -0,0 +1,91 @@
+package falcon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/crowdstrike/falcon-operator/pkg/common"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+)
+
+// setConfigHash records the hash of the sensor configuration in the common.FalconConfigHashKey annotation of the pod template of
+// the DaemonSet. A configuration change updates the pod template, so that the DaemonSet controller rolls the sensor pods out
+// according to the update strategy.
+func setConfigHash(ds *appsv1.DaemonSet, sensorConf *corev1.ConfigMap) error {
+	// Map keys are sorted by the JSON encoder, which keeps the hash stable across reconciliations
+	data, err := json.Marshal(sensorConf.Data)
+	if err != nil {
+		return fmt.Errorf("unable to hash the configuration of ConfigMap %s: %v", sensorConf.Name, err)
+	}
+
+	sum := sha256.Sum256(data)
+	if ds.Spec.Template.Annotations == nil {
+		ds.Spec.Template.Annotations = map[string]string{}
+	}
+
+	ds.Spec.Template.Annotations[common.FalconConfigHashKey] = hex.EncodeToString(sum[:])
+	return nil
+}
+
+// daemonSetRollout returns the rollout progress of the DaemonSets, summed across the node pools, and whether the rollout is complete
+func daemonSetRollout(daemonsets ...*appsv1.DaemonSet) (*falconv1alpha1.FalconNodeRolloutStatus, bool) {
+	rollout := &falconv1alpha1.FalconNodeRolloutStatus{}
+	complete := true
+
+	for _, ds := range daemonsets {
+		rollout.DesiredNumberScheduled += ds.Status.DesiredNumberScheduled
+		rollout.UpdatedNumberScheduled += ds.Status.UpdatedNumberScheduled
+		rollout.NumberAvailable += ds.Status.NumberAvailable
+		rollout.NumberUnavailable += ds.Status.NumberUnavailable
+
+		complete = complete && ds.Status.ObservedGeneration >= ds.Generation &&
+			ds.Status.UpdatedNumberScheduled >= ds.Status.DesiredNumberScheduled &&
+			ds.Status.NumberAvailable >= ds.Status.DesiredNumberScheduled
+	}
+
+	return rollout, complete
+}
+
+// rolloutStatusUpdate records the rollout progress of the DaemonSets in the status of the FalconNodeSensor
+func (r *FalconNodeSensorReconciler) rolloutStatusUpdate(ctx context.Context, nsType types.NamespacedName, nodesensor *falconv1alpha1.FalconNodeSensor, daemonsets []*appsv1.DaemonSet, logger logr.Logger) error {
+	rollout, complete := daemonSetRollout(daemonsets...)
+	if !reflect.DeepEqual(nodesensor.Status.Rollout, rollout) {
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			err := r.Get(ctx, nsType, nodesensor)
+			if err != nil {
+				return err
+			}
+
+			nodesensor.Status.Rollout = rollout
+			return r.Status().Update(ctx, nodesensor)
+		})
+		if err != nil {
+			logger.Error(err, "Failed to update FalconNodeSensor status for nodesensor.Status.Rollout")
+			return err
+		}
+	}
+
+	if complete {
+		return r.conditionsUpdate(falconv1alpha1.ConditionRolloutComplete,
+			metav1.ConditionTrue,
+			falconv1alpha1.ReasonSucceeded,
+			"FalconNodeSensor DaemonSet has been rolled out to all nodes",
+			ctx, nsType, nodesensor, logger)
+	}
+
+	return r.conditionsUpdate(falconv1alpha1.ConditionRolloutComplete,
+		metav1.ConditionFalse,
+		falconv1alpha1.ReasonRolloutInProgress,
+		fmt.Sprintf("FalconNodeSensor DaemonSet is being rolled out: %d of %d nodes updated", rollout.UpdatedNumberScheduled, rollout.DesiredNumberScheduled),
+		ctx, nsType, nodesensor, logger)
+}
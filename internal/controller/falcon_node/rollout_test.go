@@ -0,0 +1,104 @@
+package falcon
+
+import (
+	"testing"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/crowdstrike/falcon-operator/pkg/common"
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetConfigHash(t *testing.T) {
+	ds := &appsv1.DaemonSet{}
+	sensorConf := &corev1.ConfigMap{Data: map[string]string{"FALCONCTL_OPT_CID": "1234567890ABCDEF1234567890ABCDEF-12", "FALCONCTL_OPT_TAGS": "a,b"}}
+
+	if err := setConfigHash(ds, sensorConf); err != nil {
+		t.Fatalf("setConfigHash() error = %v", err)
+	}
+
+	hash := ds.Spec.Template.Annotations[common.FalconConfigHashKey]
+	if hash == "" {
+		t.Fatal("setConfigHash() did not set the config hash annotation")
+	}
+
+	if err := setConfigHash(ds, sensorConf.DeepCopy()); err != nil {
+		t.Fatalf("setConfigHash() error = %v", err)
+	}
+
+	if diff := cmp.Diff(hash, ds.Spec.Template.Annotations[common.FalconConfigHashKey]); diff != "" {
+		t.Errorf("setConfigHash() hash changed for the same configuration (-want +got): %s", diff)
+	}
+
+	sensorConf.Data["FALCONCTL_OPT_TAGS"] = "a,b,c"
+	if err := setConfigHash(ds, sensorConf); err != nil {
+		t.Fatalf("setConfigHash() error = %v", err)
+	}
+
+	if ds.Spec.Template.Annotations[common.FalconConfigHashKey] == hash {
+		t.Error("setConfigHash() hash unchanged after a configuration change")
+	}
+}
+
+func TestDaemonSetRollout(t *testing.T) {
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Generation: 2},
+		Status: appsv1.DaemonSetStatus{
+			ObservedGeneration:     2,
+			DesiredNumberScheduled: 5,
+			UpdatedNumberScheduled: 3,
+			NumberAvailable:        4,
+			NumberUnavailable:      1,
+		},
+	}
+
+	rollout, complete := daemonSetRollout(ds)
+	want := &falconv1alpha1.FalconNodeRolloutStatus{
+		DesiredNumberScheduled: 5,
+		UpdatedNumberScheduled: 3,
+		NumberAvailable:        4,
+		NumberUnavailable:      1,
+	}
+	if diff := cmp.Diff(want, rollout); diff != "" {
+		t.Errorf("daemonSetRollout() mismatch (-want +got): %s", diff)
+	}
+
+	if complete {
+		t.Error("daemonSetRollout() complete = true with pods left to update, want false")
+	}
+
+	ds.Status.UpdatedNumberScheduled = 5
+	ds.Status.NumberAvailable = 5
+	ds.Status.NumberUnavailable = 0
+	if _, complete := daemonSetRollout(ds); !complete {
+		t.Error("daemonSetRollout() complete = false with all pods updated, want true")
+	}
+
+	// The rollout of the node pools is summed with the rollout of the sensor DaemonSet
+	pool := ds.DeepCopy()
+	pool.Status.UpdatedNumberScheduled = 2
+	pool.Status.NumberAvailable = 2
+	pool.Status.NumberUnavailable = 3
+	rollout, complete = daemonSetRollout(ds, pool)
+	want = &falconv1alpha1.FalconNodeRolloutStatus{
+		DesiredNumberScheduled: 10,
+		UpdatedNumberScheduled: 7,
+		NumberAvailable:        7,
+		NumberUnavailable:      3,
+	}
+	if diff := cmp.Diff(want, rollout); diff != "" {
+		t.Errorf("daemonSetRollout() mismatch (-want +got): %s", diff)
+	}
+
+	if complete {
+		t.Error("daemonSetRollout() complete = true with node pool pods left to update, want false")
+	}
+
+	// The DaemonSet controller has not observed the latest pod template yet
+	ds.Generation = 3
+	if _, complete := daemonSetRollout(ds); complete {
+		t.Error("daemonSetRollout() complete = true with an unobserved generation, want false")
+	}
+}
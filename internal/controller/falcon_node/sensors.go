@@ -0,0 +1,158 @@
+package falcon
+
+import (
+	"context"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/crowdstrike/falcon-operator/internal/controller/assets"
+	k8sutils "github.com/crowdstrike/falcon-operator/internal/controller/common"
+	"github.com/crowdstrike/falcon-operator/pkg/common"
+	"github.com/crowdstrike/falcon-operator/pkg/node"
+	"github.com/go-logr/logr"
+	"github.com/operator-framework/operator-lib/proxy"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// handleAdditionalSensors creates and updates the ConfigMap and DaemonSet of the sensors rendered in addition to the sensor DaemonSet
+// of the FalconNodeSensor, for its node pools and for the kernel backend, and deletes those no longer rendered. It returns their DaemonSets.
+func (r *FalconNodeSensorReconciler) handleAdditionalSensors(ctx context.Context, config *node.ConfigCache, nodesensor *falconv1alpha1.FalconNodeSensor, sensors []*falconv1alpha1.FalconNodeSensor, image string, serviceAccount string, logger logr.Logger) ([]*appsv1.DaemonSet, error) {
+	daemonsets := []*appsv1.DaemonSet{}
+	keep := map[string]bool{}
+
+	for _, sensor := range sensors {
+		sensorConf := assets.SensorConfigMap(assets.DaemonsetConfigMapName(sensor), nodesensor.Spec.InstallNamespace, common.FalconKernelSensor, config.WithNodeSensor(sensor).SensorEnvVars())
+		assets.ApplyResourceMetadata(sensorConf, sensor.Spec.ResourceMetadata)
+		keep[sensorConf.Name] = true
+
+		if err := r.applyAdditionalSensorObject(ctx, nodesensor, &corev1.ConfigMap{}, sensorConf, logger); err != nil {
+			logger.Error(err, "Failed to apply the sensor ConfigMap", "Configmap.Name", sensorConf.Name)
+			return nil, err
+		}
+
+		ds := assets.Daemonset(sensor.Name, image, serviceAccount, sensor)
+		if err := assets.ApplyPodTemplateOverride(&ds.Spec.Template, nodesensor.Spec.Node.PodTemplateOverride); err != nil {
+			logger.Error(err, "Failed to apply the DaemonSet pod template override", "DaemonSet.Name", ds.Name)
+			return nil, err
+		}
+		assets.ApplyResourceMetadata(ds, sensor.Spec.ResourceMetadata)
+		keep[ds.Name] = true
+		if len(sensor.GetTagTemplates()) > 0 {
+			keep[assets.TagsConfigMapName(sensor)] = true
+		}
+
+		if err := setConfigHash(ds, sensorConf); err != nil {
+			return nil, err
+		}
+
+		if len(proxy.ReadProxyVarsFromEnv()) > 0 {
+			for i, container := range ds.Spec.Template.Spec.Containers {
+				ds.Spec.Template.Spec.Containers[i].Env = append(container.Env, proxy.ReadProxyVarsFromEnv()...)
+			}
+		}
+
+		if err := r.applyAdditionalSensorObject(ctx, nodesensor, &appsv1.DaemonSet{}, ds, logger); err != nil {
+			logger.Error(err, "Failed to apply the sensor DaemonSet", "DaemonSet.Name", ds.Name)
+			return nil, err
+		}
+
+		daemonsets = append(daemonsets, ds)
+	}
+
+	return daemonsets, r.deleteAdditionalSensors(ctx, nodesensor, keep, logger)
+}
+
+// applyAdditionalSensorObject creates a resource of a sensor DaemonSet, or updates the existing resource with server-side apply
+func (r *FalconNodeSensorReconciler) applyAdditionalSensorObject(ctx context.Context, nodesensor *falconv1alpha1.FalconNodeSensor, existing client.Object, obj client.Object, logger logr.Logger) error {
+	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}, existing)
+	if err != nil && errors.IsNotFound(err) {
+		if err := controllerutil.SetControllerReference(nodesensor, obj, r.Scheme); err != nil {
+			return err
+		}
+
+		logger.Info("Creating sensor resource", "Kind", obj.GetObjectKind().GroupVersionKind().Kind, "Name", obj.GetName())
+		return r.Create(ctx, obj)
+	} else if err != nil {
+		return err
+	}
+
+	result, err := k8sutils.ServerSideApply(r.Client, ctx, logger, nodesensor, existing, obj, nodesensor.Spec.FieldOwnership)
+	if err != nil {
+		return err
+	}
+
+	return r.driftConditionUpdate(ctx, types.NamespacedName{Name: nodesensor.Name}, nodesensor, result, logger)
+}
+
+// additionalSensorDaemonSets returns the sensor DaemonSets of the FalconNodeSensor other than its main sensor DaemonSet
+func (r *FalconNodeSensorReconciler) additionalSensorDaemonSets(ctx context.Context, nodesensor *falconv1alpha1.FalconNodeSensor) ([]*appsv1.DaemonSet, error) {
+	list := appsv1.DaemonSetList{}
+	if err := r.listSensorObjects(ctx, nodesensor, &list); err != nil {
+		return nil, err
+	}
+
+	daemonsets := []*appsv1.DaemonSet{}
+	for i := range list.Items {
+		if metav1.IsControlledBy(&list.Items[i], nodesensor) && list.Items[i].Name != nodesensor.Name {
+			daemonsets = append(daemonsets, &list.Items[i])
+		}
+	}
+
+	return daemonsets, nil
+}
+
+// deleteAdditionalSensors deletes the DaemonSets and ConfigMaps of the additional sensors of the FalconNodeSensor, except the named ones to keep
+func (r *FalconNodeSensorReconciler) deleteAdditionalSensors(ctx context.Context, nodesensor *falconv1alpha1.FalconNodeSensor, keep map[string]bool, logger logr.Logger) error {
+	daemonsets, err := r.additionalSensorDaemonSets(ctx, nodesensor)
+	if err != nil {
+		return err
+	}
+
+	configmaps := corev1.ConfigMapList{}
+	if err := r.listSensorObjects(ctx, nodesensor, &configmaps); err != nil {
+		return err
+	}
+
+	objects := []client.Object{}
+	for _, ds := range daemonsets {
+		if !keep[ds.Name] {
+			objects = append(objects, ds)
+		}
+	}
+	for i := range configmaps.Items {
+		cm := &configmaps.Items[i]
+		mainConfigMap := cm.Name == assets.DaemonsetConfigMapName(nodesensor) || cm.Name == assets.TagsConfigMapName(nodesensor)
+		if metav1.IsControlledBy(cm, nodesensor) && !mainConfigMap && !keep[cm.Name] {
+			objects = append(objects, cm)
+		}
+	}
+
+	for _, obj := range objects {
+		if err := r.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) {
+			logger.Error(err, "Failed to delete sensor resource", "Name", obj.GetName())
+			return err
+		}
+
+		logger.Info("Deleted sensor resource", "Name", obj.GetName())
+	}
+
+	return nil
+}
+
+// listSensorObjects lists the resources of the sensor in the install namespace of the FalconNodeSensor
+func (r *FalconNodeSensorReconciler) listSensorObjects(ctx context.Context, nodesensor *falconv1alpha1.FalconNodeSensor, list client.ObjectList) error {
+	listOptions := []client.ListOption{
+		client.InNamespace(nodesensor.Spec.InstallNamespace),
+		client.MatchingLabels{common.FalconComponentKey: common.FalconKernelSensor},
+	}
+	if err := r.List(ctx, list, listOptions...); err != nil {
+		return r.Reader.List(ctx, list, listOptions...)
+	}
+
+	return nil
+}
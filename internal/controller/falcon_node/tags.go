@@ -0,0 +1,232 @@
+package falcon
+
+import (
+	"context"
+	"time"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/crowdstrike/falcon-operator/internal/controller/assets"
+	"github.com/crowdstrike/falcon-operator/pkg/common"
+	"github.com/crowdstrike/falcon-operator/pkg/node"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// tagsRestartRequeueInterval is the interval at which the sensor pods left to restart after a change of their node tags are checked
+const tagsRestartRequeueInterval = 30 * time.Second
+
+// handleNodeTags renders the sensor grouping tag templates for the nodes of each sensor DaemonSet into the tags ConfigMap of the DaemonSet.
+// The tags ConfigMaps of the node pool and kernel backend DaemonSets are deleted with their DaemonSets, the one of the sensor DaemonSet
+// of the FalconNodeSensor is deleted here once tag templates are no longer used.
+func (r *FalconNodeSensorReconciler) handleNodeTags(ctx context.Context, nodesensor *falconv1alpha1.FalconNodeSensor, sensors []*falconv1alpha1.FalconNodeSensor, logger logr.Logger) error {
+	if len(nodesensor.GetTagTemplates()) == 0 {
+		return r.deleteNodeTags(ctx, nodesensor, logger)
+	}
+
+	templates, err := node.ParseTagTemplates(nodesensor.GetTagTemplates())
+	if err != nil {
+		return err
+	}
+
+	nodes := corev1.NodeList{}
+	if err := r.List(ctx, &nodes, client.MatchingLabels(common.NodeSelector)); err != nil {
+		if err = r.Reader.List(ctx, &nodes, client.MatchingLabels(common.NodeSelector)); err != nil {
+			return err
+		}
+	}
+
+	for _, sensor := range sensors {
+		tags, err := nodeTags(templates, sensor, nodes.Items)
+		if err != nil {
+			return err
+		}
+
+		existing := &corev1.ConfigMap{}
+		tagsConf := assets.SensorConfigMap(assets.TagsConfigMapName(sensor), nodesensor.Spec.InstallNamespace, common.FalconKernelSensor, tags)
+		assets.ApplyResourceMetadata(tagsConf, sensor.Spec.ResourceMetadata)
+		if err := r.applyAdditionalSensorObject(ctx, nodesensor, existing, tagsConf, logger); err != nil {
+			logger.Error(err, "Failed to apply the sensor tags ConfigMap", "Configmap.Name", tagsConf.Name)
+			return err
+		}
+
+		if err := r.markRetaggedSensorPods(ctx, sensor, changedNodeTags(existing.Data, tags), logger); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// changedNodeTags returns the nodes whose rendered sensor grouping tags differ from the previously rendered ones. Nodes rendered for
+// the first time are left out: their sensor pods wait for the tags, which reach the mounted ConfigMap without a restart.
+func changedNodeTags(previous map[string]string, tags map[string]string) map[string]bool {
+	changed := map[string]bool{}
+	for nodeName, nodeTags := range tags {
+		if previousTags, ok := previous[nodeName]; ok && previousTags != nodeTags {
+			changed[nodeName] = true
+		}
+	}
+
+	return changed
+}
+
+// markRetaggedSensorPods annotates the sensor pods of the nodes whose rendered sensor grouping tags changed, since the tags are only set
+// when the pod starts. The annotated pods are restarted by restartRetaggedSensorPods within the disruption budget of the DaemonSet.
+// Pods from a pod template with other static tags or tag templates are left to the rollout of the DaemonSet.
+func (r *FalconNodeSensorReconciler) markRetaggedSensorPods(ctx context.Context, sensor *falconv1alpha1.FalconNodeSensor, nodes map[string]bool, logger logr.Logger) error {
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	pods, err := r.sensorPods(ctx, sensor.Spec.InstallNamespace, common.CRLabels("daemonset", sensor.Name, common.FalconKernelSensor))
+	if err != nil {
+		return err
+	}
+
+	hash := assets.TagsHash(sensor)
+	for i := range pods {
+		pod := &pods[i]
+		if !nodes[pod.Spec.NodeName] || pod.DeletionTimestamp != nil || pod.Annotations[common.FalconTagTemplatesKey] != hash || pod.Annotations[common.FalconTagsOutdatedKey] == "true" {
+			continue
+		}
+
+		patch := client.MergeFrom(pod.DeepCopy())
+		metav1.SetMetaDataAnnotation(&pod.ObjectMeta, common.FalconTagsOutdatedKey, "true")
+		if err := r.Patch(ctx, pod, patch); err != nil && !errors.IsNotFound(err) {
+			logger.Error(err, "Failed to mark the sensor pod for restart", "Pod.Name", pod.Name)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restartRetaggedSensorPods deletes the sensor pods marked by markRetaggedSensorPods, no more at once than the maxUnavailable of their
+// DaemonSet allows. It returns true while marked pods are left to restart.
+func (r *FalconNodeSensorReconciler) restartRetaggedSensorPods(ctx context.Context, daemonsets []*appsv1.DaemonSet, logger logr.Logger) (bool, error) {
+	pending := false
+	for _, ds := range daemonsets {
+		pods, err := r.sensorPods(ctx, ds.Namespace, ds.Spec.Selector.MatchLabels)
+		if err != nil {
+			return false, err
+		}
+
+		outdated := []*corev1.Pod{}
+		terminating := 0
+		for i := range pods {
+			switch {
+			case pods[i].DeletionTimestamp != nil:
+				terminating++
+			case pods[i].Annotations[common.FalconTagsOutdatedKey] == "true":
+				outdated = append(outdated, &pods[i])
+			}
+		}
+
+		budget := restartBudget(ds, terminating)
+		for i, pod := range outdated {
+			if i >= budget {
+				pending = true
+				break
+			}
+
+			logger.Info("Restarting the sensor pod to apply the sensor grouping tags of the node", "Pod.Name", pod.Name, "Node.Name", pod.Spec.NodeName)
+			if err := r.Delete(ctx, pod); err != nil && !errors.IsNotFound(err) {
+				logger.Error(err, "Failed to restart the sensor pod", "Pod.Name", pod.Name)
+				return false, err
+			}
+		}
+	}
+
+	return pending, nil
+}
+
+// restartBudget returns the number of sensor pods of the DaemonSet which can be restarted without exceeding its maxUnavailable, which
+// defaults to 1 and to 1 as well when the DaemonSet surges instead. Pods being terminated are counted as unavailable, since the status
+// of the DaemonSet may not reflect them yet.
+func restartBudget(ds *appsv1.DaemonSet, terminating int) int {
+	maxUnavailable := intstr.FromInt32(1)
+	if rollingUpdate := ds.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil && rollingUpdate.MaxUnavailable != nil {
+		maxUnavailable = *rollingUpdate.MaxUnavailable
+	}
+
+	allowed, err := intstr.GetScaledValueFromIntOrPercent(&maxUnavailable, int(ds.Status.DesiredNumberScheduled), true)
+	if err != nil || allowed < 1 {
+		allowed = 1
+	}
+
+	return allowed - max(int(ds.Status.NumberUnavailable), terminating)
+}
+
+// sensorPods returns the sensor pods matching the labels in the namespace
+func (r *FalconNodeSensorReconciler) sensorPods(ctx context.Context, namespace string, matchLabels map[string]string) ([]corev1.Pod, error) {
+	pods := corev1.PodList{}
+	listOptions := &client.ListOptions{
+		LabelSelector: labels.SelectorFromSet(matchLabels),
+		Namespace:     namespace,
+	}
+	if err := r.List(ctx, &pods, listOptions); err != nil {
+		if err = r.Reader.List(ctx, &pods, listOptions); err != nil {
+			return nil, err
+		}
+	}
+
+	return pods.Items, nil
+}
+
+// nodeTags returns the sensor grouping tags rendered for each node in the scope of the sensor DaemonSet, keyed by node name
+func nodeTags(templates node.TagTemplates, sensor *falconv1alpha1.FalconNodeSensor, nodes []corev1.Node) (map[string]string, error) {
+	spec := &assets.Daemonset(sensor.Name, "", "", sensor).Spec.Template.Spec
+
+	tags := map[string]string{}
+	for i := range nodes {
+		n := &nodes[i]
+		if !nodeEligible(spec, n) {
+			continue
+		}
+
+		nodeTags, err := templates.Render(sensor.Spec.Falcon.Tags, node.TagData{
+			ClusterName: sensor.Spec.Node.ClusterName,
+			Namespace:   sensor.Spec.InstallNamespace,
+			NodeName:    n.Name,
+			NodePool:    sensor.Spec.CommonLabels[common.FalconNodePoolKey],
+			Labels:      n.Labels,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		tags[n.Name] = nodeTags
+	}
+
+	return tags, nil
+}
+
+// deleteNodeTags deletes the tags ConfigMap of the sensor DaemonSet of the FalconNodeSensor
+func (r *FalconNodeSensorReconciler) deleteNodeTags(ctx context.Context, nodesensor *falconv1alpha1.FalconNodeSensor, logger logr.Logger) error {
+	tagsConf := &corev1.ConfigMap{}
+	err := common.GetNamespacedObject(ctx, r.Client, r.Reader, types.NamespacedName{Name: assets.TagsConfigMapName(nodesensor), Namespace: nodesensor.Spec.InstallNamespace}, tagsConf)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if !metav1.IsControlledBy(tagsConf, nodesensor) {
+		return nil
+	}
+
+	if err := r.Delete(ctx, tagsConf); err != nil && !errors.IsNotFound(err) {
+		logger.Error(err, "Failed to delete the sensor tags ConfigMap", "Configmap.Name", tagsConf.Name)
+		return err
+	}
+
+	logger.Info("Deleted sensor resource", "Name", tagsConf.Name)
+	return nil
+}
@@ -0,0 +1,187 @@
+package falcon
+
+import (
+	"context"
+	"testing"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+	"github.com/crowdstrike/falcon-operator/internal/controller/assets"
+	"github.com/crowdstrike/falcon-operator/pkg/common"
+	"github.com/crowdstrike/falcon-operator/pkg/node"
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNodeTags(t *testing.T) {
+	autopilot := false
+	nodesensor := &falconv1alpha1.FalconNodeSensor{ObjectMeta: metav1.ObjectMeta{Name: "falcon-node-sensor"}}
+	nodesensor.Spec.InstallNamespace = "falcon-system"
+	nodesensor.Spec.Node.GKE.Enabled = &autopilot
+	nodesensor.Spec.Node.ClusterName = "prod"
+	nodesensor.Spec.Falcon.Tags = []string{"static"}
+	nodesensor.Spec.Node.TagTemplates = []string{"{{ .ClusterName }}/{{ .Namespace }}", "{{ .NodePool }}", "{{ index .Labels \"topology.kubernetes.io/zone\" }}"}
+	nodesensor.Spec.Node.NodePools = []falconv1alpha1.FalconNodePool{
+		{Name: "gpu", NodeSelector: map[string]string{"pool": "gpu"}, Tags: []string{"gpu-static"}},
+	}
+
+	linux := map[string]string{"kubernetes.io/os": "linux", "topology.kubernetes.io/zone": "us-east-1a"}
+	gpu := map[string]string{"kubernetes.io/os": "linux", "topology.kubernetes.io/zone": "us-east-1b", "pool": "gpu"}
+	nodes := []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: linux}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-b", Labels: gpu}},
+	}
+
+	templates, err := node.ParseTagTemplates(nodesensor.GetTagTemplates())
+	if err != nil {
+		t.Fatalf("ParseTagTemplates() error = %v", err)
+	}
+
+	got, err := nodeTags(templates, nodesensor, nodes)
+	if err != nil {
+		t.Fatalf("nodeTags() error = %v", err)
+	}
+	if diff := cmp.Diff(map[string]string{"node-a": "static,prod/falcon-system,us-east-1a"}, got); diff != "" {
+		t.Errorf("nodeTags() mismatch (-want +got): %s", diff)
+	}
+
+	got, err = nodeTags(templates, assets.NodePoolSensor(nodesensor, 0), nodes)
+	if err != nil {
+		t.Fatalf("nodeTags() error = %v", err)
+	}
+	if diff := cmp.Diff(map[string]string{"node-b": "gpu-static,prod/falcon-system,gpu,us-east-1b"}, got); diff != "" {
+		t.Errorf("nodeTags() node pool mismatch (-want +got): %s", diff)
+	}
+}
+
+func TestChangedNodeTags(t *testing.T) {
+	previous := map[string]string{"node-a": "static,zone-a", "node-b": "static,zone-b"}
+	tags := map[string]string{"node-a": "static,zone-a", "node-b": "static,zone-c", "node-c": "static,zone-a"}
+
+	if diff := cmp.Diff(map[string]bool{"node-b": true}, changedNodeTags(previous, tags)); diff != "" {
+		t.Errorf("changedNodeTags() mismatch (-want +got): %s", diff)
+	}
+}
+
+func TestMarkRetaggedSensorPods(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	nodesensor := &falconv1alpha1.FalconNodeSensor{ObjectMeta: metav1.ObjectMeta{Name: "falcon-node-sensor"}}
+	nodesensor.Spec.InstallNamespace = "falcon-system"
+	nodesensor.Spec.Node.TagTemplates = []string{"{{ .NodeName }}"}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		sensorPod("retagged", "node-a", map[string]string{common.FalconTagTemplatesKey: assets.TagsHash(nodesensor)}),
+		sensorPod("unchanged", "node-b", map[string]string{common.FalconTagTemplatesKey: assets.TagsHash(nodesensor)}),
+		sensorPod("outdated", "node-c", map[string]string{common.FalconTagTemplatesKey: "previous"}),
+	).Build()
+	r := &FalconNodeSensorReconciler{Client: c, Reader: c, Scheme: scheme}
+
+	if err := r.markRetaggedSensorPods(context.Background(), nodesensor, map[string]bool{"node-a": true, "node-c": true}, logr.Discard()); err != nil {
+		t.Fatalf("markRetaggedSensorPods() error = %v", err)
+	}
+
+	// Pods from an outdated pod template are left to the rollout of the DaemonSet
+	if diff := cmp.Diff([]string{"retagged"}, podsWithAnnotation(t, c, common.FalconTagsOutdatedKey)); diff != "" {
+		t.Errorf("markRetaggedSensorPods() marked pods mismatch (-want +got): %s", diff)
+	}
+}
+
+func TestRestartRetaggedSensorPods(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	maxUnavailable := intstr.FromString("40%")
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "falcon-node-sensor", Namespace: "falcon-system"},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: common.CRLabels("daemonset", "falcon-node-sensor", common.FalconKernelSensor)},
+			UpdateStrategy: appsv1.DaemonSetUpdateStrategy{
+				Type:          appsv1.RollingUpdateDaemonSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDaemonSet{MaxUnavailable: &maxUnavailable},
+			},
+		},
+		Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 5},
+	}
+
+	outdated := map[string]string{common.FalconTagsOutdatedKey: "true"}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		sensorPod("pod-a", "node-a", outdated),
+		sensorPod("pod-b", "node-b", outdated),
+		sensorPod("pod-c", "node-c", outdated),
+		sensorPod("pod-d", "node-d", outdated),
+		sensorPod("pod-e", "node-e", nil),
+	).Build()
+	r := &FalconNodeSensorReconciler{Client: c, Reader: c, Scheme: scheme}
+
+	// 40% of 5 pods allows 2 unavailable pods
+	pending, err := r.restartRetaggedSensorPods(context.Background(), []*appsv1.DaemonSet{ds}, logr.Discard())
+	if err != nil {
+		t.Fatalf("restartRetaggedSensorPods() error = %v", err)
+	}
+	if !pending {
+		t.Error("restartRetaggedSensorPods() = false with pods left to restart, want true")
+	}
+	if got := len(podsWithAnnotation(t, c, common.FalconTagsOutdatedKey)); got != 2 {
+		t.Errorf("restartRetaggedSensorPods() left %d marked pods, want 2", got)
+	}
+
+	// The restarted pods are not available yet
+	ds.Status.NumberUnavailable = 2
+	if _, err := r.restartRetaggedSensorPods(context.Background(), []*appsv1.DaemonSet{ds}, logr.Discard()); err != nil {
+		t.Fatalf("restartRetaggedSensorPods() error = %v", err)
+	}
+	if got := len(podsWithAnnotation(t, c, common.FalconTagsOutdatedKey)); got != 2 {
+		t.Errorf("restartRetaggedSensorPods() left %d marked pods with no pod available to restart, want 2", got)
+	}
+
+	ds.Status.NumberUnavailable = 0
+	pending, err = r.restartRetaggedSensorPods(context.Background(), []*appsv1.DaemonSet{ds}, logr.Discard())
+	if err != nil {
+		t.Fatalf("restartRetaggedSensorPods() error = %v", err)
+	}
+	if pending || len(podsWithAnnotation(t, c, common.FalconTagsOutdatedKey)) != 0 {
+		t.Error("restartRetaggedSensorPods() did not restart the remaining marked pods")
+	}
+}
+
+func sensorPod(name, nodeName string, annotations map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "falcon-system",
+			Labels:      common.CRLabels("daemonset", "falcon-node-sensor", common.FalconKernelSensor),
+			Annotations: annotations,
+		},
+		Spec: corev1.PodSpec{NodeName: nodeName},
+	}
+}
+
+// podsWithAnnotation returns the names of the pods with the annotation set to true
+func podsWithAnnotation(t *testing.T, c client.Client, annotation string) []string {
+	pods := corev1.PodList{}
+	if err := c.List(context.Background(), &pods); err != nil {
+		t.Fatal(err)
+	}
+
+	names := []string{}
+	for _, pod := range pods.Items {
+		if pod.Annotations[annotation] == "true" {
+			names = append(names, pod.Name)
+		}
+	}
+
+	return names
+}
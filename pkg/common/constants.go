@@ -12,6 +12,8 @@ const (
 	FalconDaemonsetInitBinary               = "/opt/CrowdStrike/falcon-daemonset-init -i"
 	FalconDaemonsetConfigureClusterIdBinary = "/opt/CrowdStrike/configure-cluster-id"
 	FalconDaemonsetCleanupBinary            = "/opt/CrowdStrike/falcon-daemonset-init -u"
+	FalconCtlBinary                         = "/opt/CrowdStrike/falconctl"
+	FalconNodeTagsDir                       = "/etc/falcon-tags"
 	FalconContainerProbePath                = "/live"
 	FalconAdmissionClientStartupProbePath   = "/startz"
 	FalconAdmissionClientLivenessProbePath  = "/livez"
@@ -25,14 +27,22 @@ const (
 	FalconImageAnalyzerAgentService         = "iar-agent-service"
 	FalconImageAnalyzerHTTPSName            = "service-port"
 
-	FalconInstanceNameKey    = "crowdstrike.com/name"
-	FalconInstanceKey        = "crowdstrike.com/instance"
-	FalconComponentKey       = "crowdstrike.com/component"
-	FalconManagedByKey       = "crowdstrike.com/managed-by"
-	FalconPartOfKey          = "crowdstrike.com/part-of"
-	FalconProviderKey        = "crowdstrike.com/provider"
-	FalconCreatedKey         = "crowdstrike.com/created-by"
-	FalconAdmissionReviewKey = "falcon.crowdstrike.com/admission-review"
+	FalconInstanceNameKey        = "crowdstrike.com/name"
+	FalconInstanceKey            = "crowdstrike.com/instance"
+	FalconComponentKey           = "crowdstrike.com/component"
+	FalconManagedByKey           = "crowdstrike.com/managed-by"
+	FalconPartOfKey              = "crowdstrike.com/part-of"
+	FalconProviderKey            = "crowdstrike.com/provider"
+	FalconCreatedKey             = "crowdstrike.com/created-by"
+	FalconAdmissionReviewKey     = "falcon.crowdstrike.com/admission-review"
+	FalconPodTemplateOverrideKey = "falcon.crowdstrike.com/pod-template-override"
+	FalconForceFinalizeKey       = "falcon.crowdstrike.com/force-finalize"
+	FalconNodePoolKey            = "falcon.crowdstrike.com/node-pool"
+	FalconNodeBackendKey         = "falcon.crowdstrike.com/backend"
+	FalconNodeSelectedBackendKey = "falcon.crowdstrike.com/selected-backend"
+	FalconTagTemplatesKey        = "falcon.crowdstrike.com/tag-templates"
+	FalconTagsOutdatedKey        = "falcon.crowdstrike.com/tags-outdated"
+	FalconConfigHashKey          = "falcon.crowdstrike.com/config-hash"
 
 	FalconKernelSensor        = "kernel_sensor"
 	FalconSidecarSensor       = "container_sensor"
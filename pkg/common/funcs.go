@@ -32,6 +32,17 @@ func InitCleanupArgs() []string {
 	}
 }
 
+// InitTagsArgs waits for the sensor grouping tags of the node to be rendered by the operator, then sets them in the falconstore
+func InitTagsArgs() []string {
+	return []string{
+		"-c",
+		fmt.Sprintf("tags_file=\"%[1]s/$POD_NODE_NAME\"; i=0; "+
+			"until [ -f \"$tags_file\" ]; do if [ $i -ge 60 ]; then echo \"Sensor grouping tags of node $POD_NODE_NAME not found\"; exit 1; fi; i=$((i+1)); sleep 5; done; "+
+			"tags=$(cat \"$tags_file\"); echo \"Setting sensor grouping tags: $tags\"; "+
+			"if [ -n \"$tags\" ]; then %[2]s -s -f --tags=\"$tags\"; else %[2]s -d -f --tags; fi", FalconNodeTagsDir, FalconCtlBinary),
+	}
+}
+
 func CleanupSleep() []string {
 	return []string{
 		"-c",
@@ -0,0 +1,107 @@
+package falcon_api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/hosts"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+)
+
+const (
+	// hostsFilterBatch is the number of hostnames per query, which keeps the filter expressions within the URL length limits
+	hostsFilterBatch = 100
+	hostsPageLimit   = int64(1000)
+	hostsFields      = "device_id,cid,hostname,last_seen,agent_version"
+)
+
+// Hosts returns the hosts registered in the Falcon platform whose hostname is one of the given hostnames
+func Hosts(ctx context.Context, client *client.CrowdStrikeAPISpecification, hostnames []string) ([]*models.DeviceapiDeviceSwagger, error) {
+	devices := []*models.DeviceapiDeviceSwagger{}
+	for start := 0; start < len(hostnames); start += hostsFilterBatch {
+		quoted := []string{}
+		for _, hostname := range hostnames[start:min(start+hostsFilterBatch, len(hostnames))] {
+			quoted = append(quoted, fmt.Sprintf("'%s'", hostname))
+		}
+
+		filter := fmt.Sprintf("hostname:[%s]", strings.Join(quoted, ","))
+		fields := hostsFields
+		limit := hostsPageLimit
+		var offset *string
+		for {
+			res, err := client.Hosts.CombinedDevicesByFilter(&hosts.CombinedDevicesByFilterParams{
+				Context: ctx,
+				Filter:  &filter,
+				Fields:  &fields,
+				Limit:   &limit,
+				Offset:  offset,
+			})
+			if err != nil {
+				return nil, errorHint(err, "Could not query hosts from CrowdStrike Falcon API")
+			}
+
+			payload := res.GetPayload()
+			if err = falcon.AssertNoError(payload.Errors); err != nil {
+				return nil, fmt.Errorf("Error reported when querying hosts from CrowdStrike Falcon API: %v", err)
+			}
+			devices = append(devices, payload.Resources...)
+
+			if payload.Meta == nil || payload.Meta.Pagination == nil || payload.Meta.Pagination.Next == "" {
+				break
+			}
+			next := payload.Meta.Pagination.Next
+			offset = &next
+		}
+	}
+
+	return devices, nil
+}
+
+// HideHosts hides the given hosts from the Falcon console
+func HideHosts(ctx context.Context, client *client.CrowdStrikeAPISpecification, aids []string) error {
+	res, err := client.Hosts.PerformActionV2(&hosts.PerformActionV2Params{
+		Context:    ctx,
+		ActionName: "hide_host",
+		Body:       &models.MsaEntityActionRequestV2{Ids: aids},
+	})
+	if err != nil {
+		return errorHint(err, "Could not hide hosts with CrowdStrike Falcon API")
+	}
+	if err = falcon.AssertNoError(res.GetPayload().Errors); err != nil {
+		return fmt.Errorf("Error reported when hiding hosts with CrowdStrike Falcon API: %v", err)
+	}
+
+	return nil
+}
+
+// TagHosts adds the given sensor grouping tags to the hosts
+func TagHosts(ctx context.Context, client *client.CrowdStrikeAPISpecification, aids []string, tags []string) error {
+	action := "add"
+	groupingTags := []string{}
+	for _, tag := range tags {
+		groupingTags = append(groupingTags, "FalconGroupingTags/"+tag)
+	}
+
+	ok, accepted, err := client.Hosts.UpdateDeviceTags(&hosts.UpdateDeviceTagsParams{
+		Context: ctx,
+		Body:    &models.DeviceapiUpdateDeviceTagsRequestV1{Action: &action, DeviceIds: aids, Tags: groupingTags},
+	})
+	if err != nil {
+		return errorHint(err, "Could not tag hosts with CrowdStrike Falcon API")
+	}
+
+	payload := &models.DeviceapiUpdateDeviceTagsSwaggerV1{}
+	if ok != nil {
+		payload = ok.GetPayload()
+	} else if accepted != nil {
+		payload = accepted.GetPayload()
+	}
+	if err = falcon.AssertNoError(payload.Errors); err != nil {
+		return fmt.Errorf("Error reported when tagging hosts with CrowdStrike Falcon API: %v", err)
+	}
+
+	return nil
+}
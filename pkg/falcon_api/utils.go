@@ -3,6 +3,7 @@ package falcon_api
 import (
 	"fmt"
 	"github.com/crowdstrike/gofalcon/falcon/client/falcon_container"
+	"github.com/crowdstrike/gofalcon/falcon/client/hosts"
 	"github.com/crowdstrike/gofalcon/falcon/client/oauth2"
 	"github.com/crowdstrike/gofalcon/falcon/client/sensor_download"
 )
@@ -13,6 +14,10 @@ func errorHint(err error, extraDescription string) error {
 		return fmt.Errorf("Insufficient CrowdStrike privileges, please grant [Falcon Images Download: Read] to CrowdStrike API Key. Error was: %s", err)
 	case *sensor_download.GetSensorInstallersCCIDByQueryForbidden:
 		return fmt.Errorf("Insufficient CrowdStrike privileges, please grant [Sensor Download: Read] to CrowdStrike API Key. Error was: %s", err)
+	case *hosts.CombinedDevicesByFilterForbidden:
+		return fmt.Errorf("Insufficient CrowdStrike privileges, please grant [Hosts: Read] to CrowdStrike API Key. Error was: %s", err)
+	case *hosts.PerformActionV2Forbidden, *hosts.UpdateDeviceTagsForbidden:
+		return fmt.Errorf("Insufficient CrowdStrike privileges, please grant [Hosts: Write] to CrowdStrike API Key. Error was: %s", err)
 	case *oauth2.Oauth2AccessTokenForbidden:
 		if e.Payload != nil && len(e.Payload.Errors) == 1 && e.Payload.Errors[0] != nil && e.Payload.Errors[0].Message != nil && *e.Payload.Errors[0].Message == "access denied, authorization failed" {
 			return fmt.Errorf("Please check the settings of IP-based allowlisting in CrowdStrike Falcon Console. %s", e)
@@ -0,0 +1,310 @@
+package gcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
+)
+
+const (
+	artifactRegistryAPI = "https://artifactregistry.googleapis.com/v1"
+
+	// artifactRegistryTimeout bounds each request to the Artifact Registry API
+	artifactRegistryTimeout = 30 * time.Second
+
+	// ArtifactRegistryRequeueInterval is the interval after which the callers check the creation of a repository again
+	ArtifactRegistryRequeueInterval = 10 * time.Second
+)
+
+// ErrRepositoryCreating is returned while the creation of an Artifact Registry repository is in progress
+var ErrRepositoryCreating = errors.New("Artifact Registry repository is being created")
+
+// IsRepositoryCreating reports whether the error is caused by the creation of an Artifact Registry repository still in progress
+func IsRepositoryCreating(err error) bool {
+	return errors.Is(err, ErrRepositoryCreating)
+}
+
+// ArtifactRepository represents Google Artifact Registry repository
+type ArtifactRepository struct {
+	Name   string `json:"name"`
+	Format string `json:"format"`
+
+	// RepositoryUri is the docker URI prefix for the images stored in the repository
+	RepositoryUri string `json:"-"`
+}
+
+// artifactRegistryOperation represents the long-running operation returned by the creation of a repository
+type artifactRegistryOperation struct {
+	Name  string `json:"name"`
+	Done  bool   `json:"done"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// artifactRegistryOperations records the long-running operations creating repositories, keyed by repository, so that their completion is
+// checked by the next reconciliation rather than awaited
+type artifactRegistryOperations struct {
+	mu  sync.Mutex
+	ops map[string]string
+}
+
+// pendingOperations holds the repository creations shared by all the clients of the Artifact Registry API
+var pendingOperations = &artifactRegistryOperations{}
+
+func (o *artifactRegistryOperations) get(repo string) (string, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	op, ok := o.ops[repo]
+	return op, ok
+}
+
+func (o *artifactRegistryOperations) record(repo, op string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.ops == nil {
+		o.ops = map[string]string{}
+	}
+	o.ops[repo] = op
+}
+
+func (o *artifactRegistryOperations) forget(repo string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	delete(o.ops, repo)
+}
+
+// ArtifactRegistry is a client of the Artifact Registry API
+type ArtifactRegistry struct {
+	// BaseURL is the endpoint of the Artifact Registry API
+	BaseURL string
+
+	// Token is the OAuth2 access token used to authenticate to the API
+	Token string
+
+	// HTTPClient sends the requests to the API
+	HTTPClient *http.Client
+
+	operations *artifactRegistryOperations
+}
+
+// NewArtifactRegistry returns a client of the Artifact Registry API authenticated with the given access token
+func NewArtifactRegistry(token string) *ArtifactRegistry {
+	return &ArtifactRegistry{
+		BaseURL:    artifactRegistryAPI,
+		Token:      token,
+		HTTPClient: &http.Client{Timeout: artifactRegistryTimeout},
+		operations: pendingOperations,
+	}
+}
+
+// ArtifactRegistryHost returns docker registry host serving the given Artifact Registry location
+func ArtifactRegistryHost(location string) string {
+	return location + "-docker.pkg.dev"
+}
+
+func artifactRegistryUri(location, projectId, name string) string {
+	return fmt.Sprintf("%s/%s/%s", ArtifactRegistryHost(location), projectId, name)
+}
+
+func (ar *ArtifactRegistry) request(ctx context.Context, method, uri string, body []byte) (*http.Response, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, uri, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Add("Authorization", "Bearer "+ar.Token)
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := ar.HTTPClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	return resp, respBody, err
+}
+
+// getRepo describes the repository of the given name, returning nil when it does not exist
+func (ar *ArtifactRegistry) getRepo(ctx context.Context, parent, name string) (*ArtifactRepository, error) {
+	resp, body, err := ar.request(ctx, http.MethodGet, parent+"/"+url.PathEscape(name), nil)
+	if err != nil {
+		return nil, fmt.Errorf("Could not describe Artifact Registry repository %s: %v", name, err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("Could not describe Artifact Registry repository %s: %s: %s", name, resp.Status, string(body))
+	}
+
+	repo := ArtifactRepository{}
+	if err := json.Unmarshal(body, &repo); err != nil {
+		return nil, fmt.Errorf("Could not parse Artifact Registry repository %s: %v", name, err)
+	}
+	if repo.Format != "" && repo.Format != "DOCKER" {
+		return nil, fmt.Errorf("Artifact Registry repository %s has format %s, expected DOCKER", name, repo.Format)
+	}
+
+	return &repo, nil
+}
+
+// getOperation fetches the current state of a long-running operation
+func (ar *ArtifactRegistry) getOperation(ctx context.Context, name string) (*artifactRegistryOperation, error) {
+	resp, body, err := ar.request(ctx, http.MethodGet, ar.BaseURL+"/"+name, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not get operation %s: %s: %s", name, resp.Status, string(body))
+	}
+
+	op := artifactRegistryOperation{}
+	if err := json.Unmarshal(body, &op); err != nil {
+		return nil, fmt.Errorf("could not parse operation %s: %v", name, err)
+	}
+
+	return &op, nil
+}
+
+// operationError returns the error of a completed long-running operation
+func operationError(op *artifactRegistryOperation) error {
+	if op.Error != nil {
+		return fmt.Errorf("operation %s failed with code %d: %s", op.Name, op.Error.Code, op.Error.Message)
+	}
+
+	return nil
+}
+
+// UpsertRepo makes sure docker repository of the given name exists within Artifact Registry. The creation of a missing repository is
+// started and recorded without being awaited: ErrRepositoryCreating is returned until the repository exists, and the callers requeue.
+func (ar *ArtifactRegistry) UpsertRepo(ctx context.Context, projectId, location, name string) (*ArtifactRepository, error) {
+	parent := fmt.Sprintf("%s/projects/%s/locations/%s/repositories", ar.BaseURL, url.PathEscape(projectId), url.PathEscape(location))
+	key := fmt.Sprintf("projects/%s/locations/%s/repositories/%s", projectId, location, name)
+
+	repo, err := ar.getRepo(ctx, parent, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if repo == nil {
+		if err := ar.createRepo(ctx, parent, key, name); err != nil {
+			return nil, err
+		}
+
+		repo, err = ar.getRepo(ctx, parent, name)
+		if err != nil {
+			return nil, err
+		}
+		if repo == nil {
+			return nil, fmt.Errorf("Artifact Registry repository %s was not found after its creation", name)
+		}
+	}
+
+	ar.operations.forget(key)
+	repo.RepositoryUri = artifactRegistryUri(location, projectId, name)
+	return repo, nil
+}
+
+// createRepo starts the creation of the repository, or checks the creation started by a previous call. It returns ErrRepositoryCreating
+// while the creation is in progress, and nil once it is done.
+func (ar *ArtifactRegistry) createRepo(ctx context.Context, parent, key, name string) error {
+	if opName, ok := ar.operations.get(key); ok {
+		op, err := ar.getOperation(ctx, opName)
+		if err != nil {
+			return fmt.Errorf("Could not create Artifact Registry repository %s: %v", name, err)
+		}
+		if !op.Done {
+			return fmt.Errorf("%w: %s", ErrRepositoryCreating, name)
+		}
+
+		ar.operations.forget(key)
+		if err := operationError(op); err != nil {
+			return fmt.Errorf("Could not create Artifact Registry repository %s: %v", name, err)
+		}
+
+		return nil
+	}
+
+	payload, err := json.Marshal(ArtifactRepository{Format: "DOCKER"})
+	if err != nil {
+		return err
+	}
+
+	resp, body, err := ar.request(ctx, http.MethodPost, parent+"?repositoryId="+url.QueryEscape(name), payload)
+	if err != nil {
+		return fmt.Errorf("Could not create Artifact Registry repository %s: %v", name, err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		op := artifactRegistryOperation{}
+		if err := json.Unmarshal(body, &op); err != nil {
+			return fmt.Errorf("Could not parse creation of Artifact Registry repository %s: %v", name, err)
+		}
+		if op.Done {
+			if err := operationError(&op); err != nil {
+				return fmt.Errorf("Could not create Artifact Registry repository %s: %v", name, err)
+			}
+			return nil
+		}
+
+		ar.operations.record(key, op.Name)
+		return fmt.Errorf("%w: %s", ErrRepositoryCreating, name)
+	case http.StatusConflict:
+		// The repository is being created concurrently, it is described again on the next call
+		return fmt.Errorf("%w: %s", ErrRepositoryCreating, name)
+	default:
+		return fmt.Errorf("Could not create Artifact Registry repository %s: %s: %s", name, resp.Status, string(body))
+	}
+}
+
+// UpsertArtifactRegistryRepo creates Artifact Registry repository within the GCP project this workload is running in, using the credentials of the workload
+func UpsertArtifactRegistryRepo(ctx context.Context, location, name string) (*ArtifactRepository, error) {
+	projectId, err := GetProjectID()
+	if err != nil {
+		return nil, fmt.Errorf("Cannot get GCP Project ID: %v", err)
+	}
+
+	token, err := GetAccessToken()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to obtain GCP access token. Please make sure that kubernetes service account falcon-operator is bound to GCP service account using Workload Identity. Error was: %v", err)
+	}
+
+	data, err := NewArtifactRegistry(token).UpsertRepo(ctx, projectId, location, name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to upsert Artifact Registry repository: %w", err)
+	}
+
+	return data, nil
+}
+
+// ArtifactRegistryImageUri returns the docker URI of the image within the Artifact Registry repository of the registry spec,
+// creating the repository when it does not exist. The error wraps ErrRepositoryCreating until the repository is created.
+func ArtifactRegistryImageUri(ctx context.Context, registry falconv1alpha1.RegistrySpec, image string) (string, error) {
+	if registry.GarLocation == nil {
+		return "", fmt.Errorf("Cannot push Falcon Image locally to GAR. gar_location was not specified")
+	}
+
+	repo, err := UpsertArtifactRegistryRepo(ctx, *registry.GarLocation, registry.GarRepositoryName())
+	if err != nil {
+		return "", fmt.Errorf("Cannot get target docker URI for GAR repository: %w", err)
+	}
+
+	return repo.RepositoryUri + "/" + image, nil
+}
@@ -0,0 +1,126 @@
+package gcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRepositoryPath = "/projects/my-project/locations/us-central1/repositories"
+
+func testArtifactRegistry(server *httptest.Server) *ArtifactRegistry {
+	ar := NewArtifactRegistry("token")
+	ar.BaseURL = server.URL
+	ar.HTTPClient = server.Client()
+	ar.operations = &artifactRegistryOperations{}
+	return ar
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	require.NoError(t, json.NewEncoder(w).Encode(v))
+}
+
+func TestUpsertRepo_WithExistingRepository(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, testRepositoryPath+"/falcon-sensor", r.URL.Path)
+		writeJSON(t, w, map[string]string{"name": "projects/my-project/locations/us-central1/repositories/falcon-sensor", "format": "DOCKER"})
+	}))
+	defer server.Close()
+
+	repo, err := testArtifactRegistry(server).UpsertRepo(context.Background(), "my-project", "us-central1", "falcon-sensor")
+	require.NoError(t, err)
+	assert.Equal(t, "projects/my-project/locations/us-central1/repositories/falcon-sensor", repo.Name)
+	assert.Equal(t, "us-central1-docker.pkg.dev/my-project/falcon-sensor", repo.RepositoryUri)
+}
+
+func TestUpsertRepo_WithNonDockerRepository(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, map[string]string{"name": "falcon-sensor", "format": "MAVEN"})
+	}))
+	defer server.Close()
+
+	_, err := testArtifactRegistry(server).UpsertRepo(context.Background(), "my-project", "us-central1", "falcon-sensor")
+	assert.ErrorContains(t, err, "expected DOCKER")
+}
+
+func TestUpsertRepo_WithMissingRepository(t *testing.T) {
+	created := false
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == testRepositoryPath+"/falcon-sensor":
+			if !created {
+				http.Error(w, "not found", http.StatusNotFound)
+				return
+			}
+			writeJSON(t, w, map[string]string{"name": "projects/my-project/locations/us-central1/repositories/falcon-sensor", "format": "DOCKER"})
+		case r.Method == http.MethodPost && r.URL.Path == testRepositoryPath:
+			assert.Equal(t, "falcon-sensor", r.URL.Query().Get("repositoryId"))
+			body := ArtifactRepository{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "DOCKER", body.Format)
+			writeJSON(t, w, map[string]interface{}{"name": "projects/my-project/locations/us-central1/operations/op", "done": false})
+		case r.Method == http.MethodGet && r.URL.Path == "/projects/my-project/locations/us-central1/operations/op":
+			polls++
+			created = polls > 1
+			writeJSON(t, w, map[string]interface{}{"name": "projects/my-project/locations/us-central1/operations/op", "done": created})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.Error(w, "unexpected", http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	// The creation is started by the first call and checked once by each of the following calls, without being awaited
+	ar := testArtifactRegistry(server)
+	for i := 0; i < 2; i++ {
+		_, err := ar.UpsertRepo(context.Background(), "my-project", "us-central1", "falcon-sensor")
+		require.ErrorIs(t, err, ErrRepositoryCreating)
+	}
+	assert.Equal(t, 1, polls)
+
+	repo, err := ar.UpsertRepo(context.Background(), "my-project", "us-central1", "falcon-sensor")
+	require.NoError(t, err)
+	assert.Equal(t, 2, polls)
+	assert.Equal(t, "us-central1-docker.pkg.dev/my-project/falcon-sensor", repo.RepositoryUri)
+
+	_, recorded := ar.operations.get("projects/my-project/locations/us-central1/repositories/falcon-sensor")
+	assert.False(t, recorded, "the completed operation is still recorded")
+}
+
+func TestUpsertRepo_WithFailedCreation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			http.Error(w, "not found", http.StatusNotFound)
+		case http.MethodPost:
+			writeJSON(t, w, map[string]interface{}{
+				"name":  "projects/my-project/locations/us-central1/operations/op",
+				"done":  true,
+				"error": map[string]interface{}{"code": 7, "message": "permission denied"},
+			})
+		}
+	}))
+	defer server.Close()
+
+	_, err := testArtifactRegistry(server).UpsertRepo(context.Background(), "my-project", "us-central1", "falcon-sensor")
+	assert.ErrorContains(t, err, "permission denied")
+}
+
+func TestUpsertRepo_WithDescribeError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	_, err := testArtifactRegistry(server).UpsertRepo(context.Background(), "my-project", "us-central1", "falcon-sensor")
+	assert.ErrorContains(t, err, "403")
+}
@@ -3,8 +3,12 @@ package gcp
 import (
 	"io"
 	"net/http"
+	"time"
 )
 
+// metadataClient queries the GCP metadata server, which answers quickly when the workload runs on GCP
+var metadataClient = &http.Client{Timeout: 10 * time.Second}
+
 // Get project-id of the GCP project within which this workload is running in
 func GetProjectID() (string, error) {
 	// curl -s "http://metadata.google.internal/computeMetadata/v1/project/project-id" -H "Metadata-Flavor: Google"
@@ -13,7 +17,7 @@ func GetProjectID() (string, error) {
 		return "", err
 	}
 	req.Header.Add("Metadata-Flavor", "Google")
-	resp, err := (&http.Client{}).Do(req)
+	resp, err := metadataClient.Do(req)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,47 @@
+package gcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+type metadataToken struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+	TokenType   string `json:"token_type"`
+}
+
+// Get OAuth2 access token of the service account bound to this workload (Workload Identity or node service account)
+func GetAccessToken() (string, error) {
+	// curl -s "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token" -H "Metadata-Flavor: Google"
+	req, err := http.NewRequest("GET", "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token", nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Add("Metadata-Flavor", "Google")
+	resp, err := metadataClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Cannot fetch access token from GCP metadata server: %s: %s", resp.Status, string(body))
+	}
+
+	token := metadataToken{}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", fmt.Errorf("Cannot parse access token from GCP metadata server: %v", err)
+	}
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("GCP metadata server returned empty access token")
+	}
+
+	return token.AccessToken, nil
+}
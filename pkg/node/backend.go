@@ -0,0 +1,94 @@
+package node
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/crowdstrike/falcon-operator/pkg/common"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	BackendKernel = "kernel"
+	BackendBPF    = "bpf"
+	// BackendAuto selects the backend of the sensor per node
+	BackendAuto = "auto"
+	// BackendUnsupported labels the nodes not supported by any backend of the sensor
+	BackendUnsupported = "unsupported"
+)
+
+var (
+	kernelVersionRegex = regexp.MustCompile(`^(\d+)\.(\d+)`)
+
+	// Minimum kernel of the eBPF backend
+	minBPFKernel = [2]int{5, 8}
+	// Enterprise Linux distributions backport the eBPF features used by the sensor to their 4.18 kernels
+	minBackportedBPFKernel = [2]int{4, 18}
+	backportedBPFOSImages  = []string{"Red Hat Enterprise Linux", "CentOS", "Rocky Linux", "AlmaLinux", "Oracle Linux"}
+	// Minimum kernel of the kernel backend
+	minKernelModeKernel = [2]int{3, 10}
+
+	supportedArchitectures = []string{"amd64", "arm64"}
+)
+
+// SelectBackend returns the sensor backend supported by the node, based on its kernel version, OS image and architecture.
+// The backend can be forced with the falcon.crowdstrike.com/backend node label. An empty backend is returned, along with
+// the reason, when the sensor does not support the node.
+func SelectBackend(node *corev1.Node) (string, string) {
+	switch backend := node.Labels[common.FalconNodeBackendKey]; backend {
+	case BackendKernel, BackendBPF:
+		return backend, ""
+	}
+
+	info := node.Status.NodeInfo
+	// Nodes that did not report their system info yet run the default eBPF backend until they are classified
+	if info.KernelVersion == "" {
+		return BackendBPF, ""
+	}
+
+	if info.Architecture != "" && !slices.Contains(supportedArchitectures, info.Architecture) {
+		return "", fmt.Sprintf("architecture %s is not supported", info.Architecture)
+	}
+
+	kernel, ok := parseKernelVersion(info.KernelVersion)
+	if !ok {
+		return "", fmt.Sprintf("unable to parse kernel version %s", info.KernelVersion)
+	}
+
+	switch {
+	case !kernelOlder(kernel, minBPFKernel):
+		return BackendBPF, ""
+	case !kernelOlder(kernel, minBackportedBPFKernel) && slices.ContainsFunc(backportedBPFOSImages, func(image string) bool { return strings.HasPrefix(info.OSImage, image) }):
+		return BackendBPF, ""
+	case !kernelOlder(kernel, minKernelModeKernel):
+		return BackendKernel, ""
+	}
+
+	return "", fmt.Sprintf("kernel %s (%s) is older than %d.%d", info.KernelVersion, info.OSImage, minKernelModeKernel[0], minKernelModeKernel[1])
+}
+
+// parseKernelVersion returns the major and minor versions of a kernel release such as 5.15.0-1051-azure
+func parseKernelVersion(release string) ([2]int, bool) {
+	match := kernelVersionRegex.FindStringSubmatch(release)
+	if match == nil {
+		return [2]int{}, false
+	}
+
+	major, err := strconv.Atoi(match[1])
+	if err != nil {
+		return [2]int{}, false
+	}
+	minor, err := strconv.Atoi(match[2])
+	if err != nil {
+		return [2]int{}, false
+	}
+
+	return [2]int{major, minor}, true
+}
+
+func kernelOlder(kernel, minimum [2]int) bool {
+	return kernel[0] < minimum[0] || (kernel[0] == minimum[0] && kernel[1] < minimum[1])
+}
@@ -0,0 +1,77 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/crowdstrike/falcon-operator/pkg/common"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSelectBackend(t *testing.T) {
+	tests := []struct {
+		name        string
+		labels      map[string]string
+		info        corev1.NodeSystemInfo
+		wantBackend string
+		wantReason  bool
+	}{
+		{
+			name:        "recent kernel",
+			info:        corev1.NodeSystemInfo{KernelVersion: "5.15.0-1051-azure", OSImage: "Ubuntu 22.04.4 LTS", Architecture: "amd64"},
+			wantBackend: BackendBPF,
+		},
+		{
+			name:        "enterprise linux kernel with eBPF backports",
+			info:        corev1.NodeSystemInfo{KernelVersion: "4.18.0-513.5.1.el8_9.x86_64", OSImage: "Red Hat Enterprise Linux CoreOS 414.92", Architecture: "amd64"},
+			wantBackend: BackendBPF,
+		},
+		{
+			name:        "legacy kernel",
+			info:        corev1.NodeSystemInfo{KernelVersion: "4.14.336-257.562.amzn2.x86_64", OSImage: "Amazon Linux 2", Architecture: "amd64"},
+			wantBackend: BackendKernel,
+		},
+		{
+			name:       "unsupported kernel",
+			info:       corev1.NodeSystemInfo{KernelVersion: "2.6.32-754.el6.x86_64", OSImage: "CentOS release 6.10", Architecture: "amd64"},
+			wantReason: true,
+		},
+		{
+			name:       "unsupported architecture",
+			info:       corev1.NodeSystemInfo{KernelVersion: "5.14.0-362.el9.s390x", OSImage: "Red Hat Enterprise Linux 9.3", Architecture: "s390x"},
+			wantReason: true,
+		},
+		{
+			name:       "unparsable kernel",
+			info:       corev1.NodeSystemInfo{KernelVersion: "custom", Architecture: "arm64"},
+			wantReason: true,
+		},
+		{
+			name:        "system info not reported yet",
+			wantBackend: BackendBPF,
+		},
+		{
+			name:        "backend forced by the node label",
+			labels:      map[string]string{common.FalconNodeBackendKey: BackendKernel},
+			info:        corev1.NodeSystemInfo{KernelVersion: "6.1.0", Architecture: "amd64"},
+			wantBackend: BackendKernel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: tt.labels},
+				Status:     corev1.NodeStatus{NodeInfo: tt.info},
+			}
+
+			backend, reason := SelectBackend(node)
+			if backend != tt.wantBackend {
+				t.Errorf("SelectBackend() backend = %s, want %s", backend, tt.wantBackend)
+			}
+			if (reason != "") != tt.wantReason {
+				t.Errorf("SelectBackend() reason = %q, want a reason: %v", reason, tt.wantReason)
+			}
+		})
+	}
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/crowdstrike/falcon-operator/pkg/registry/falcon_registry"
 	"github.com/crowdstrike/falcon-operator/pkg/registry/pulltoken"
 	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/models"
 	"github.com/go-logr/logr"
 )
 
@@ -25,6 +27,7 @@ type ConfigCache struct {
 	imageUri        string
 	nodesensor      *falconv1alpha1.FalconNodeSensor
 	falconApiConfig *falcon.ApiConfig
+	falconClient    *client.CrowdStrikeAPISpecification
 }
 
 func NewConfigCache(ctx context.Context, nodesensor *falconv1alpha1.FalconNodeSensor) (*ConfigCache, error) {
@@ -80,6 +83,59 @@ func (cc *ConfigCache) GetPullToken(ctx context.Context) ([]byte, error) {
 	return pulltoken.CrowdStrike(ctx, cc.falconApiConfig)
 }
 
+// FalconAPIConfigured returns true when the FalconNodeSensor provides CrowdStrike API credentials
+func (cc *ConfigCache) FalconAPIConfigured() bool {
+	return cc.falconApiConfig != nil
+}
+
+// GetHosts returns the hosts registered in the Falcon platform with one of the given hostnames
+func (cc *ConfigCache) GetHosts(ctx context.Context, hostnames []string) ([]*models.DeviceapiDeviceSwagger, error) {
+	client, err := cc.apiClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return falcon_api.Hosts(ctx, client, hostnames)
+}
+
+// HideHosts hides the given hosts from the Falcon console
+func (cc *ConfigCache) HideHosts(ctx context.Context, aids []string) error {
+	client, err := cc.apiClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	return falcon_api.HideHosts(ctx, client, aids)
+}
+
+// TagHosts adds the given sensor grouping tags to the hosts
+func (cc *ConfigCache) TagHosts(ctx context.Context, aids []string, tags []string) error {
+	client, err := cc.apiClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	return falcon_api.TagHosts(ctx, client, aids, tags)
+}
+
+// apiClient returns the CrowdStrike API client of the cache, which is created on first use and shared by the following calls
+func (cc *ConfigCache) apiClient(ctx context.Context) (*client.CrowdStrikeAPISpecification, error) {
+	if cc.falconApiConfig == nil {
+		return nil, ErrFalconAPINotConfigured
+	}
+
+	if cc.falconClient == nil {
+		cc.falconApiConfig.Context = ctx
+		falconClient, err := falcon.NewClient(cc.falconApiConfig)
+		if err != nil {
+			return nil, err
+		}
+		cc.falconClient = falconClient
+	}
+
+	return cc.falconClient, nil
+}
+
 func (cc *ConfigCache) SensorEnvVars() map[string]string {
 	sensorConfig := common.MakeSensorEnvMap(cc.nodesensor.Spec.Falcon.FalconSensor)
 	if cc.cid != "" {
@@ -91,10 +147,21 @@ func (cc *ConfigCache) SensorEnvVars() map[string]string {
 	if cc.nodesensor.Spec.Falcon.Cloud != "" {
 		sensorConfig["FALCONCTL_OPT_CLOUD"] = cc.nodesensor.Spec.Falcon.Cloud
 	}
+	// With tag templates, the tags of each node are applied by an init container instead
+	if len(cc.nodesensor.GetTagTemplates()) > 0 {
+		delete(sensorConfig, "FALCONCTL_OPT_TAGS")
+	}
 
 	return sensorConfig
 }
 
+// WithNodeSensor returns a copy of the cache rendering the sensor configuration of another FalconNodeSensor, such as the one derived for a node pool
+func (cc *ConfigCache) WithNodeSensor(nodesensor *falconv1alpha1.FalconNodeSensor) *ConfigCache {
+	cache := *cc
+	cache.nodesensor = nodesensor
+	return &cache
+}
+
 func (cc *ConfigCache) getFalconImage(ctx context.Context, nodesensor *falconv1alpha1.FalconNodeSensor) (string, error) {
 	if nodesensor.Spec.Node.Image != "" {
 		return nodesensor.Spec.Node.Image, nil
@@ -156,7 +223,7 @@ func versionLock(nodesensor *falconv1alpha1.FalconNodeSensor) bool {
 		return false
 	}
 
-	return nodesensor.Spec.Node.Version == nil || strings.Contains(*nodesensor.Status.Sensor, *nodesensor.Spec.Node.Version)
+	return falcon_registry.VersionMatches(*nodesensor.Status.Sensor, nodesensor.Spec.Node.Version)
 }
 
 func ConfigCacheTest(cid string, imageUri string, nodeTest *falconv1alpha1.FalconNodeSensor, apiConfig *falcon.ApiConfig) *ConfigCache {
@@ -132,6 +132,30 @@ func TestSensorEnvVars(t *testing.T) {
 	}
 }
 
+func TestWithNodeSensor(t *testing.T) {
+	poolSensor := config.nodesensor.DeepCopy()
+	poolSensor.Spec.Node.Backend = "bpf"
+
+	poolConfig := config.WithNodeSensor(poolSensor)
+	if poolConfig.CID() != config.CID() {
+		t.Errorf("WithNodeSensor() CID = %s, want %s", poolConfig.CID(), config.CID())
+	}
+
+	if got := poolConfig.SensorEnvVars()["FALCONCTL_OPT_BACKEND"]; got != "bpf" {
+		t.Errorf("WithNodeSensor() backend = %s, want bpf", got)
+	}
+
+	if config.nodesensor == poolSensor {
+		t.Error("WithNodeSensor() modified the original cache")
+	}
+
+	poolSensor.Spec.Falcon.Tags = []string{"static"}
+	poolSensor.Spec.Node.TagTemplates = []string{"{{ .NodePool }}"}
+	if _, ok := config.WithNodeSensor(poolSensor).SensorEnvVars()["FALCONCTL_OPT_TAGS"]; ok {
+		t.Error("WithNodeSensor() sets FALCONCTL_OPT_TAGS with tag templates, want the tags applied per node")
+	}
+}
+
 func TestNewConfigCache(t *testing.T) {
 	want := ConfigCache{cid: falconCID, nodesensor: &falconNode}
 
@@ -0,0 +1,64 @@
+package node
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// invalidTagChars matches the characters not allowed in sensor grouping tags
+var invalidTagChars = regexp.MustCompile(`[^a-zA-Z0-9/_-]`)
+
+// TagData holds the cluster and node metadata sensor grouping tag templates are rendered with
+type TagData struct {
+	ClusterName string
+	Namespace   string
+	NodeName    string
+	NodePool    string
+	Labels      map[string]string
+}
+
+// TagTemplates are the parsed sensor grouping tag templates of a FalconNodeSensor
+type TagTemplates []*template.Template
+
+// ParseTagTemplates parses the sensor grouping tag templates of a FalconNodeSensor
+func ParseTagTemplates(templates []string) (TagTemplates, error) {
+	parsed := TagTemplates{}
+	for _, text := range templates {
+		tmpl, err := template.New("tag").Option("missingkey=zero").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sensor grouping tag template %q: %v", text, err)
+		}
+		parsed = append(parsed, tmpl)
+	}
+
+	return parsed, nil
+}
+
+// Render returns the sensor grouping tags of a node as a comma separated list: the static tags followed by the rendered templates.
+// Characters not allowed in tags are replaced with '-', and empty or duplicate tags are dropped.
+func (tt TagTemplates) Render(static []string, data TagData) (string, error) {
+	tags := []string{}
+	add := func(tag string) {
+		tag = invalidTagChars.ReplaceAllString(strings.TrimSpace(tag), "-")
+		if tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	for _, tag := range static {
+		add(tag)
+	}
+
+	for _, tmpl := range tt {
+		var tag strings.Builder
+		if err := tmpl.Execute(&tag, data); err != nil {
+			return "", fmt.Errorf("unable to render sensor grouping tag template for node %s: %v", data.NodeName, err)
+		}
+		add(tag.String())
+	}
+
+	return strings.Join(tags, ","), nil
+}
@@ -0,0 +1,43 @@
+package node
+
+import (
+	"testing"
+)
+
+func TestTagTemplatesRender(t *testing.T) {
+	templates, err := ParseTagTemplates([]string{
+		"{{ .ClusterName }}",
+		"ns/{{ .Namespace }}",
+		"zone/{{ index .Labels \"topology.kubernetes.io/zone\" }}",
+		"{{ index .Labels \"node.kubernetes.io/instance-type\" }}",
+		"{{ .NodePool }}",
+		"{{ index .Labels \"missing\" }}",
+	})
+	if err != nil {
+		t.Fatalf("ParseTagTemplates() error = %v", err)
+	}
+
+	data := TagData{
+		ClusterName: "prod cluster",
+		Namespace:   "falcon-system",
+		NodeName:    "node-a",
+		Labels: map[string]string{
+			"topology.kubernetes.io/zone":      "us-east-1a",
+			"node.kubernetes.io/instance-type": "m5.large",
+		},
+	}
+
+	got, err := templates.Render([]string{"static", "prod-cluster"}, data)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := "static,prod-cluster,ns/falcon-system,zone/us-east-1a,m5-large"
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	if _, err := ParseTagTemplates([]string{"{{ .ClusterName "}); err == nil {
+		t.Error("ParseTagTemplates() error = nil for an invalid template, want an error")
+	}
+}
@@ -172,3 +172,39 @@ func ECRCredentials(token string) (Credentials, error) {
 		password: token[4:],
 	}, nil
 }
+
+type gar struct {
+	host     string
+	password string
+}
+
+func (g *gar) Name() string {
+	return "GAR Token from GCP metadata server"
+}
+
+func (g *gar) Pulltoken() ([]byte, error) {
+	newData, err := Dockerfile(g.host, "oauth2accesstoken", g.password)
+	if err != nil {
+		return nil, fmt.Errorf("Could not create pull token for GAR: %s", err)
+	}
+	return newData, nil
+}
+
+func (g *gar) DestinationContext() (*types.SystemContext, error) {
+	return &types.SystemContext{
+		DockerAuthConfig: &types.DockerAuthConfig{
+			Username: "oauth2accesstoken",
+			Password: g.password,
+		},
+	}, nil
+}
+
+func GARCredentials(host, token string) (Credentials, error) {
+	if token == "" {
+		return nil, fmt.Errorf("Could not use empty GCP access token for GAR")
+	}
+	return &gar{
+		host:     host,
+		password: token,
+	}, nil
+}
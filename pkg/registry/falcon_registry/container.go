@@ -13,15 +13,13 @@ func (reg *FalconRegistry) LastContainerTag(ctx context.Context, sensorType falc
 		return "", err
 	}
 
-	return lastTag(ctx, systemContext, reg.imageUriContainer(sensorType), func(tag string) bool {
+	return lastTag(ctx, systemContext, reg.imageUriContainer(sensorType), versionRequested, func(tag string) bool {
 		tagContains := ".container"
 		if sensorType == falcon.ImageSensor || sensorType == falcon.KacSensor {
 			tagContains = ""
 		}
 
-		return (tag[0] >= '0' && tag[0] <= '9' &&
-			strings.Contains(tag, tagContains) &&
-			(versionRequested == nil || strings.HasPrefix(tag, *versionRequested)))
+		return tag[0] >= '0' && tag[0] <= '9' && strings.Contains(tag, tagContains)
 	})
 }
 
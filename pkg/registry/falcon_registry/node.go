@@ -3,7 +3,6 @@ package falcon_registry
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/crowdstrike/gofalcon/falcon"
 	"golang.org/x/mod/semver"
@@ -20,18 +19,17 @@ func (reg *FalconRegistry) LastNodeTag(ctx context.Context, versionRequested *st
 	}
 
 	filter := func(tag string) bool {
-		return (tag[0] >= '0' && tag[0] <= '9' &&
-			(versionRequested == nil || strings.HasPrefix(tag, *versionRequested)))
+		return tag[0] >= '0' && tag[0] <= '9'
 	}
 
 	if reg.falconOverrideRepo != "" {
 		imageUri := reg.falconOverrideRepo
-		return lastTag(ctx, systemContext, imageUri, filter)
+		return lastTag(ctx, systemContext, imageUri, versionRequested, filter)
 	}
 
-	tag, err := lastTag(ctx, systemContext, UnifiedImageURINode(reg.falconCloud), filter)
+	tag, err := lastTag(ctx, systemContext, UnifiedImageURINode(reg.falconCloud), versionRequested, filter)
 	if err != nil {
-		return lastTag(ctx, systemContext, ImageURINode(reg.falconCloud), filter)
+		return lastTag(ctx, systemContext, ImageURINode(reg.falconCloud), versionRequested, filter)
 	}
 
 	return tag, err
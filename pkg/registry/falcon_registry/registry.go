@@ -91,7 +91,13 @@ func imageReference(imageUri, tag string) (types.ImageReference, error) {
 	return docker.ParseReference(fmt.Sprintf("//%s:%s", imageUri, tag))
 }
 
-func lastTag(ctx context.Context, systemContext *types.SystemContext, imageUri string, filter func(string) bool) (string, error) {
+// lastTag returns the most recent image tag passing the filter and satisfying the requested version
+func lastTag(ctx context.Context, systemContext *types.SystemContext, imageUri string, versionRequested *string, filter func(string) bool) (string, error) {
+	request, err := ParseVersionRequest(versionRequested)
+	if err != nil {
+		return "", err
+	}
+
 	ref, err := reference.ParseNormalizedNamed(imageUri)
 	if err != nil {
 		return "", err
@@ -117,7 +123,19 @@ func lastTag(ctx context.Context, systemContext *types.SystemContext, imageUri s
 		})
 	}
 
-	return guessLastTag(tags, filter)
+	if request.isConstraint() {
+		filteredTags := []string{}
+		for _, tag := range tags {
+			if filter(tag) {
+				filteredTags = append(filteredTags, tag)
+			}
+		}
+		return request.selectTag(filteredTags)
+	}
+
+	return guessLastTag(tags, func(tag string) bool {
+		return filter(tag) && request.hasPrefix(tag)
+	})
 }
 
 func guessLastTag(tags []string, filter func(string) bool) (string, error) {
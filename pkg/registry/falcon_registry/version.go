@@ -0,0 +1,251 @@
+package falcon_registry
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	version "github.com/hashicorp/go-version"
+)
+
+var (
+	// latestRelease matches the requests of the latest sensor release, or of the Nth release before it
+	latestRelease = regexp.MustCompile(`^latest(?:-(\d+))?$`)
+
+	// constraintOperators matches the operators of version constraints, and the spaces following them
+	constraintOperators = regexp.MustCompile(`(>=|<=|!=|~>|=|>|<)\s*`)
+)
+
+// VersionRequest is the sensor version requested in the spec of a custom resource. It is either a prefix of the image tag, such as
+// "7.14" or "7.14.0-17005", or a version constraint evaluated against the image tags: a range such as ">=7.10 <7.14", a wildcard
+// such as "7.x", "latest", or "latest-N" for the Nth release before the latest one.
+type VersionRequest struct {
+	prefix      string
+	constraints version.Constraints
+	previous    int
+	relative    bool
+}
+
+// ParseVersionRequest parses the requested sensor version. A nil request selects the latest image tag.
+func ParseVersionRequest(requested *string) (*VersionRequest, error) {
+	if requested == nil {
+		return &VersionRequest{}, nil
+	}
+
+	text := strings.TrimSpace(*requested)
+	if match := latestRelease.FindStringSubmatch(text); match != nil {
+		previous := 0
+		if match[1] != "" {
+			previous, _ = strconv.Atoi(match[1])
+		}
+		return &VersionRequest{previous: previous, relative: true}, nil
+	}
+
+	if !isVersionConstraint(text) {
+		return &VersionRequest{prefix: text}, nil
+	}
+
+	expressions := []string{}
+	for _, field := range strings.FieldsFunc(constraintOperators.ReplaceAllString(text, "$1"), func(r rune) bool { return r == ',' || r == ' ' }) {
+		expression, err := constraintExpression(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sensor version constraint %q: %v", text, err)
+		}
+		if expression != "" {
+			expressions = append(expressions, expression)
+		}
+	}
+
+	if len(expressions) == 0 {
+		return &VersionRequest{}, nil
+	}
+
+	constraints, err := version.NewConstraint(strings.Join(expressions, ","))
+	if err != nil {
+		return nil, fmt.Errorf("invalid sensor version constraint %q: %v", text, err)
+	}
+
+	return &VersionRequest{constraints: constraints}, nil
+}
+
+// VersionMatches reports whether the sensor version of an image tag still satisfies the requested version. Requests of a release
+// before the latest one never match, since they depend on the image tags available in the registry.
+func VersionMatches(tag string, requested *string) bool {
+	request, err := ParseVersionRequest(requested)
+	if err != nil {
+		return false
+	}
+
+	return request.matches(tag)
+}
+
+func (vr *VersionRequest) matches(tag string) bool {
+	switch {
+	case vr.relative:
+		return vr.previous == 0
+	case vr.constraints != nil:
+		core, _, ok := tagVersion(tag)
+		return ok && vr.constraints.Check(core)
+	default:
+		return strings.Contains(tag, vr.prefix)
+	}
+}
+
+// isConstraint reports whether the request is evaluated against the versions of the image tags rather than matched as a prefix
+func (vr *VersionRequest) isConstraint() bool {
+	return vr.relative || vr.constraints != nil
+}
+
+// hasPrefix reports whether the image tag starts with the requested prefix
+func (vr *VersionRequest) hasPrefix(tag string) bool {
+	return strings.HasPrefix(tag, vr.prefix)
+}
+
+// selectTag returns the most recent image tag satisfying the version constraint
+func (vr *VersionRequest) selectTag(tags []string) (string, error) {
+	candidates := []string{}
+	for _, tag := range tags {
+		if core, _, ok := tagVersion(tag); ok && (vr.constraints == nil || vr.constraints.Check(core)) {
+			candidates = append(candidates, tag)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return tagLess(candidates[j], candidates[i]) })
+
+	if vr.relative {
+		releases := []string{}
+		for _, tag := range candidates {
+			core, _, _ := tagVersion(tag)
+			if release := releaseOf(core); !slices.Contains(releases, release) {
+				releases = append(releases, release)
+			}
+		}
+		if vr.previous >= len(releases) {
+			return "", fmt.Errorf("Could not find the sensor release latest-%d in the CrowdStrike registry. Releases were: %+v", vr.previous, releases)
+		}
+
+		for _, tag := range candidates {
+			if core, _, _ := tagVersion(tag); releaseOf(core) == releases[vr.previous] {
+				return tag, nil
+			}
+		}
+	}
+
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("Could not find image tag satisfying the version constraint %s in the CrowdStrike registry. Tags were: %+v", vr.constraints, tags)
+	}
+
+	return candidates[0], nil
+}
+
+// isVersionConstraint reports whether the requested version is a constraint expression rather than a prefix of the image tag:
+// it starts with an operator, or contains a wildcard
+func isVersionConstraint(text string) bool {
+	if strings.IndexAny(text, "<>=!~") == 0 {
+		return true
+	}
+
+	for _, field := range strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ' ' }) {
+		for _, segment := range strings.Split(field, ".") {
+			if isWildcard(segment) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// constraintExpression converts a field of a version constraint to a constraint understood by hashicorp/go-version, expanding
+// wildcards such as 7.x to the range >= 7, < 8
+func constraintExpression(field string) (string, error) {
+	operator := constraintOperators.FindString(field)
+	if !strings.HasPrefix(field, operator) {
+		return "", fmt.Errorf("unexpected operator in %q", field)
+	}
+	text := strings.TrimPrefix(strings.TrimPrefix(field, operator), "v")
+
+	segments := strings.Split(text, ".")
+	wildcard := -1
+	for i, segment := range segments {
+		if isWildcard(segment) {
+			wildcard = i
+			break
+		}
+	}
+
+	if wildcard < 0 {
+		if _, err := version.NewVersion(text); err != nil {
+			return "", err
+		}
+		if operator == "" {
+			operator = "="
+		}
+		return operator + text, nil
+	}
+
+	if operator != "" && operator != "=" {
+		return "", fmt.Errorf("wildcard %q cannot be used with operator %s", field, operator)
+	}
+	if wildcard == 0 {
+		return "", nil
+	}
+
+	lower, err := version.NewVersion(strings.Join(segments[:wildcard], "."))
+	if err != nil {
+		return "", err
+	}
+	upper := append([]int{}, lower.Segments()[:wildcard]...)
+	upper[wildcard-1]++
+
+	return fmt.Sprintf(">=%s,<%s", lower, joinSegments(upper)), nil
+}
+
+func isWildcard(segment string) bool {
+	return segment == "x" || segment == "X" || segment == "*"
+}
+
+// tagVersion returns the version and the build number of an image tag, such as 7.14.0 and 17005 for 7.14.0-17005-1.falcon-linux.Release.US-1
+func tagVersion(tag string) (*version.Version, int, bool) {
+	text, build, _ := strings.Cut(tag, "-")
+	core, err := version.NewVersion(text)
+	if err != nil {
+		return nil, 0, false
+	}
+
+	digits := build
+	if i := strings.IndexFunc(build, func(r rune) bool { return r < '0' || r > '9' }); i >= 0 {
+		digits = build[:i]
+	}
+	number, _ := strconv.Atoi(digits)
+
+	return core, number, true
+}
+
+// tagLess orders image tags by version, then by build number
+func tagLess(a, b string) bool {
+	coreA, buildA, _ := tagVersion(a)
+	coreB, buildB, _ := tagVersion(b)
+	if !coreA.Equal(coreB) {
+		return coreA.LessThan(coreB)
+	}
+	if buildA != buildB {
+		return buildA < buildB
+	}
+	return a < b
+}
+
+// releaseOf returns the sensor release of a version, made of its major and minor segments
+func releaseOf(v *version.Version) string {
+	return joinSegments(v.Segments()[:2])
+}
+
+func joinSegments(segments []int) string {
+	parts := []string{}
+	for _, segment := range segments {
+		parts = append(parts, strconv.Itoa(segment))
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,92 @@
+package falcon_registry
+
+import (
+	"testing"
+)
+
+func TestVersionRequestSelectTag(t *testing.T) {
+	tags := []string{
+		"7.13.0-16604-1.falcon-linux.Release.US-1",
+		"7.14.0-16703-1.falcon-linux.Release.US-1",
+		"7.14.0-16807-1.falcon-linux.Release.US-1",
+		"7.9.0-15507-1.falcon-linux.Release.US-1",
+		"7.10.0-15808-1.falcon-linux.Release.US-1",
+		"6.58.0-15002-1.falcon-linux.Release.US-1",
+	}
+
+	tests := []struct {
+		requested string
+		want      string
+	}{
+		{"latest", "7.14.0-16807-1.falcon-linux.Release.US-1"},
+		{"latest-1", "7.13.0-16604-1.falcon-linux.Release.US-1"},
+		{"latest-3", "7.9.0-15507-1.falcon-linux.Release.US-1"},
+		{">=7.10 <7.14", "7.13.0-16604-1.falcon-linux.Release.US-1"},
+		{">= 7.9, < 7.10", "7.9.0-15507-1.falcon-linux.Release.US-1"},
+		{"6.x", "6.58.0-15002-1.falcon-linux.Release.US-1"},
+		{"7.10.x", "7.10.0-15808-1.falcon-linux.Release.US-1"},
+		{"~> 7.10.0", "7.10.0-15808-1.falcon-linux.Release.US-1"},
+	}
+
+	for _, tt := range tests {
+		request, err := ParseVersionRequest(&tt.requested)
+		if err != nil {
+			t.Errorf("ParseVersionRequest(%q) error = %v", tt.requested, err)
+			continue
+		}
+		if !request.isConstraint() {
+			t.Errorf("ParseVersionRequest(%q) is not a version constraint", tt.requested)
+			continue
+		}
+
+		got, err := request.selectTag(tags)
+		if err != nil {
+			t.Errorf("selectTag(%q) error = %v", tt.requested, err)
+		} else if got != tt.want {
+			t.Errorf("selectTag(%q) = %q, want %q", tt.requested, got, tt.want)
+		}
+	}
+
+	for _, requested := range []string{"latest-6", ">=8.0"} {
+		request, err := ParseVersionRequest(&requested)
+		if err != nil {
+			t.Fatalf("ParseVersionRequest(%q) error = %v", requested, err)
+		}
+		if _, err := request.selectTag(tags); err == nil {
+			t.Errorf("selectTag(%q) error = nil, want an error", requested)
+		}
+	}
+
+	for _, requested := range []string{">=7.x", ">=", ">=7.10 =<7.15"} {
+		if _, err := ParseVersionRequest(&requested); err == nil {
+			t.Errorf("ParseVersionRequest(%q) error = nil, want an error", requested)
+		}
+	}
+}
+
+func TestVersionMatches(t *testing.T) {
+	tag := "7.14.0-16807-1.falcon-linux.Release.US-1"
+	version := func(v string) *string { return &v }
+
+	tests := []struct {
+		requested *string
+		want      bool
+	}{
+		{nil, true},
+		{version("7.14"), true},
+		{version("7.14.0-16807"), true},
+		{version("7.13"), false},
+		{version("some sensor"), false},
+		{version("7.x"), true},
+		{version(">=7.10 <7.14"), false},
+		{version("latest"), true},
+		{version("latest-1"), false},
+		{version(">=7.x"), false},
+	}
+
+	for _, tt := range tests {
+		if got := VersionMatches(tag, tt.requested); got != tt.want {
+			t.Errorf("VersionMatches(%v) = %v, want %v", tt.requested, got, tt.want)
+		}
+	}
+}
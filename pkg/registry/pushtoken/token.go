@@ -6,13 +6,14 @@ import (
 
 	falconv1alpha1 "github.com/crowdstrike/falcon-operator/api/falcon/v1alpha1"
 	"github.com/crowdstrike/falcon-operator/pkg/aws"
+	"github.com/crowdstrike/falcon-operator/pkg/gcp"
 	"github.com/crowdstrike/falcon-operator/pkg/k8s_utils"
 	"github.com/crowdstrike/falcon-operator/pkg/registry/auth"
 )
 
 // GetCredentials returns container pushtoken authentication information that can be used to authenticate container push requests.
-func GetCredentials(ctx context.Context, registryType falconv1alpha1.RegistryTypeSpec, query k8s_utils.KubeQuerySecretsMethod) (auth.Credentials, error) {
-	switch registryType {
+func GetCredentials(ctx context.Context, registry falconv1alpha1.RegistrySpec, query k8s_utils.KubeQuerySecretsMethod) (auth.Credentials, error) {
+	switch registry.Type {
 	case falconv1alpha1.RegistryTypeECR:
 		cfg, err := aws.NewConfig()
 		if err != nil {
@@ -23,6 +24,15 @@ func GetCredentials(ctx context.Context, registryType falconv1alpha1.RegistryTyp
 			return nil, err
 		}
 		return auth.ECRCredentials(string(token))
+	case falconv1alpha1.RegistryTypeGAR:
+		if registry.GarLocation == nil {
+			return nil, fmt.Errorf("Cannot push Falcon Image locally to GAR. gar_location was not specified")
+		}
+		token, err := gcp.GetAccessToken()
+		if err != nil {
+			return nil, err
+		}
+		return auth.GARCredentials(gcp.ArtifactRegistryHost(*registry.GarLocation), token)
 	default:
 		secrets, err := query(ctx)
 		if err != nil {